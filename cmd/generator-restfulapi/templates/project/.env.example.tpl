@@ -79,6 +79,8 @@ TELEMETRYFLOW_ENABLE_TRACES=true
 TELEMETRYFLOW_ENABLE_METRICS=true
 TELEMETRYFLOW_ENABLE_LOGS=true
 TELEMETRYFLOW_ENABLE_EXEMPLARS=true
+TELEMETRYFLOW_RUNTIME_METRICS=true
+TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL=10s
 
 # Performance Settings
 TELEMETRYFLOW_TIMEOUT=10
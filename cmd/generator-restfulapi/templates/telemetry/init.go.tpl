@@ -21,6 +21,7 @@ var client *telemetryflow.Client
 //   - TELEMETRYFLOW_SERVICE_NAME, TELEMETRYFLOW_SERVICE_VERSION
 //   - TELEMETRYFLOW_USE_V2_API, TELEMETRYFLOW_V2_ONLY
 //   - TELEMETRYFLOW_COLLECTOR_NAME, TELEMETRYFLOW_DATACENTER
+//   - TELEMETRYFLOW_RUNTIME_METRICS, TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL (Go runtime metrics, on by default)
 func Init() error {
 	var err error
 
@@ -41,6 +42,7 @@ func Init() error {
 
 	// Build client with TFO v2 API and collector identity support
 	builder := telemetryflow.NewBuilder().
+		WithRuntimeMetrics(). // TELEMETRYFLOW_RUNTIME_METRICS=false turns it off
 		WithAutoConfiguration().
 		WithInsecure(insecure).
 		WithSignals(true, true, true).
@@ -82,6 +84,7 @@ func InitWithV2Only() error {
 	insecure := os.Getenv("TELEMETRYFLOW_INSECURE") == "true"
 
 	client, err = telemetryflow.NewBuilder().
+		WithRuntimeMetrics(). // TELEMETRYFLOW_RUNTIME_METRICS=false turns it off
 		WithAutoConfiguration().
 		WithInsecure(insecure).
 		WithV2Only(). // Enable v2-only mode
@@ -129,7 +129,7 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&templateDir, "template-dir", "", "Custom template directory (uses embedded templates if not set)")
 	rootCmd.PersistentFlags().BoolVar(&noBanner, "no-banner", false, "Disable banner output")
 
-	rootCmd.AddCommand(initCmd, exampleCmd, configCmd, versionCmd)
+	rootCmd.AddCommand(initCmd, exampleCmd, configCmd, newReplayCmd(), versionCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,104 @@
+// Package main provides the TelemetryFlow SDK Generator CLI.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+)
+
+var (
+	replayConfigFile string
+	replayProtocol   string
+	replayInsecure   bool
+)
+
+// newReplayCmd creates the replay command, which uploads files written by the file exporter
+func newReplayCmd() *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use:   "replay [file or directory]...",
+		Short: "Send OTLP/JSON files to a collector",
+		Long: "Send OTLP/JSON lines files written by the SDK file exporter to a collector.\n" +
+			"Connection settings come from TELEMETRYFLOW_* environment variables, an optional\n" +
+			"config file, and the flags below, in that order of precedence (lowest first).",
+		Args: cobra.MinimumNArgs(1),
+		RunE: runReplay,
+	}
+
+	replayCmd.Flags().StringVarP(&replayConfigFile, "config", "c", "", "SDK config file (see configs/sdk-default.yaml)")
+	replayCmd.Flags().StringVarP(&endpoint, "endpoint", "e", "", "OTLP endpoint (overrides TELEMETRYFLOW_ENDPOINT)")
+	replayCmd.Flags().StringVarP(&apiKeyID, "key-id", "k", "", "TelemetryFlow API Key ID")
+	replayCmd.Flags().StringVarP(&apiKeySecret, "key-secret", "s", "", "TelemetryFlow API Key Secret")
+	replayCmd.Flags().StringVar(&replayProtocol, "protocol", "", "OTLP protocol: grpc or http (default: inferred from the endpoint)")
+	replayCmd.Flags().BoolVar(&replayInsecure, "insecure", false, "Disable TLS")
+	return replayCmd
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	builder := telemetryflow.NewBuilder().WithAutoConfiguration()
+	if replayConfigFile != "" {
+		builder.WithConfigFile(replayConfigFile)
+	}
+	if endpoint != "" {
+		builder.WithEndpoint(endpoint)
+	}
+	if apiKeyID != "" || apiKeySecret != "" {
+		builder.WithAPIKey(apiKeyID, apiKeySecret)
+	}
+	if replayProtocol != "" {
+		builder.WithProtocol(domain.Protocol(replayProtocol))
+	}
+	if cmd.Flags().Changed("insecure") {
+		builder.WithInsecure(replayInsecure)
+	}
+	if os.Getenv("TELEMETRYFLOW_SERVICE_NAME") == "" && replayConfigFile == "" {
+		builder.WithService("telemetryflow-replay", "")
+	}
+
+	// Replay always targets the OTLP endpoint, whatever exporters the environment selects
+	client, err := builder.WithExporters(domain.ExporterOTLP).Build()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	replayer := infrastructure.NewReplayer(client.Config())
+	defer func() { _ = replayer.Close() }()
+
+	results, err := replayer.ReplayPaths(ctx, args...)
+	total := 0
+	for _, result := range results {
+		fmt.Printf("📤 %s: %d %s requests\n", result.Path, result.Requests, result.Signal)
+		total += result.Requests
+	}
+	if err != nil {
+		return fmt.Errorf("replay failed after %d requests: %w", total, err)
+	}
+	fmt.Printf("✅ Replayed %d requests from %d files\n", total, len(results))
+	return nil
+}
@@ -47,6 +47,8 @@ TELEMETRYFLOW_ENABLE_TRACES={{.EnableTraces}}
 TELEMETRYFLOW_ENABLE_METRICS={{.EnableMetrics}}
 TELEMETRYFLOW_ENABLE_LOGS={{.EnableLogs}}
 TELEMETRYFLOW_ENABLE_EXEMPLARS=true
+TELEMETRYFLOW_RUNTIME_METRICS=true
+TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL=10s
 
 # -----------------------------------------------------------------------------
 # Performance Settings
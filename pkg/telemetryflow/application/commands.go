@@ -20,6 +20,8 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
@@ -44,7 +46,7 @@ type RecordMetricCommand struct {
 	Value      float64
 	Unit       string
 	Attributes map[string]interface{}
-	Timestamp  time.Time
+	Timestamp  time.Time // Optional time of the measurement (zero: observed at each collection)
 }
 
 func (*RecordMetricCommand) isCommand() {}
@@ -77,6 +79,23 @@ type RecordHistogramCommand struct {
 
 func (*RecordHistogramCommand) isCommand() {}
 
+// RecordMetricsCommand records multiple measurements as one operation
+type RecordMetricsCommand struct {
+	Measurements []Measurement
+}
+
+func (*RecordMetricsCommand) isCommand() {}
+
+// Measurement is a single value of a RecordMetricsCommand
+type Measurement struct {
+	Kind       string // gauge (default), counter, histogram
+	Name       string
+	Value      float64 // Counters take whole, non-negative increments
+	Unit       string
+	Attributes map[string]interface{}
+	Timestamp  time.Time // Optional time of a gauge value (zero: observed at each collection)
+}
+
 // ===== LOG COMMANDS =====
 
 // EmitLogCommand emits a structured log entry
@@ -84,9 +103,9 @@ type EmitLogCommand struct {
 	Severity   string
 	Message    string
 	Attributes map[string]interface{}
-	Timestamp  time.Time
-	TraceID    string // Optional trace correlation
-	SpanID     string // Optional span correlation
+	Timestamp  time.Time // Optional time of the event (zero: now)
+	TraceID    string    // Optional trace correlation
+	SpanID     string    // Optional span correlation
 }
 
 func (*EmitLogCommand) isCommand() {}
@@ -98,6 +117,38 @@ type EmitBatchLogsCommand struct {
 
 func (*EmitBatchLogsCommand) isCommand() {}
 
+// BatchResult reports the outcome of a batch command item by item
+type BatchResult struct {
+	Accepted int          // Number of items recorded
+	Errors   []BatchError // Rejected items, in batch order
+}
+
+// Err returns the errors of the rejected items, or nil when every item was accepted
+func (r *BatchResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Errors))
+	for i := range r.Errors {
+		errs[i] = r.Errors[i]
+	}
+	return errors.Join(errs...)
+}
+
+// BatchError is the error of one rejected batch item
+type BatchError struct {
+	Index int // Position of the item in the batch
+	Err   error
+}
+
+func (e BatchError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e BatchError) Unwrap() error {
+	return e.Err
+}
+
 // ===== TRACE COMMANDS =====
 
 // StartSpanCommand starts a new trace span
@@ -128,6 +179,50 @@ type AddSpanEventCommand struct {
 
 func (*AddSpanEventCommand) isCommand() {}
 
+// SetSpanAttributesCommand sets attributes on an active span, overwriting existing keys
+type SetSpanAttributesCommand struct {
+	SpanID     string
+	Attributes map[string]interface{}
+}
+
+func (*SetSpanAttributesCommand) isCommand() {}
+
+// SetSpanStatusCommand sets the status of an active span
+type SetSpanStatusCommand struct {
+	SpanID      string
+	Code        string // unset, ok, error
+	Description string // Only kept for the error status
+}
+
+func (*SetSpanStatusCommand) isCommand() {}
+
+// SetSpanNameCommand renames an active span
+type SetSpanNameCommand struct {
+	SpanID string
+	Name   string
+}
+
+func (*SetSpanNameCommand) isCommand() {}
+
+// AddSpanLinkCommand links an active span to another span, possibly of another trace
+type AddSpanLinkCommand struct {
+	SpanID        string
+	LinkedTraceID string // Hex trace ID of the linked span
+	LinkedSpanID  string // Hex span ID of the linked span
+	Attributes    map[string]interface{}
+}
+
+func (*AddSpanLinkCommand) isCommand() {}
+
+// SetSpanBaggageCommand sets a baggage member of an active span
+type SetSpanBaggageCommand struct {
+	SpanID string
+	Key    string
+	Value  string
+}
+
+func (*SetSpanBaggageCommand) isCommand() {}
+
 // ===== INITIALIZATION COMMANDS =====
 
 // InitializeSDKCommand initializes the SDK with configuration
@@ -185,6 +185,16 @@ type TraceSummary struct {
 	ErrorCount  int
 }
 
+// GetSpanBaggageQuery retrieves the baggage of an active span
+type GetSpanBaggageQuery struct {
+	SpanID string
+}
+
+// SpanBaggageResult represents the baggage of a span
+type SpanBaggageResult struct {
+	Baggage map[string]string // member values by key
+}
+
 // ===== HEALTH & STATUS QUERIES =====
 
 // GetHealthQuery checks the health of the SDK connection
@@ -196,6 +206,9 @@ type HealthQueryResult struct {
 	LastSuccess time.Time
 	LastError   error
 	Metrics     HealthMetrics
+	Endpoints   []EndpointHealth // per-signal endpoint health when failover is configured
+	Failovers   int64            // number of times exports moved to another endpoint
+	Breakers    []BreakerHealth  // per-exporter circuit breaker state when a breaker is configured
 }
 
 // HealthMetrics represents health-related metrics
@@ -206,6 +219,31 @@ type HealthMetrics struct {
 	ConnectionState string
 }
 
+// EndpointHealth represents the health of a single collector endpoint for one signal
+type EndpointHealth struct {
+	Signal              string
+	Endpoint            string
+	Priority            int  // 0 is the primary endpoint
+	Healthy             bool // false while the endpoint is skipped after a failure
+	Active              bool // true if the last successful export used this endpoint
+	ConsecutiveFailures int
+	LastSuccess         time.Time
+	LastFailure         time.Time
+	LastError           error
+}
+
+// BreakerHealth represents the circuit breaker of one exporter
+type BreakerHealth struct {
+	Signal              string
+	Exporter            string // otlp, console, file or the destination name
+	State               string // closed, open, half-open
+	ConsecutiveFailures int
+	OpenedAt            time.Time // zero unless the breaker has opened
+	RetryAt             time.Time // when an open breaker lets the next export probe the collector
+	Buffered            int       // items kept for the buffer policy
+	Dropped             int64     // items discarded while the breaker was open
+}
+
 // GetSDKStatusQuery gets the current SDK status
 type GetSDKStatusQuery struct{}
 
@@ -216,6 +254,19 @@ type SDKStatusResult struct {
 	EnabledSignals []string
 	Config         map[string]interface{}
 	Statistics     SDKStatistics
+	Memory         *MemoryStatus // nil unless a memory limiter is configured
+}
+
+// MemoryStatus represents the state of the memory limiter
+type MemoryStatus struct {
+	State         string // normal, soft, hard
+	BufferedBytes int64  // estimated memory of the spans and log records buffered by the SDK
+	HeapBytes     int64  // Go heap in use at the last check, 0 unless heap limits are set
+	SoftLimit     int64
+	HardLimit     int64
+	HeapSoftLimit int64
+	HeapHardLimit int64
+	Dropped       int64 // spans and log records dropped or not sampled because of the limits
 }
 
 // SDKStatistics represents SDK statistics
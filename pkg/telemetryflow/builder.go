@@ -20,9 +20,18 @@ package telemetryflow
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
 )
 
@@ -37,8 +46,8 @@ type Builder struct {
 	serviceVersion   string
 	environment      string
 	datacenter       string
-	protocol         domain.Protocol
-	insecure         bool
+	protocol         domain.Protocol // empty: inferred from the endpoint
+	insecure         *bool           // nil: inferred from the endpoint
 	timeout          time.Duration
 	enableMetrics    bool
 	enableLogs       bool
@@ -60,13 +69,103 @@ type Builder struct {
 	collectorHostname    string
 	collectorTags        map[string]string
 	enrichResources      bool
+
+	// Failover settings
+	failoverEndpoints     []string
+	failoverProbeInterval time.Duration
+
+	// Fan-out destinations
+	destinations []*domain.Destination
+
+	// Export tuning
+	compression    bool
+	retryEnabled   bool
+	maxRetries     int
+	retryBackoff   time.Duration
+	batchTimeout   time.Duration
+	batchMaxSize   int
+	maxRequestSize int
+
+	// Retry cap (0: twice the backoff) and per-exporter circuit breaker
+	// (threshold 0: disabled; other zero fields: domain defaults)
+	retryMaxBackoff time.Duration
+	circuitBreaker  domain.CircuitBreakerConfig
+
+	// Memory limiter (no limit set: disabled); degradation and check interval default
+	// unless set
+	memoryLimiter     domain.MemoryLimiterConfig
+	memoryDegradation bool
+
+	// Limits on explicit metric and log timestamps
+	timestampSkew domain.TimestampSkewConfig
+
+	// Log severity thresholds, sampling and de-duplication
+	logFilter domain.LogFilterConfig
+
+	// Per-signal connection overrides
+	signalSettings map[domain.SignalType]*signalOverrides
+
+	// HTTP exporter transport
+	proxyURL       string
+	proxyUsername  string
+	proxyPassword  string
+	noProxy        []string
+	httpClient     *http.Client
+	httpTransport  http.RoundTripper
+	headers        map[string]string
+	headerProvider domain.HeaderProvider
+	httpEncoding   domain.HTTPEncoding
+
+	// Exporters (nil: primary OTLP endpoint only)
+	exporters          []domain.ExporterType
+	consoleWriter      io.Writer
+	fileExporter       domain.FileExporterConfig
+	prometheusEndpoint string
+
+	// Go runtime metrics (interval 0: config default)
+	runtimeMetrics         bool
+	runtimeMetricsInterval time.Duration
+
+	// Process and host resource metrics
+	processMetrics bool
+	hostMetrics    bool
+
+	// Background error handler and export callbacks
+	errorHandler    func(error)
+	onExportSuccess []func(domain.ExportEvent)
+	onExportFailure []func(domain.ExportEvent)
+
+	// SDK self metrics (provider nil: the client's meter provider)
+	selfMetrics         bool
+	selfMetricsProvider otelmetric.MeterProvider
+
+	// In-process consumers registered next to the exporters
+	spanProcessors []sdktrace.SpanProcessor
+	metricReaders  []sdkmetric.Reader
+	logProcessors  []sdklog.Processor
+}
+
+// Placeholder connection used when the primary OTLP exporter is disabled,
+// so console-only setups need neither credentials nor a collector
+const (
+	localKeyID     = "tfk_local"
+	localKeySecret = "tfs_local"
+	localEndpoint  = "localhost:4317"
+)
+
+// signalOverrides holds the per-signal settings collected by the builder.
+// Zero values fall back to the shared connection settings.
+type signalOverrides struct {
+	endpoint    string
+	protocol    domain.Protocol
+	headers     map[string]string
+	compression *bool
+	timeout     time.Duration
 }
 
 // NewBuilder creates a new SDK builder
 func NewBuilder() *Builder {
 	return &Builder{
-		protocol:         domain.ProtocolGRPC,
-		insecure:         false,
 		timeout:          30 * time.Second,
 		enableMetrics:    true,
 		enableLogs:       true,
@@ -88,6 +187,18 @@ func NewBuilder() *Builder {
 		collectorHostname:    "",
 		collectorTags:        make(map[string]string),
 		enrichResources:      true, // enabled by default
+		// Export tuning (aligned with domain defaults)
+		compression:    true,
+		retryEnabled:   true,
+		maxRetries:     3,
+		retryBackoff:   5 * time.Second,
+		batchTimeout:   10 * time.Second,
+		batchMaxSize:   512,
+		signalSettings: make(map[domain.SignalType]*signalOverrides),
+		headers:        make(map[string]string),
+		fileExporter:   domain.DefaultFileExporterConfig(),
+		timestampSkew:  domain.DefaultTimestampSkewConfig(),
+		logFilter:      domain.DefaultLogFilterConfig(),
 	}
 }
 
@@ -113,7 +224,9 @@ func (b *Builder) WithAPIKeyFromEnv() *Builder {
 	return b
 }
 
-// WithEndpoint sets the OTLP endpoint
+// WithEndpoint sets the OTLP endpoint: a host:port, an http(s) URL with an optional
+// base path (https://gateway.example.com/otlp), or a unix socket (unix:///var/run/tfo-agent.sock).
+// URL endpoints imply the protocol and TLS defaults unless they are set explicitly.
 func (b *Builder) WithEndpoint(endpoint string) *Builder {
 	b.endpoint = endpoint
 	return b
@@ -136,6 +249,46 @@ func (b *Builder) WithService(name, version string) *Builder {
 	return b
 }
 
+// WithFailoverEndpoints sets secondary endpoints that are used, in order, when the primary endpoint fails
+func (b *Builder) WithFailoverEndpoints(endpoints ...string) *Builder {
+	b.failoverEndpoints = endpoints
+	return b
+}
+
+// WithFailoverEndpointsFromEnv reads a comma-separated list of failover endpoints from environment variable
+func (b *Builder) WithFailoverEndpointsFromEnv() *Builder {
+	value := os.Getenv("TELEMETRYFLOW_FAILOVER_ENDPOINTS")
+	if value == "" {
+		return b
+	}
+
+	var endpoints []string
+	for _, endpoint := range strings.Split(value, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	b.failoverEndpoints = endpoints
+	return b
+}
+
+// WithFailoverProbeInterval sets how long a failed endpoint is skipped before it is retried
+func (b *Builder) WithFailoverProbeInterval(interval time.Duration) *Builder {
+	b.failoverProbeInterval = interval
+	return b
+}
+
+// WithDestination adds a named destination that receives telemetry in addition to the primary endpoint.
+// Each destination has its own credentials, protocol, endpoint, signal selection and retry policy.
+func (b *Builder) WithDestination(destination *domain.Destination) *Builder {
+	if destination == nil {
+		b.errors = append(b.errors, fmt.Errorf("destination cannot be nil"))
+		return b
+	}
+	b.destinations = append(b.destinations, destination)
+	return b
+}
+
 // WithServiceFromEnv reads service info from environment variables
 func (b *Builder) WithServiceFromEnv() *Builder {
 	b.serviceName = os.Getenv("TELEMETRYFLOW_SERVICE_NAME")
@@ -190,7 +343,7 @@ func (b *Builder) WithHTTP() *Builder {
 
 // WithInsecure enables insecure connections (no TLS)
 func (b *Builder) WithInsecure(insecure bool) *Builder {
-	b.insecure = insecure
+	b.insecure = &insecure
 	return b
 }
 
@@ -263,6 +416,36 @@ func (b *Builder) WithExemplars(enabled bool) *Builder {
 	return b
 }
 
+// WithRuntimeMetrics collects Go runtime metrics (goroutines, GC pauses, memory, scheduler
+// latency) under the OpenTelemetry semantic convention names
+func (b *Builder) WithRuntimeMetrics() *Builder {
+	b.runtimeMetrics = true
+	return b
+}
+
+// WithRuntimeMetricsInterval sets how often runtime metrics are sampled (default: 10s)
+func (b *Builder) WithRuntimeMetricsInterval(interval time.Duration) *Builder {
+	b.runtimeMetricsInterval = interval
+	return b
+}
+
+// WithRuntimeMetricsFromEnv reads TELEMETRYFLOW_RUNTIME_METRICS and
+// TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL
+func (b *Builder) WithRuntimeMetricsFromEnv() *Builder {
+	if value := os.Getenv("TELEMETRYFLOW_RUNTIME_METRICS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_RUNTIME_METRICS: %w", err))
+		} else {
+			b.runtimeMetrics = enabled
+		}
+	}
+	if value := os.Getenv("TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL"); value != "" {
+		b.setDuration(&b.runtimeMetricsInterval, "TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL", value)
+	}
+	return b
+}
+
 // WithV2API enables/disables TFO Platform v2 API endpoints (aligned with tfoexporter)
 func (b *Builder) WithV2API(enabled bool) *Builder {
 	b.useV2API = enabled
@@ -294,6 +477,661 @@ func (b *Builder) WithLogsEndpoint(endpoint string) *Builder {
 	return b
 }
 
+// WithCompression enables/disables gzip compression
+func (b *Builder) WithCompression(enabled bool) *Builder {
+	b.compression = enabled
+	return b
+}
+
+// WithRetry configures retry behavior
+func (b *Builder) WithRetry(enabled bool, maxRetries int, backoff time.Duration) *Builder {
+	b.retryEnabled = enabled
+	b.maxRetries = maxRetries
+	b.retryBackoff = backoff
+	return b
+}
+
+// WithRetryMaxBackoff caps the retry backoff, which doubles after every attempt
+// (default: twice the initial backoff). Collector throttling hints may ask for longer.
+func (b *Builder) WithRetryMaxBackoff(maxBackoff time.Duration) *Builder {
+	b.retryMaxBackoff = maxBackoff
+	return b
+}
+
+// WithCircuitBreaker pauses an exporter for cooldown after failureThreshold consecutive
+// failed exports; data exported meanwhile is dropped unless WithCircuitBreakerBuffer is set.
+// A cooldown of 0 keeps the default of 30s.
+func (b *Builder) WithCircuitBreaker(failureThreshold int, cooldown time.Duration) *Builder {
+	b.circuitBreaker.FailureThreshold = failureThreshold
+	b.circuitBreaker.Cooldown = cooldown
+	return b
+}
+
+// WithCircuitBreakerBuffer keeps up to size spans or log records per exporter while its
+// circuit breaker is open, sending them once it closes (0: default of 2048)
+func (b *Builder) WithCircuitBreakerBuffer(size int) *Builder {
+	b.circuitBreaker.Policy = domain.BreakerBuffer
+	b.circuitBreaker.BufferSize = size
+	return b
+}
+
+// WithCircuitBreakerFromEnv reads TELEMETRYFLOW_CIRCUIT_BREAKER_{THRESHOLD,COOLDOWN,POLICY,BUFFER_SIZE}
+func (b *Builder) WithCircuitBreakerFromEnv() *Builder {
+	if value := os.Getenv("TELEMETRYFLOW_CIRCUIT_BREAKER_THRESHOLD"); value != "" {
+		threshold, err := strconv.Atoi(value)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_CIRCUIT_BREAKER_THRESHOLD: %w", err))
+		} else {
+			b.circuitBreaker.FailureThreshold = threshold
+		}
+	}
+	b.setDuration(&b.circuitBreaker.Cooldown, "TELEMETRYFLOW_CIRCUIT_BREAKER_COOLDOWN", os.Getenv("TELEMETRYFLOW_CIRCUIT_BREAKER_COOLDOWN"))
+	if value := os.Getenv("TELEMETRYFLOW_CIRCUIT_BREAKER_POLICY"); value != "" {
+		b.circuitBreaker.Policy = domain.BreakerPolicy(strings.ToLower(value))
+	}
+	if value := os.Getenv("TELEMETRYFLOW_CIRCUIT_BREAKER_BUFFER_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_CIRCUIT_BREAKER_BUFFER_SIZE: %w", err))
+		} else {
+			b.circuitBreaker.BufferSize = size
+		}
+	}
+	return b
+}
+
+// circuitBreakerConfig returns the circuit breaker settings, defaulting unset fields
+func (b *Builder) circuitBreakerConfig() domain.CircuitBreakerConfig {
+	cfg := domain.DefaultCircuitBreakerConfig()
+	cfg.FailureThreshold = b.circuitBreaker.FailureThreshold
+	if b.circuitBreaker.Cooldown != 0 {
+		cfg.Cooldown = b.circuitBreaker.Cooldown
+	}
+	if b.circuitBreaker.Policy != "" {
+		cfg.Policy = b.circuitBreaker.Policy
+	}
+	if b.circuitBreaker.BufferSize != 0 {
+		cfg.BufferSize = b.circuitBreaker.BufferSize
+	}
+	return cfg
+}
+
+// WithMemoryLimit limits the estimated memory of the spans and log records buffered by
+// the SDK. Above softLimit bytes new traces are sampled less and low severity logs are
+// dropped; above hardLimit bytes new spans and log records are dropped. 0 disables a limit.
+func (b *Builder) WithMemoryLimit(softLimit, hardLimit int64) *Builder {
+	b.memoryLimiter.SoftLimit = softLimit
+	b.memoryLimiter.HardLimit = hardLimit
+	return b
+}
+
+// WithHeapLimit applies the memory limiter's soft and hard levels when the Go heap in use
+// exceeds softLimit or hardLimit bytes. The heap is read at most once per second.
+func (b *Builder) WithHeapLimit(softLimit, hardLimit int64) *Builder {
+	b.memoryLimiter.HeapSoftLimit = softLimit
+	b.memoryLimiter.HeapHardLimit = hardLimit
+	return b
+}
+
+// WithMemoryLimiterDegradation sets what happens above a soft memory limit: new traces
+// are sampled at samplingRatio and log records below minSeverity are dropped
+// (default: 0.1 and "warn"; "" keeps all log records)
+func (b *Builder) WithMemoryLimiterDegradation(samplingRatio float64, minSeverity string) *Builder {
+	b.memoryLimiter.SamplingRatio = samplingRatio
+	b.memoryLimiter.MinSeverity = minSeverity
+	b.memoryDegradation = true
+	return b
+}
+
+// WithMemoryLimiterFromEnv reads TELEMETRYFLOW_MEMORY_{SOFT_LIMIT,HARD_LIMIT,HEAP_SOFT_LIMIT,
+// HEAP_HARD_LIMIT} in bytes, TELEMETRYFLOW_MEMORY_SAMPLING_RATIO,
+// TELEMETRYFLOW_MEMORY_MIN_LOG_SEVERITY and TELEMETRYFLOW_MEMORY_CHECK_INTERVAL
+func (b *Builder) WithMemoryLimiterFromEnv() *Builder {
+	limits := []struct {
+		name  string
+		limit *int64
+	}{
+		{"TELEMETRYFLOW_MEMORY_SOFT_LIMIT", &b.memoryLimiter.SoftLimit},
+		{"TELEMETRYFLOW_MEMORY_HARD_LIMIT", &b.memoryLimiter.HardLimit},
+		{"TELEMETRYFLOW_MEMORY_HEAP_SOFT_LIMIT", &b.memoryLimiter.HeapSoftLimit},
+		{"TELEMETRYFLOW_MEMORY_HEAP_HARD_LIMIT", &b.memoryLimiter.HeapHardLimit},
+	}
+	for _, l := range limits {
+		if value := os.Getenv(l.name); value != "" {
+			limit, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				b.errors = append(b.errors, fmt.Errorf("%s: %w", l.name, err))
+			} else {
+				*l.limit = limit
+			}
+		}
+	}
+	if value := os.Getenv("TELEMETRYFLOW_MEMORY_SAMPLING_RATIO"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_MEMORY_SAMPLING_RATIO: %w", err))
+		} else {
+			b.defaultMemoryDegradation()
+			b.memoryLimiter.SamplingRatio = ratio
+		}
+	}
+	if value := os.Getenv("TELEMETRYFLOW_MEMORY_MIN_LOG_SEVERITY"); value != "" {
+		b.defaultMemoryDegradation()
+		b.memoryLimiter.MinSeverity = value
+	}
+	b.setDuration(&b.memoryLimiter.CheckInterval, "TELEMETRYFLOW_MEMORY_CHECK_INTERVAL", os.Getenv("TELEMETRYFLOW_MEMORY_CHECK_INTERVAL"))
+	return b
+}
+
+// defaultMemoryDegradation sets the default degradation before one of its settings is
+// overridden on its own
+func (b *Builder) defaultMemoryDegradation() {
+	if !b.memoryDegradation {
+		defaults := domain.DefaultMemoryLimiterConfig()
+		b.memoryLimiter.SamplingRatio = defaults.SamplingRatio
+		b.memoryLimiter.MinSeverity = defaults.MinSeverity
+		b.memoryDegradation = true
+	}
+}
+
+// memoryLimiterConfig returns the memory limiter settings, defaulting the degradation
+// and check interval unless set
+func (b *Builder) memoryLimiterConfig() domain.MemoryLimiterConfig {
+	b.defaultMemoryDegradation()
+	cfg := b.memoryLimiter
+	if cfg.CheckInterval == 0 {
+		cfg.CheckInterval = domain.DefaultMemoryLimiterConfig().CheckInterval
+	}
+	return cfg
+}
+
+// WithBatchSettings configures batch export settings
+func (b *Builder) WithBatchSettings(timeout time.Duration, maxSize int) *Builder {
+	b.batchTimeout = timeout
+	b.batchMaxSize = maxSize
+	return b
+}
+
+// WithMaxRequestSize caps export requests at bytes when encoded. Larger requests are
+// split; a single span, data point or log record over the cap has its attribute and body
+// values truncated, or is dropped. gRPC requests also respect the max send message size.
+func (b *Builder) WithMaxRequestSize(bytes int) *Builder {
+	b.maxRequestSize = bytes
+	return b
+}
+
+// WithTimestampSkew limits how far explicit timestamps passed to RecordMetricAt and LogAt
+// may be from the current time (0: unlimited; default: 24h past, 5m future)
+func (b *Builder) WithTimestampSkew(maxPast, maxFuture time.Duration) *Builder {
+	b.timestampSkew = domain.TimestampSkewConfig{MaxPast: maxPast, MaxFuture: maxFuture}
+	return b
+}
+
+// WithTimestampSkewFromEnv reads TELEMETRYFLOW_TIMESTAMP_MAX_PAST and
+// TELEMETRYFLOW_TIMESTAMP_MAX_FUTURE
+func (b *Builder) WithTimestampSkewFromEnv() *Builder {
+	b.setDuration(&b.timestampSkew.MaxPast, "TELEMETRYFLOW_TIMESTAMP_MAX_PAST", os.Getenv("TELEMETRYFLOW_TIMESTAMP_MAX_PAST"))
+	b.setDuration(&b.timestampSkew.MaxFuture, "TELEMETRYFLOW_TIMESTAMP_MAX_FUTURE", os.Getenv("TELEMETRYFLOW_TIMESTAMP_MAX_FUTURE"))
+	return b
+}
+
+// ===== LOG FILTER =====
+
+// WithMinLogSeverity drops logs below severity, e.g. "info", unless their logger has its
+// own minimum set with WithLoggerSeverity
+func (b *Builder) WithMinLogSeverity(severity string) *Builder {
+	b.logFilter.MinSeverity = severity
+	return b
+}
+
+// WithLoggerSeverity drops the logs of a logger below severity. The logger of a log is
+// its "logger.name" attribute; logger also applies to its dotted children, e.g. "db" to
+// "db.pool".
+func (b *Builder) WithLoggerSeverity(logger, severity string) *Builder {
+	if b.logFilter.LoggerSeverities == nil {
+		b.logFilter.LoggerSeverities = make(map[string]string)
+	}
+	b.logFilter.LoggerSeverities[logger] = severity
+	return b
+}
+
+// WithLogSampling emits the first initial logs with the same severity, logger and message
+// template within each interval, then every thereafter-th of them. Numbers in messages
+// are ignored when comparing them.
+func (b *Builder) WithLogSampling(interval time.Duration, initial, thereafter int) *Builder {
+	b.logFilter.SamplingInterval = interval
+	b.logFilter.SamplingInitial = initial
+	b.logFilter.SamplingThereafter = thereafter
+	return b
+}
+
+// WithLogDeduplication suppresses the repeats of a log within window, then emits a
+// "suppressed N similar messages" log for them
+func (b *Builder) WithLogDeduplication(window time.Duration) *Builder {
+	b.logFilter.DedupWindow = window
+	return b
+}
+
+// WithLogFilterFromEnv reads TELEMETRYFLOW_LOG_MIN_SEVERITY,
+// TELEMETRYFLOW_LOG_LOGGER_SEVERITIES (e.g. "db=debug,http=warn"),
+// TELEMETRYFLOW_LOG_SAMPLING_{INTERVAL,INITIAL,THEREAFTER} and
+// TELEMETRYFLOW_LOG_DEDUP_WINDOW
+func (b *Builder) WithLogFilterFromEnv() *Builder {
+	if value := os.Getenv("TELEMETRYFLOW_LOG_MIN_SEVERITY"); value != "" {
+		b.logFilter.MinSeverity = value
+	}
+	if value := os.Getenv("TELEMETRYFLOW_LOG_LOGGER_SEVERITIES"); value != "" {
+		severities, err := parseLoggerSeverities(value)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_LOG_LOGGER_SEVERITIES: %w", err))
+		}
+		for logger, severity := range severities {
+			b.WithLoggerSeverity(logger, severity)
+		}
+	}
+	b.setDuration(&b.logFilter.SamplingInterval, "TELEMETRYFLOW_LOG_SAMPLING_INTERVAL", os.Getenv("TELEMETRYFLOW_LOG_SAMPLING_INTERVAL"))
+	for _, l := range []struct {
+		name  string
+		count *int
+	}{
+		{"TELEMETRYFLOW_LOG_SAMPLING_INITIAL", &b.logFilter.SamplingInitial},
+		{"TELEMETRYFLOW_LOG_SAMPLING_THEREAFTER", &b.logFilter.SamplingThereafter},
+	} {
+		if value := os.Getenv(l.name); value != "" {
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				b.errors = append(b.errors, fmt.Errorf("%s: %w", l.name, err))
+			} else {
+				*l.count = count
+			}
+		}
+	}
+	b.setDuration(&b.logFilter.DedupWindow, "TELEMETRYFLOW_LOG_DEDUP_WINDOW", os.Getenv("TELEMETRYFLOW_LOG_DEDUP_WINDOW"))
+	return b
+}
+
+// parseLoggerSeverities parses comma-separated logger=severity pairs
+func parseLoggerSeverities(value string) (map[string]string, error) {
+	severities := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		logger, severity, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(logger) == "" {
+			return severities, fmt.Errorf("invalid logger severity %q, expected logger=severity", pair)
+		}
+		severities[strings.TrimSpace(logger)] = strings.TrimSpace(severity)
+	}
+	return severities, nil
+}
+
+// ===== PER-SIGNAL SETTINGS =====
+
+// signal returns the overrides for a signal, creating them if needed
+func (b *Builder) signal(signal domain.SignalType) *signalOverrides {
+	settings, ok := b.signalSettings[signal]
+	if !ok {
+		settings = &signalOverrides{}
+		b.signalSettings[signal] = settings
+	}
+	return settings
+}
+
+// WithSignalEndpoint sends a single signal to its own endpoint: a host:port, an http(s)
+// URL with an optional base path (https://gateway.example.com/otlp), or a unix socket
+// (unix:///var/run/tfo-agent.sock). An http(s) URL implies the HTTP protocol unless
+// WithSignalProtocol is set, and its scheme decides TLS.
+func (b *Builder) WithSignalEndpoint(signal domain.SignalType, endpoint string) *Builder {
+	b.signal(signal).endpoint = endpoint
+	return b
+}
+
+// WithSignalProtocol sets the OTLP protocol (grpc or http) for a single signal
+func (b *Builder) WithSignalProtocol(signal domain.SignalType, protocol domain.Protocol) *Builder {
+	b.signal(signal).protocol = protocol
+	return b
+}
+
+// WithSignalHeader adds a header sent only with a single signal's exports
+func (b *Builder) WithSignalHeader(signal domain.SignalType, key, value string) *Builder {
+	settings := b.signal(signal)
+	if settings.headers == nil {
+		settings.headers = make(map[string]string)
+	}
+	settings.headers[key] = value
+	return b
+}
+
+// WithSignalCompression enables/disables gzip compression for a single signal
+func (b *Builder) WithSignalCompression(signal domain.SignalType, enabled bool) *Builder {
+	b.signal(signal).compression = &enabled
+	return b
+}
+
+// WithSignalTimeout sets the export timeout for a single signal
+func (b *Builder) WithSignalTimeout(signal domain.SignalType, timeout time.Duration) *Builder {
+	b.signal(signal).timeout = timeout
+	return b
+}
+
+// WithSignalSettingsFromEnv reads per-signal settings from environment variables:
+// TELEMETRYFLOW_{TRACES,METRICS,LOGS}_OTLP_{ENDPOINT,PROTOCOL,HEADERS,COMPRESSION,TIMEOUT}.
+// Headers are comma-separated key=value pairs; compression is gzip or none.
+func (b *Builder) WithSignalSettingsFromEnv() *Builder {
+	for _, signal := range []domain.SignalType{domain.SignalTraces, domain.SignalMetrics, domain.SignalLogs} {
+		prefix := "TELEMETRYFLOW_" + strings.ToUpper(string(signal)) + "_OTLP_"
+
+		if endpoint := os.Getenv(prefix + "ENDPOINT"); endpoint != "" {
+			b.WithSignalEndpoint(signal, endpoint)
+		}
+		if protocol := os.Getenv(prefix + "PROTOCOL"); protocol != "" {
+			b.WithSignalProtocol(signal, domain.Protocol(strings.ToLower(protocol)))
+		}
+		if headers := os.Getenv(prefix + "HEADERS"); headers != "" {
+			parsed, err := parseHeaders(headers)
+			if err != nil {
+				b.errors = append(b.errors, fmt.Errorf("%sHEADERS: %w", prefix, err))
+			}
+			for key, value := range parsed {
+				b.WithSignalHeader(signal, key, value)
+			}
+		}
+		if compression := os.Getenv(prefix + "COMPRESSION"); compression != "" {
+			enabled, err := parseCompression(compression)
+			if err != nil {
+				b.errors = append(b.errors, fmt.Errorf("%sCOMPRESSION: %w", prefix, err))
+			} else {
+				b.WithSignalCompression(signal, enabled)
+			}
+		}
+		if timeout := os.Getenv(prefix + "TIMEOUT"); timeout != "" {
+			d, err := time.ParseDuration(timeout)
+			if err != nil {
+				b.errors = append(b.errors, fmt.Errorf("%sTIMEOUT: %w", prefix, err))
+			} else {
+				b.WithSignalTimeout(signal, d)
+			}
+		}
+	}
+	return b
+}
+
+// parseHeaders parses comma-separated key=value pairs
+func parseHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return headers, fmt.Errorf("invalid header %q, expected key=value", pair)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return headers, nil
+}
+
+// parseCompression parses a compression name (gzip or none)
+func parseCompression(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "gzip":
+		return true, nil
+	case "none":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported compression: %s", value)
+	}
+}
+
+// ===== HTTP TRANSPORT =====
+
+// WithProxy routes HTTP exports through a proxy (http, https or socks5 URL).
+// Without it the standard HTTP_PROXY/HTTPS_PROXY environment variables apply.
+func (b *Builder) WithProxy(proxyURL string) *Builder {
+	b.proxyURL = proxyURL
+	return b
+}
+
+// WithProxyAuth sets the credentials used to authenticate with the proxy
+func (b *Builder) WithProxyAuth(username, password string) *Builder {
+	b.proxyUsername = username
+	b.proxyPassword = password
+	return b
+}
+
+// WithNoProxy sets the hosts that bypass the proxy (host names, .domain suffixes, IPs and CIDR ranges)
+func (b *Builder) WithNoProxy(hosts ...string) *Builder {
+	b.noProxy = hosts
+	return b
+}
+
+// WithProxyFromEnv reads proxy settings from environment variables:
+// TELEMETRYFLOW_PROXY_URL, TELEMETRYFLOW_PROXY_USERNAME, TELEMETRYFLOW_PROXY_PASSWORD
+// and TELEMETRYFLOW_NO_PROXY (comma-separated).
+func (b *Builder) WithProxyFromEnv() *Builder {
+	if proxyURL := os.Getenv("TELEMETRYFLOW_PROXY_URL"); proxyURL != "" {
+		b.proxyURL = proxyURL
+	}
+	if username := os.Getenv("TELEMETRYFLOW_PROXY_USERNAME"); username != "" {
+		b.proxyUsername = username
+		b.proxyPassword = os.Getenv("TELEMETRYFLOW_PROXY_PASSWORD")
+	}
+	if noProxy := os.Getenv("TELEMETRYFLOW_NO_PROXY"); noProxy != "" {
+		b.noProxy = nil
+		for _, host := range strings.Split(noProxy, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				b.noProxy = append(b.noProxy, host)
+			}
+		}
+	}
+	return b
+}
+
+// WithHTTPClient sets the HTTP client used by HTTP exporters. Proxy settings are
+// rejected with it; the proxy of the client's transport applies.
+func (b *Builder) WithHTTPClient(client *http.Client) *Builder {
+	b.httpClient = client
+	return b
+}
+
+// WithHTTPTransport sets the HTTP transport used by HTTP exporters, e.g. for request
+// signing. Proxy settings are rejected with it; the transport's own proxy applies.
+func (b *Builder) WithHTTPTransport(transport http.RoundTripper) *Builder {
+	b.httpTransport = transport
+	return b
+}
+
+// WithHeader adds a static header sent with every HTTP export
+func (b *Builder) WithHeader(key, value string) *Builder {
+	b.headers[key] = value
+	return b
+}
+
+// WithHeaders adds static headers sent with every HTTP export
+func (b *Builder) WithHeaders(headers map[string]string) *Builder {
+	for key, value := range headers {
+		b.headers[key] = value
+	}
+	return b
+}
+
+// WithHeaderProvider sets a function called for every HTTP export request to add
+// headers that change over time, such as short-lived tokens
+func (b *Builder) WithHeaderProvider(provider domain.HeaderProvider) *Builder {
+	b.headerProvider = provider
+	return b
+}
+
+// WithHeadersFromEnv reads static export headers from TELEMETRYFLOW_OTLP_HEADERS
+// (comma-separated key=value pairs)
+func (b *Builder) WithHeadersFromEnv() *Builder {
+	if headers := os.Getenv("TELEMETRYFLOW_OTLP_HEADERS"); headers != "" {
+		parsed, err := parseHeaders(headers)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_OTLP_HEADERS: %w", err))
+		}
+		b.WithHeaders(parsed)
+	}
+	return b
+}
+
+// WithHTTPEncoding sets the body encoding of HTTP exports: domain.HTTPEncodingProtobuf
+// (default) or domain.HTTPEncodingJSON, for gateways that only inspect JSON bodies
+func (b *Builder) WithHTTPEncoding(encoding domain.HTTPEncoding) *Builder {
+	b.httpEncoding = encoding
+	return b
+}
+
+// WithHTTPEncodingFromEnv reads the HTTP export encoding (protobuf or json) from
+// TELEMETRYFLOW_OTLP_HTTP_ENCODING
+func (b *Builder) WithHTTPEncodingFromEnv() *Builder {
+	if encoding := os.Getenv("TELEMETRYFLOW_OTLP_HTTP_ENCODING"); encoding != "" {
+		b.httpEncoding = domain.HTTPEncoding(strings.ToLower(encoding))
+	}
+	return b
+}
+
+// ===== EXPORTERS =====
+
+// WithExporters replaces the enabled exporters. Without ExporterOTLP nothing is sent
+// to the primary endpoint, and credentials and endpoint become optional. With no
+// arguments all exporters are disabled, which is useful with in-process processors.
+func (b *Builder) WithExporters(exporters ...domain.ExporterType) *Builder {
+	b.exporters = append([]domain.ExporterType{}, exporters...)
+	return b
+}
+
+// WithConsoleExporter prints telemetry to the console in addition to the enabled exporters
+func (b *Builder) WithConsoleExporter() *Builder {
+	return b.addExporter(domain.ExporterConsole)
+}
+
+// WithFileExporter writes OTLP/JSON lines to rotating files in dir, in addition to
+// the enabled exporters. Use telemetryflow-gen replay to send the files later.
+func (b *Builder) WithFileExporter(dir string) *Builder {
+	b.fileExporter.Directory = dir
+	return b.addExporter(domain.ExporterFile)
+}
+
+// WithFileRotation sets when the file exporter rotates its files (0 disables a limit)
+// and whether rotated files are gzipped
+func (b *Builder) WithFileRotation(maxSize int64, maxAge time.Duration, compress bool) *Builder {
+	b.fileExporter.MaxSize = maxSize
+	b.fileExporter.MaxAge = maxAge
+	b.fileExporter.Compress = compress
+	return b
+}
+
+// WithPrometheusEndpoint serves metrics for Prometheus scrapers at http://<addr>/metrics,
+// in addition to the enabled exporters. With an empty addr nothing listens and the metrics
+// are only served through Client.PrometheusHandler.
+func (b *Builder) WithPrometheusEndpoint(addr string) *Builder {
+	b.prometheusEndpoint = addr
+	return b.addExporter(domain.ExporterPrometheus)
+}
+
+// addExporter enables an exporter, keeping the OTLP exporter unless exporters were replaced
+func (b *Builder) addExporter(exporter domain.ExporterType) *Builder {
+	if b.exporters == nil {
+		b.exporters = []domain.ExporterType{domain.ExporterOTLP}
+	}
+	for _, enabled := range b.exporters {
+		if enabled == exporter {
+			return b
+		}
+	}
+	b.exporters = append(b.exporters, exporter)
+	return b
+}
+
+// WithConsoleWriter sets where the console exporter writes (default: stdout)
+func (b *Builder) WithConsoleWriter(w io.Writer) *Builder {
+	b.consoleWriter = w
+	return b
+}
+
+// WithExporterFromEnv reads the enabled exporters from TELEMETRYFLOW_EXPORTER, a
+// comma-separated list of otlp, console, file and prometheus (e.g. "console" or "otlp,file"),
+// the file exporter settings from TELEMETRYFLOW_FILE_EXPORTER_{DIR,MAX_SIZE_MB,MAX_AGE,COMPRESS}
+// and the Prometheus listen address from TELEMETRYFLOW_PROMETHEUS_ENDPOINT
+func (b *Builder) WithExporterFromEnv() *Builder {
+	if value := os.Getenv("TELEMETRYFLOW_EXPORTER"); value != "" {
+		b.exporters = parseExporters(value)
+	}
+	if addr := os.Getenv("TELEMETRYFLOW_PROMETHEUS_ENDPOINT"); addr != "" {
+		b.prometheusEndpoint = addr
+	}
+	if dir := os.Getenv("TELEMETRYFLOW_FILE_EXPORTER_DIR"); dir != "" {
+		b.fileExporter.Directory = dir
+	}
+	if value := os.Getenv("TELEMETRYFLOW_FILE_EXPORTER_MAX_SIZE_MB"); value != "" {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_FILE_EXPORTER_MAX_SIZE_MB: %w", err))
+		} else {
+			b.fileExporter.MaxSize = size << 20
+		}
+	}
+	if value := os.Getenv("TELEMETRYFLOW_FILE_EXPORTER_MAX_AGE"); value != "" {
+		b.setDuration(&b.fileExporter.MaxAge, "TELEMETRYFLOW_FILE_EXPORTER_MAX_AGE", value)
+	}
+	if value := os.Getenv("TELEMETRYFLOW_FILE_EXPORTER_COMPRESS"); value != "" {
+		compress, err := strconv.ParseBool(value)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_FILE_EXPORTER_COMPRESS: %w", err))
+		} else {
+			b.fileExporter.Compress = compress
+		}
+	}
+	return b
+}
+
+// parseExporters parses a comma-separated list of exporter names
+func parseExporters(value string) []domain.ExporterType {
+	var exporters []domain.ExporterType
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			exporters = append(exporters, domain.ExporterType(strings.ToLower(name)))
+		}
+	}
+	return exporters
+}
+
+// otlpEnabled returns true if the primary OTLP exporter is enabled
+func (b *Builder) otlpEnabled() bool {
+	if b.exporters == nil {
+		return true
+	}
+	for _, exporter := range b.exporters {
+		if exporter == domain.ExporterOTLP {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSpanProcessor adds a span processor that receives every span in addition to the
+// enabled exporters, e.g. an in-memory recorder in tests
+func (b *Builder) WithSpanProcessor(processor sdktrace.SpanProcessor) *Builder {
+	b.spanProcessors = append(b.spanProcessors, processor)
+	return b
+}
+
+// WithMetricReader adds a metric reader in addition to the enabled exporters
+func (b *Builder) WithMetricReader(reader sdkmetric.Reader) *Builder {
+	b.metricReaders = append(b.metricReaders, reader)
+	return b
+}
+
+// WithLogProcessor adds a log processor that receives every log record in addition to
+// the enabled exporters
+func (b *Builder) WithLogProcessor(processor sdklog.Processor) *Builder {
+	b.logProcessors = append(b.logProcessors, processor)
+	return b
+}
+
 // WithCollectorName sets the human-readable collector name (aligned with tfoidentityextension)
 func (b *Builder) WithCollectorName(name string) *Builder {
 	b.collectorName = name
@@ -352,11 +1190,108 @@ func (b *Builder) WithDatacenterFromEnv() *Builder {
 	return b
 }
 
+// WithProcessMetrics collects CPU time, resident memory, open file descriptors and
+// thread count of the current process from /proc (Linux only)
+func (b *Builder) WithProcessMetrics() *Builder {
+	b.processMetrics = true
+	return b
+}
+
+// WithHostMetrics collects host CPU, memory, load average, network and disk I/O
+// from /proc (Linux only), for hosts without a node exporter
+func (b *Builder) WithHostMetrics() *Builder {
+	b.hostMetrics = true
+	return b
+}
+
+// WithHostMetricsFromEnv reads TELEMETRYFLOW_PROCESS_METRICS and TELEMETRYFLOW_HOST_METRICS
+func (b *Builder) WithHostMetricsFromEnv() *Builder {
+	for name, field := range map[string]*bool{
+		"TELEMETRYFLOW_PROCESS_METRICS": &b.processMetrics,
+		"TELEMETRYFLOW_HOST_METRICS":    &b.hostMetrics,
+	} {
+		if value := os.Getenv(name); value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				b.errors = append(b.errors, fmt.Errorf("%s: %w", name, err))
+			} else {
+				*field = enabled
+			}
+		}
+	}
+	return b
+}
+
+// WithErrorHandler sets the function receiving errors the OpenTelemetry SDK reports in
+// the background, such as failed batch exports. The client replaces the global
+// OpenTelemetry error handler while it is initialized and passes these errors to the
+// handler it replaced unless one is set here.
+func (b *Builder) WithErrorHandler(handler func(error)) *Builder {
+	b.errorHandler = handler
+	return b
+}
+
+// OnExportSuccess adds a callback run after every successful export, for every signal
+// and exporter. Callbacks run on the export goroutine and should return quickly.
+func (b *Builder) OnExportSuccess(hook func(domain.ExportEvent)) *Builder {
+	b.onExportSuccess = append(b.onExportSuccess, hook)
+	return b
+}
+
+// OnExportFailure adds a callback run after every failed export, for every signal and
+// exporter. Errors caused by rejected credentials wrap domain.ErrCredentialsRejected.
+func (b *Builder) OnExportFailure(hook func(domain.ExportEvent)) *Builder {
+	b.onExportFailure = append(b.onExportFailure, hook)
+	return b
+}
+
+// WithSelfMetrics reports the SDK's own telemetryflow.sdk.* metrics: spans and log records
+// queued, exported, dropped and failed, export latency, batch sizes, retries and active spans
+func (b *Builder) WithSelfMetrics() *Builder {
+	b.selfMetrics = true
+	return b
+}
+
+// WithSelfMetricsMeterProvider enables self metrics and records them with provider instead
+// of the client's meter provider, so they reach a backend even when the client's own
+// exports are failing
+func (b *Builder) WithSelfMetricsMeterProvider(provider otelmetric.MeterProvider) *Builder {
+	b.selfMetrics = true
+	b.selfMetricsProvider = provider
+	return b
+}
+
+// WithSelfMetricsFromEnv reads TELEMETRYFLOW_SELF_METRICS
+func (b *Builder) WithSelfMetricsFromEnv() *Builder {
+	if value := os.Getenv("TELEMETRYFLOW_SELF_METRICS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("TELEMETRYFLOW_SELF_METRICS: %w", err))
+		} else {
+			b.selfMetrics = enabled
+		}
+	}
+	return b
+}
+
 // WithAutoConfiguration attempts to configure from environment variables
 func (b *Builder) WithAutoConfiguration() *Builder {
 	return b.
 		WithAPIKeyFromEnv().
 		WithEndpointFromEnv().
+		WithFailoverEndpointsFromEnv().
+		WithSignalSettingsFromEnv().
+		WithProxyFromEnv().
+		WithHeadersFromEnv().
+		WithHTTPEncodingFromEnv().
+		WithExporterFromEnv().
+		WithCircuitBreakerFromEnv().
+		WithMemoryLimiterFromEnv().
+		WithTimestampSkewFromEnv().
+		WithLogFilterFromEnv().
+		WithRuntimeMetricsFromEnv().
+		WithHostMetricsFromEnv().
+		WithSelfMetricsFromEnv().
 		WithServiceFromEnv().
 		WithServiceNamespaceFromEnv().
 		WithCollectorIDFromEnv().
@@ -372,11 +1307,23 @@ func (b *Builder) Build() (*Client, error) {
 		return nil, fmt.Errorf("builder errors: %v", b.errors)
 	}
 
+	// Without the primary OTLP exporter nothing is sent to the endpoint,
+	// so placeholders stand in for missing credentials and endpoint
+	apiKeyID, apiKeySecret, endpoint := b.apiKeyID, b.apiKeySecret, b.endpoint
+	if !b.otlpEnabled() {
+		if apiKeyID == "" && apiKeySecret == "" {
+			apiKeyID, apiKeySecret = localKeyID, localKeySecret
+		}
+		if endpoint == "" {
+			endpoint = localEndpoint
+		}
+	}
+
 	// Validate required fields
-	if b.apiKeyID == "" || b.apiKeySecret == "" {
+	if apiKeyID == "" || apiKeySecret == "" {
 		return nil, fmt.Errorf("API credentials are required")
 	}
-	if b.endpoint == "" {
+	if endpoint == "" {
 		return nil, fmt.Errorf("endpoint is required")
 	}
 	if b.serviceName == "" {
@@ -384,22 +1331,24 @@ func (b *Builder) Build() (*Client, error) {
 	}
 
 	// Create credentials
-	credentials, err := domain.NewCredentials(b.apiKeyID, b.apiKeySecret)
+	credentials, err := domain.NewCredentials(apiKeyID, apiKeySecret)
 	if err != nil {
 		return nil, fmt.Errorf("invalid credentials: %w", err)
 	}
 
 	// Create config
-	config, err := domain.NewTelemetryConfig(credentials, b.endpoint, b.serviceName)
+	config, err := domain.NewTelemetryConfig(credentials, endpoint, b.serviceName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config: %w", err)
 	}
 
 	// Apply builder settings
 	config.
-		WithProtocol(b.protocol).
-		WithInsecure(b.insecure).
 		WithTimeout(b.timeout).
+		WithCompression(b.compression).
+		WithRetry(b.retryEnabled, b.maxRetries, b.retryBackoff).
+		WithBatchSettings(b.batchTimeout, b.batchMaxSize).
+		WithMaxRequestSize(b.maxRequestSize).
 		WithSignals(b.enableMetrics, b.enableLogs, b.enableTraces).
 		WithServiceVersion(b.serviceVersion).
 		WithServiceNamespace(b.serviceNamespace).
@@ -410,6 +1359,14 @@ func (b *Builder) Build() (*Client, error) {
 		WithV2Only(b.v2Only).
 		WithEnrichResources(b.enrichResources)
 
+	// Endpoint URLs imply protocol and TLS defaults, explicit settings override them
+	if b.protocol != "" {
+		config.WithProtocol(b.protocol)
+	}
+	if b.insecure != nil {
+		config.WithInsecure(*b.insecure)
+	}
+
 	// Set collector ID if provided
 	if b.collectorID != "" {
 		config.WithCollectorID(b.collectorID)
@@ -442,13 +1399,121 @@ func (b *Builder) Build() (*Client, error) {
 		config.WithLogsEndpoint(b.logsEndpoint)
 	}
 
+	// Set failover endpoints
+	if len(b.failoverEndpoints) > 0 {
+		config.WithFailoverEndpoints(b.failoverEndpoints...)
+	}
+	if b.failoverProbeInterval > 0 {
+		config.WithFailoverProbeInterval(b.failoverProbeInterval)
+	}
+
+	// Set per-signal connection overrides
+	for signal, settings := range b.signalSettings {
+		if settings.endpoint != "" {
+			config.WithSignalEndpoint(signal, settings.endpoint)
+		}
+		if settings.protocol != "" {
+			config.WithSignalProtocol(signal, settings.protocol)
+		}
+		for key, value := range settings.headers {
+			config.WithSignalHeader(signal, key, value)
+		}
+		if settings.compression != nil {
+			config.WithSignalCompression(signal, *settings.compression)
+		}
+		if settings.timeout != 0 {
+			config.WithSignalTimeout(signal, settings.timeout)
+		}
+	}
+
+	// Set HTTP exporter transport settings
+	if b.proxyURL != "" {
+		config.WithProxy(b.proxyURL)
+	}
+	if b.proxyUsername != "" || b.proxyPassword != "" {
+		config.WithProxyAuth(b.proxyUsername, b.proxyPassword)
+	}
+	if len(b.noProxy) > 0 {
+		config.WithNoProxy(b.noProxy...)
+	}
+	if b.httpClient != nil {
+		config.WithHTTPClient(b.httpClient)
+	}
+	if b.httpTransport != nil {
+		config.WithHTTPTransport(b.httpTransport)
+	}
+	for key, value := range b.headers {
+		config.WithHeader(key, value)
+	}
+	if b.headerProvider != nil {
+		config.WithHeaderProvider(b.headerProvider)
+	}
+	if b.httpEncoding != "" {
+		config.WithHTTPEncoding(b.httpEncoding)
+	}
+
+	// Set exporters
+	if b.exporters != nil {
+		config.WithExporters(b.exporters...)
+	}
+	if b.consoleWriter != nil {
+		config.WithConsoleWriter(b.consoleWriter)
+	}
+	config.WithFileExporter(b.fileExporter)
+	config.WithRetryMaxBackoff(b.retryMaxBackoff)
+	if b.circuitBreaker.FailureThreshold != 0 {
+		config.WithCircuitBreaker(b.circuitBreakerConfig())
+	}
+	if b.memoryLimiter.Enabled() {
+		config.WithMemoryLimiter(b.memoryLimiterConfig())
+	}
+	config.WithTimestampSkew(b.timestampSkew)
+	config.WithLogFilter(b.logFilter)
+	config.WithPrometheusEndpoint(b.prometheusEndpoint)
+	config.WithRuntimeMetrics(b.runtimeMetrics)
+	if b.runtimeMetricsInterval != 0 {
+		config.WithRuntimeMetricsInterval(b.runtimeMetricsInterval)
+	}
+	config.WithProcessMetrics(b.processMetrics)
+	config.WithHostMetrics(b.hostMetrics)
+	config.WithSelfMetrics(b.selfMetrics)
+	config.WithErrorHandler(b.errorHandler)
+	for _, hook := range b.onExportSuccess {
+		config.OnExportSuccess(hook)
+	}
+	for _, hook := range b.onExportFailure {
+		config.OnExportFailure(hook)
+	}
+	config.WithInProcessConsumers(len(b.spanProcessors)+len(b.metricReaders)+len(b.logProcessors) > 0)
+
+	// Add fan-out destinations
+	for _, destination := range b.destinations {
+		config.WithDestination(destination)
+	}
+
 	// Add custom attributes
 	for key, value := range b.customAttrs {
 		config.WithCustomAttribute(key, value)
 	}
 
 	// Create client
-	return NewClient(config)
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	for _, processor := range b.spanProcessors {
+		client.commandHandler.AddSpanProcessor(processor)
+	}
+	for _, reader := range b.metricReaders {
+		client.commandHandler.AddMetricReader(reader)
+	}
+	for _, processor := range b.logProcessors {
+		client.commandHandler.AddLogProcessor(processor)
+	}
+	if b.selfMetricsProvider != nil {
+		client.commandHandler.SetSelfMetricsMeterProvider(b.selfMetricsProvider)
+	}
+	return client, nil
 }
 
 // MustBuild builds the client and panics on error (useful for quick setup)
@@ -21,6 +21,7 @@ package telemetryflow
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -60,7 +61,8 @@ func NewClient(config *domain.TelemetryConfig) (*Client, error) {
 	}, nil
 }
 
-// Initialize initializes the SDK and starts exporters
+// Initialize initializes the SDK and starts exporters. A failed initialization shuts
+// down what it started, so it can be retried.
 func (c *Client) Initialize(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -119,6 +121,14 @@ func (c *Client) Flush(ctx context.Context) error {
 
 // RecordMetric records a generic metric
 func (c *Client) RecordMetric(ctx context.Context, name string, value float64, unit string, attributes map[string]interface{}) error {
+	return c.RecordMetricAt(ctx, name, value, unit, attributes, time.Time{})
+}
+
+// RecordMetricAt records a gauge value measured at timestamp, e.g. to backfill delayed
+// data. The value is exported once with that time. Timestamps outside the configured
+// skew limits are rejected with an error wrapping domain.ErrTimestampSkew; a zero
+// timestamp behaves like RecordMetric.
+func (c *Client) RecordMetricAt(ctx context.Context, name string, value float64, unit string, attributes map[string]interface{}, timestamp time.Time) error {
 	if !c.isInitialized() {
 		return fmt.Errorf("client not initialized")
 	}
@@ -128,12 +138,26 @@ func (c *Client) RecordMetric(ctx context.Context, name string, value float64, u
 		Value:      value,
 		Unit:       unit,
 		Attributes: attributes,
-		Timestamp:  time.Now(),
+		Timestamp:  timestamp,
 	}
 
 	return c.commandHandler.Handle(ctx, cmd)
 }
 
+// RecordMetrics records multiple measurements as one operation. Measurements default to
+// gauges; set Kind to "counter" or "histogram" for the other instruments. The result
+// reports the measurements that were rejected; the error is only set when the whole
+// batch could not be recorded.
+func (c *Client) RecordMetrics(ctx context.Context, measurements []application.Measurement) (*application.BatchResult, error) {
+	if !c.isInitialized() {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	return c.commandHandler.RecordMetricsDirect(ctx, &application.RecordMetricsCommand{
+		Measurements: measurements,
+	})
+}
+
 // IncrementCounter increments a counter metric
 func (c *Client) IncrementCounter(ctx context.Context, name string, value int64, attributes map[string]interface{}) error {
 	if !c.isInitialized() {
@@ -184,6 +208,14 @@ func (c *Client) RecordHistogram(ctx context.Context, name string, value float64
 
 // Log emits a structured log entry
 func (c *Client) Log(ctx context.Context, severity string, message string, attributes map[string]interface{}) error {
+	return c.LogAt(ctx, severity, message, attributes, time.Time{})
+}
+
+// LogAt emits a structured log entry that occurred at timestamp, e.g. to backfill
+// delayed logs. The observed timestamp is the time of the call. Timestamps outside the
+// configured skew limits are rejected with an error wrapping domain.ErrTimestampSkew;
+// a zero timestamp behaves like Log.
+func (c *Client) LogAt(ctx context.Context, severity string, message string, attributes map[string]interface{}, timestamp time.Time) error {
 	if !c.isInitialized() {
 		return fmt.Errorf("client not initialized")
 	}
@@ -192,12 +224,24 @@ func (c *Client) Log(ctx context.Context, severity string, message string, attri
 		Severity:   severity,
 		Message:    message,
 		Attributes: attributes,
-		Timestamp:  time.Now(),
+		Timestamp:  timestamp,
 	}
 
 	return c.commandHandler.Handle(ctx, cmd)
 }
 
+// LogBatch emits multiple logs as one operation. The result reports the logs that were
+// rejected; the error is only set when the whole batch could not be emitted.
+func (c *Client) LogBatch(ctx context.Context, logs []application.EmitLogCommand) (*application.BatchResult, error) {
+	if !c.isInitialized() {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	return c.commandHandler.EmitLogsDirect(ctx, &application.EmitBatchLogsCommand{
+		Logs: logs,
+	})
+}
+
 // LogInfo emits an info-level log
 func (c *Client) LogInfo(ctx context.Context, message string, attributes map[string]interface{}) error {
 	return c.Log(ctx, "info", message, attributes)
@@ -213,6 +257,18 @@ func (c *Client) LogError(ctx context.Context, message string, attributes map[st
 	return c.Log(ctx, "error", message, attributes)
 }
 
+// SetLogFilter replaces the severity thresholds, sampling and de-duplication of the
+// logs emitted by the client. It can be called at any time, e.g. to lower the minimum
+// severity of a logger while investigating an incident.
+func (c *Client) SetLogFilter(cfg domain.LogFilterConfig) error {
+	return c.commandHandler.SetLogFilter(cfg)
+}
+
+// LogFilter returns the log filter settings in effect
+func (c *Client) LogFilter() domain.LogFilterConfig {
+	return c.commandHandler.LogFilter()
+}
+
 // ===== TRACES API =====
 
 // StartSpan starts a new trace span and returns the span ID
@@ -255,6 +311,133 @@ func (c *Client) AddSpanEvent(ctx context.Context, spanID string, name string, a
 	return c.commandHandler.Handle(ctx, cmd)
 }
 
+// SetSpanAttributes sets attributes on an active span, overwriting existing keys
+func (c *Client) SetSpanAttributes(ctx context.Context, spanID string, attributes map[string]interface{}) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	cmd := &application.SetSpanAttributesCommand{
+		SpanID:     spanID,
+		Attributes: attributes,
+	}
+
+	return c.commandHandler.Handle(ctx, cmd)
+}
+
+// SetSpanStatus sets the status of an active span: "unset", "ok" or "error". The
+// description is only kept for the error status.
+func (c *Client) SetSpanStatus(ctx context.Context, spanID string, code string, description string) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	cmd := &application.SetSpanStatusCommand{
+		SpanID:      spanID,
+		Code:        code,
+		Description: description,
+	}
+
+	return c.commandHandler.Handle(ctx, cmd)
+}
+
+// SetSpanName renames an active span
+func (c *Client) SetSpanName(ctx context.Context, spanID string, name string) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	cmd := &application.SetSpanNameCommand{
+		SpanID: spanID,
+		Name:   name,
+	}
+
+	return c.commandHandler.Handle(ctx, cmd)
+}
+
+// AddSpanLink links an active span to the span with the given hex trace and span IDs,
+// e.g. the producer of each message processed by a batch consumer
+func (c *Client) AddSpanLink(ctx context.Context, spanID string, linkedTraceID string, linkedSpanID string, attributes map[string]interface{}) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	cmd := &application.AddSpanLinkCommand{
+		SpanID:        spanID,
+		LinkedTraceID: linkedTraceID,
+		LinkedSpanID:  linkedSpanID,
+		Attributes:    attributes,
+	}
+
+	return c.commandHandler.Handle(ctx, cmd)
+}
+
+// SetSpanBaggage sets a baggage member of an active span. A span starts with the
+// baggage of the context passed to StartSpan.
+func (c *Client) SetSpanBaggage(ctx context.Context, spanID string, key string, value string) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	cmd := &application.SetSpanBaggageCommand{
+		SpanID: spanID,
+		Key:    key,
+		Value:  value,
+	}
+
+	return c.commandHandler.Handle(ctx, cmd)
+}
+
+// SpanBaggage returns the baggage members of an active span
+func (c *Client) SpanBaggage(ctx context.Context, spanID string) (map[string]string, error) {
+	if !c.isInitialized() {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	result, err := c.commandHandler.Query(ctx, &application.GetSpanBaggageQuery{SpanID: spanID})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*application.SpanBaggageResult).Baggage, nil
+}
+
+// ContextWithSpan returns ctx carrying an active span and its baggage, for starting
+// child spans with OpenTelemetry APIs or injecting them into outgoing requests
+func (c *Client) ContextWithSpan(ctx context.Context, spanID string) (context.Context, error) {
+	if !c.isInitialized() {
+		return ctx, fmt.Errorf("client not initialized")
+	}
+
+	return c.commandHandler.ContextWithSpan(ctx, spanID)
+}
+
+// ===== STATUS API =====
+
+// Health returns the health of the SDK and its collector endpoints
+func (c *Client) Health(ctx context.Context) (*application.HealthQueryResult, error) {
+	result, err := c.commandHandler.Query(ctx, &application.GetHealthQuery{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*application.HealthQueryResult), nil
+}
+
+// Status returns the current SDK status
+func (c *Client) Status(ctx context.Context) (*application.SDKStatusResult, error) {
+	result, err := c.commandHandler.Query(ctx, &application.GetSDKStatusQuery{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*application.SDKStatusResult), nil
+}
+
+// PrometheusHandler returns a handler serving the client's metrics in the Prometheus text
+// format, for mounting on an existing server. It is nil unless the client is initialized
+// with the Prometheus exporter.
+func (c *Client) PrometheusHandler() http.Handler {
+	return c.commandHandler.PrometheusHandler()
+}
+
 // ===== HELPER METHODS =====
 
 func (c *Client) isInitialized() bool {
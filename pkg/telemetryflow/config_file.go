@@ -0,0 +1,413 @@
+// Package telemetryflow provides the main SDK interface for TelemetryFlow.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPattern matches ${VAR} and ${VAR:default} references in config files
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+// fileConfig mirrors the layout of configs/sdk-default.yaml
+type fileConfig struct {
+	Service struct {
+		Name        string `yaml:"name"`
+		Version     string `yaml:"version"`
+		Namespace   string `yaml:"namespace"`
+		Environment string `yaml:"environment"`
+	} `yaml:"service"`
+
+	Credentials struct {
+		KeyID     string `yaml:"key_id"`
+		KeySecret string `yaml:"key_secret"`
+	} `yaml:"credentials"`
+
+	Endpoint struct {
+		Address  string            `yaml:"address"`
+		Protocol string            `yaml:"protocol"`
+		Encoding string            `yaml:"encoding"`
+		Insecure *bool             `yaml:"insecure"`
+		Timeout  string            `yaml:"timeout"`
+		Failover []string          `yaml:"failover"`
+		Headers  map[string]string `yaml:"headers"`
+		Proxy    struct {
+			URL      string   `yaml:"url"`
+			Username string   `yaml:"username"`
+			Password string   `yaml:"password"`
+			NoProxy  []string `yaml:"no_proxy"`
+		} `yaml:"proxy"`
+	} `yaml:"endpoint"`
+
+	V2API struct {
+		Enabled         *bool  `yaml:"enabled"`
+		V2Only          *bool  `yaml:"v2_only"`
+		TracesEndpoint  string `yaml:"traces_endpoint"`
+		MetricsEndpoint string `yaml:"metrics_endpoint"`
+		LogsEndpoint    string `yaml:"logs_endpoint"`
+	} `yaml:"v2_api"`
+
+	Collector struct {
+		ID              string            `yaml:"id"`
+		Name            string            `yaml:"name"`
+		Description     string            `yaml:"description"`
+		Hostname        string            `yaml:"hostname"`
+		Datacenter      string            `yaml:"datacenter"`
+		EnrichResources *bool             `yaml:"enrich_resources"`
+		Tags            map[string]string `yaml:"tags"`
+	} `yaml:"collector"`
+
+	Signals struct {
+		Traces  fileSignalConfig `yaml:"traces"`
+		Metrics fileSignalConfig `yaml:"metrics"`
+		Logs    fileSignalConfig `yaml:"logs"`
+	} `yaml:"signals"`
+
+	Batch struct {
+		Timeout        string `yaml:"timeout"`
+		MaxSize        int    `yaml:"max_size"`
+		MaxRequestSize int    `yaml:"max_request_size"`
+	} `yaml:"batch"`
+
+	Retry struct {
+		Enabled        *bool  `yaml:"enabled"`
+		MaxAttempts    *int   `yaml:"max_attempts"`
+		InitialBackoff string `yaml:"initial_backoff"`
+		MaxBackoff     string `yaml:"max_backoff"`
+	} `yaml:"retry"`
+
+	CircuitBreaker struct {
+		FailureThreshold *int   `yaml:"failure_threshold"`
+		Cooldown         string `yaml:"cooldown"`
+		Policy           string `yaml:"policy"`
+		BufferSize       *int   `yaml:"buffer_size"`
+	} `yaml:"circuit_breaker"`
+
+	MemoryLimiter struct {
+		SoftLimit      *int64   `yaml:"soft_limit"`
+		HardLimit      *int64   `yaml:"hard_limit"`
+		HeapSoftLimit  *int64   `yaml:"heap_soft_limit"`
+		HeapHardLimit  *int64   `yaml:"heap_hard_limit"`
+		SamplingRatio  *float64 `yaml:"sampling_ratio"`
+		MinLogSeverity string   `yaml:"min_log_severity"`
+		CheckInterval  string   `yaml:"check_interval"`
+	} `yaml:"memory_limiter"`
+
+	Timestamps struct {
+		MaxPast   string `yaml:"max_past"`
+		MaxFuture string `yaml:"max_future"`
+	} `yaml:"timestamps"`
+
+	LogFilter struct {
+		MinSeverity      string            `yaml:"min_severity"`
+		LoggerSeverities map[string]string `yaml:"logger_severities"`
+		Sampling         struct {
+			Interval   string `yaml:"interval"`
+			Initial    *int   `yaml:"initial"`
+			Thereafter *int   `yaml:"thereafter"`
+		} `yaml:"sampling"`
+		DedupWindow string `yaml:"dedup_window"`
+	} `yaml:"log_filter"`
+
+	Compression struct {
+		Enabled *bool `yaml:"enabled"`
+	} `yaml:"compression"`
+
+	Exporter string `yaml:"exporter"`
+
+	FileExporter struct {
+		Directory string `yaml:"directory"`
+		MaxSizeMB *int64 `yaml:"max_size_mb"`
+		MaxAge    string `yaml:"max_age"`
+		Compress  *bool  `yaml:"compress"`
+	} `yaml:"file_exporter"`
+
+	Prometheus struct {
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"prometheus"`
+
+	RuntimeMetrics struct {
+		Enabled  *bool  `yaml:"enabled"`
+		Interval string `yaml:"interval"`
+	} `yaml:"runtime_metrics"`
+
+	ProcessMetrics struct {
+		Enabled *bool `yaml:"enabled"`
+	} `yaml:"process_metrics"`
+
+	HostMetrics struct {
+		Enabled *bool `yaml:"enabled"`
+	} `yaml:"host_metrics"`
+
+	SelfMetrics struct {
+		Enabled *bool `yaml:"enabled"`
+	} `yaml:"self_metrics"`
+
+	ResourceAttributes map[string]string `yaml:"resource_attributes"`
+}
+
+// fileSignalConfig holds the signal switch and per-signal connection overrides
+type fileSignalConfig struct {
+	Enabled     *bool             `yaml:"enabled"`
+	Exemplars   *bool             `yaml:"exemplars"`
+	Endpoint    string            `yaml:"endpoint"`
+	Protocol    string            `yaml:"protocol"`
+	Headers     map[string]string `yaml:"headers"`
+	Compression string            `yaml:"compression"`
+	Timeout     string            `yaml:"timeout"`
+}
+
+// WithConfigFile loads settings from a YAML file (see configs/sdk-default.yaml).
+// ${VAR} and ${VAR:default} references are expanded from the environment.
+// Only values present in the file are applied, so later builder calls override them.
+func (b *Builder) WithConfigFile(path string) *Builder {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("failed to read config file: %w", err))
+		return b
+	}
+
+	var cfg fileConfig
+	if err := yaml.Unmarshal([]byte(expandEnv(string(data))), &cfg); err != nil {
+		b.errors = append(b.errors, fmt.Errorf("failed to parse config file %s: %w", path, err))
+		return b
+	}
+
+	b.applyFileConfig(&cfg)
+	return b
+}
+
+// expandEnv replaces ${VAR} and ${VAR:default} with environment values
+func expandEnv(content string) string {
+	return envPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := envPattern.FindStringSubmatch(match)
+		if value, ok := os.LookupEnv(parts[1]); ok && value != "" {
+			return value
+		}
+		return parts[2]
+	})
+}
+
+func (b *Builder) applyFileConfig(cfg *fileConfig) {
+	setString(&b.serviceName, cfg.Service.Name)
+	setString(&b.serviceVersion, cfg.Service.Version)
+	setString(&b.serviceNamespace, cfg.Service.Namespace)
+	setString(&b.environment, cfg.Service.Environment)
+
+	setString(&b.apiKeyID, cfg.Credentials.KeyID)
+	setString(&b.apiKeySecret, cfg.Credentials.KeySecret)
+
+	setString(&b.endpoint, cfg.Endpoint.Address)
+	if cfg.Endpoint.Protocol != "" {
+		b.protocol = domain.Protocol(strings.ToLower(cfg.Endpoint.Protocol))
+	}
+	if cfg.Endpoint.Encoding != "" {
+		b.httpEncoding = domain.HTTPEncoding(strings.ToLower(cfg.Endpoint.Encoding))
+	}
+	if cfg.Endpoint.Insecure != nil {
+		b.WithInsecure(*cfg.Endpoint.Insecure)
+	}
+	b.setDuration(&b.timeout, "endpoint.timeout", cfg.Endpoint.Timeout)
+	if len(cfg.Endpoint.Failover) > 0 {
+		b.failoverEndpoints = cfg.Endpoint.Failover
+	}
+	b.WithHeaders(cfg.Endpoint.Headers)
+	setString(&b.proxyURL, cfg.Endpoint.Proxy.URL)
+	setString(&b.proxyUsername, cfg.Endpoint.Proxy.Username)
+	setString(&b.proxyPassword, cfg.Endpoint.Proxy.Password)
+	if len(cfg.Endpoint.Proxy.NoProxy) > 0 {
+		b.noProxy = cfg.Endpoint.Proxy.NoProxy
+	}
+
+	setBool(&b.useV2API, cfg.V2API.Enabled)
+	setBool(&b.v2Only, cfg.V2API.V2Only)
+	if b.v2Only {
+		b.useV2API = true // v2Only implies useV2API
+	}
+	setString(&b.tracesEndpoint, cfg.V2API.TracesEndpoint)
+	setString(&b.metricsEndpoint, cfg.V2API.MetricsEndpoint)
+	setString(&b.logsEndpoint, cfg.V2API.LogsEndpoint)
+
+	setString(&b.collectorID, cfg.Collector.ID)
+	setString(&b.collectorName, cfg.Collector.Name)
+	setString(&b.collectorDescription, cfg.Collector.Description)
+	setString(&b.collectorHostname, cfg.Collector.Hostname)
+	setString(&b.datacenter, cfg.Collector.Datacenter)
+	setBool(&b.enrichResources, cfg.Collector.EnrichResources)
+	for key, value := range cfg.Collector.Tags {
+		b.collectorTags[key] = value
+	}
+
+	setBool(&b.enableTraces, cfg.Signals.Traces.Enabled)
+	setBool(&b.enableMetrics, cfg.Signals.Metrics.Enabled)
+	setBool(&b.enableLogs, cfg.Signals.Logs.Enabled)
+	setBool(&b.enableExemplars, cfg.Signals.Metrics.Exemplars)
+	b.applyFileSignalConfig(domain.SignalTraces, &cfg.Signals.Traces)
+	b.applyFileSignalConfig(domain.SignalMetrics, &cfg.Signals.Metrics)
+	b.applyFileSignalConfig(domain.SignalLogs, &cfg.Signals.Logs)
+
+	b.setDuration(&b.batchTimeout, "batch.timeout", cfg.Batch.Timeout)
+	if cfg.Batch.MaxSize > 0 {
+		b.batchMaxSize = cfg.Batch.MaxSize
+	}
+	if cfg.Batch.MaxRequestSize > 0 {
+		b.maxRequestSize = cfg.Batch.MaxRequestSize
+	}
+
+	setBool(&b.retryEnabled, cfg.Retry.Enabled)
+	if cfg.Retry.MaxAttempts != nil {
+		b.maxRetries = *cfg.Retry.MaxAttempts
+	}
+	b.setDuration(&b.retryBackoff, "retry.initial_backoff", cfg.Retry.InitialBackoff)
+	b.setDuration(&b.retryMaxBackoff, "retry.max_backoff", cfg.Retry.MaxBackoff)
+
+	if cfg.CircuitBreaker.FailureThreshold != nil {
+		b.circuitBreaker.FailureThreshold = *cfg.CircuitBreaker.FailureThreshold
+	}
+	b.setDuration(&b.circuitBreaker.Cooldown, "circuit_breaker.cooldown", cfg.CircuitBreaker.Cooldown)
+	if cfg.CircuitBreaker.Policy != "" {
+		b.circuitBreaker.Policy = domain.BreakerPolicy(strings.ToLower(cfg.CircuitBreaker.Policy))
+	}
+	if cfg.CircuitBreaker.BufferSize != nil {
+		b.circuitBreaker.BufferSize = *cfg.CircuitBreaker.BufferSize
+	}
+
+	limits := []struct {
+		value *int64
+		limit *int64
+	}{
+		{cfg.MemoryLimiter.SoftLimit, &b.memoryLimiter.SoftLimit},
+		{cfg.MemoryLimiter.HardLimit, &b.memoryLimiter.HardLimit},
+		{cfg.MemoryLimiter.HeapSoftLimit, &b.memoryLimiter.HeapSoftLimit},
+		{cfg.MemoryLimiter.HeapHardLimit, &b.memoryLimiter.HeapHardLimit},
+	}
+	for _, l := range limits {
+		if l.value != nil {
+			*l.limit = *l.value
+		}
+	}
+	if cfg.MemoryLimiter.SamplingRatio != nil {
+		b.defaultMemoryDegradation()
+		b.memoryLimiter.SamplingRatio = *cfg.MemoryLimiter.SamplingRatio
+	}
+	if cfg.MemoryLimiter.MinLogSeverity != "" {
+		b.defaultMemoryDegradation()
+		b.memoryLimiter.MinSeverity = cfg.MemoryLimiter.MinLogSeverity
+	}
+	b.setDuration(&b.memoryLimiter.CheckInterval, "memory_limiter.check_interval", cfg.MemoryLimiter.CheckInterval)
+	b.setDuration(&b.timestampSkew.MaxPast, "timestamps.max_past", cfg.Timestamps.MaxPast)
+	b.setDuration(&b.timestampSkew.MaxFuture, "timestamps.max_future", cfg.Timestamps.MaxFuture)
+
+	if cfg.LogFilter.MinSeverity != "" {
+		b.logFilter.MinSeverity = cfg.LogFilter.MinSeverity
+	}
+	for logger, severity := range cfg.LogFilter.LoggerSeverities {
+		b.WithLoggerSeverity(logger, severity)
+	}
+	b.setDuration(&b.logFilter.SamplingInterval, "log_filter.sampling.interval", cfg.LogFilter.Sampling.Interval)
+	if cfg.LogFilter.Sampling.Initial != nil {
+		b.logFilter.SamplingInitial = *cfg.LogFilter.Sampling.Initial
+	}
+	if cfg.LogFilter.Sampling.Thereafter != nil {
+		b.logFilter.SamplingThereafter = *cfg.LogFilter.Sampling.Thereafter
+	}
+	b.setDuration(&b.logFilter.DedupWindow, "log_filter.dedup_window", cfg.LogFilter.DedupWindow)
+
+	setBool(&b.compression, cfg.Compression.Enabled)
+
+	if cfg.Exporter != "" {
+		b.exporters = parseExporters(cfg.Exporter)
+	}
+	setString(&b.fileExporter.Directory, cfg.FileExporter.Directory)
+	if cfg.FileExporter.MaxSizeMB != nil {
+		b.fileExporter.MaxSize = *cfg.FileExporter.MaxSizeMB << 20
+	}
+	b.setDuration(&b.fileExporter.MaxAge, "file_exporter.max_age", cfg.FileExporter.MaxAge)
+	setBool(&b.fileExporter.Compress, cfg.FileExporter.Compress)
+	setString(&b.prometheusEndpoint, cfg.Prometheus.Endpoint)
+	setBool(&b.runtimeMetrics, cfg.RuntimeMetrics.Enabled)
+	b.setDuration(&b.runtimeMetricsInterval, "runtime_metrics.interval", cfg.RuntimeMetrics.Interval)
+	setBool(&b.processMetrics, cfg.ProcessMetrics.Enabled)
+	setBool(&b.hostMetrics, cfg.HostMetrics.Enabled)
+	setBool(&b.selfMetrics, cfg.SelfMetrics.Enabled)
+
+	for key, value := range cfg.ResourceAttributes {
+		b.customAttrs[key] = value
+	}
+}
+
+func (b *Builder) applyFileSignalConfig(signal domain.SignalType, cfg *fileSignalConfig) {
+	if cfg.Endpoint != "" {
+		b.WithSignalEndpoint(signal, cfg.Endpoint)
+	}
+	if cfg.Protocol != "" {
+		b.WithSignalProtocol(signal, domain.Protocol(strings.ToLower(cfg.Protocol)))
+	}
+	for key, value := range cfg.Headers {
+		b.WithSignalHeader(signal, key, value)
+	}
+	if cfg.Compression != "" {
+		enabled, err := parseCompression(cfg.Compression)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("signals.%s.compression: %w", signal, err))
+		} else {
+			b.WithSignalCompression(signal, enabled)
+		}
+	}
+	if cfg.Timeout != "" {
+		var timeout time.Duration
+		b.setDuration(&timeout, fmt.Sprintf("signals.%s.timeout", signal), cfg.Timeout)
+		if timeout != 0 {
+			b.WithSignalTimeout(signal, timeout)
+		}
+	}
+}
+
+// setDuration parses value into target, recording an error for invalid durations
+func (b *Builder) setDuration(target *time.Duration, field, value string) {
+	if value == "" {
+		return
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("%s: %w", field, err))
+		return
+	}
+	*target = d
+}
+
+func setString(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+func setBool(target *bool, value *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
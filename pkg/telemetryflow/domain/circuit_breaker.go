@@ -0,0 +1,95 @@
+// Package domain provides the per-exporter circuit breaker settings for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCircuitOpen is wrapped by export errors for data not sent because the exporter's
+// circuit breaker was open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerPolicy decides what happens to data exported while a circuit breaker is open
+type BreakerPolicy string
+
+const (
+	BreakerDrop   BreakerPolicy = "drop"   // discard the data
+	BreakerBuffer BreakerPolicy = "buffer" // keep spans and log records, sent once the breaker closes
+)
+
+// CircuitBreakerConfig configures the circuit breaker of every exporter.
+// After FailureThreshold consecutive failed exports (retries included) the exporter
+// pauses for Cooldown; then one export probes the collector and closes the breaker
+// again on success. Metrics are never buffered: the next collection supersedes them.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // consecutive failures that open the breaker (0: disabled)
+	Cooldown         time.Duration // pause before the probe export
+	Policy           BreakerPolicy // what happens to data exported while open
+	BufferSize       int           // spans or log records kept per exporter with BreakerBuffer
+}
+
+// DefaultCircuitBreakerConfig returns the circuit breaker defaults: 5 failures, 30s, drop
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+		Policy:           BreakerDrop,
+		BufferSize:       2048,
+	}
+}
+
+// Enabled returns true if the breaker opens after failures.
+func (c CircuitBreakerConfig) Enabled() bool { return c.FailureThreshold > 0 }
+
+// CircuitBreaker returns the per-exporter circuit breaker settings.
+func (c *TelemetryConfig) CircuitBreaker() CircuitBreakerConfig { return c.circuitBreaker }
+
+// WithCircuitBreaker sets the circuit breaker of every exporter, e.g.
+// WithCircuitBreaker(DefaultCircuitBreakerConfig())
+func (c *TelemetryConfig) WithCircuitBreaker(cfg CircuitBreakerConfig) *TelemetryConfig {
+	c.circuitBreaker = cfg
+	return c
+}
+
+// validateCircuitBreaker checks the circuit breaker settings
+func (c *TelemetryConfig) validateCircuitBreaker() error {
+	cb := c.circuitBreaker
+	if cb.FailureThreshold < 0 {
+		return errors.New("circuit breaker failure threshold cannot be negative")
+	}
+	if !cb.Enabled() {
+		return nil
+	}
+	if cb.Cooldown <= 0 {
+		return errors.New("circuit breaker cooldown must be positive")
+	}
+	switch cb.Policy {
+	case BreakerDrop:
+	case BreakerBuffer:
+		if cb.BufferSize <= 0 {
+			return errors.New("circuit breaker buffer size must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown circuit breaker policy: %s", cb.Policy)
+	}
+	return nil
+}
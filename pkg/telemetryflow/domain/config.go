@@ -21,6 +21,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -70,8 +71,44 @@ type TelemetryConfig struct {
 	retryEnabled    bool
 	maxRetries      int
 	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration // cap of the exponential backoff (0: twice retryBackoff)
 	compressionGzip bool
 
+	// Per-signal connection overrides (endpoint, protocol, headers, compression, timeout)
+	signalSettings map[SignalType]*signalSettings
+
+	// HTTP exporter transport settings (proxy, custom client, extra headers)
+	httpTransport httpTransportSettings
+
+	// Export callbacks and background error handler
+	exportHooks exportHooks
+
+	// Per-exporter circuit breaker (disabled unless a failure threshold is set)
+	circuitBreaker CircuitBreakerConfig
+
+	// Memory limiter for the data buffered by the SDK (disabled unless a limit is set)
+	memoryLimiter MemoryLimiterConfig
+
+	// Limits on explicit metric and log timestamps
+	timestampSkew TimestampSkewConfig
+
+	// Log severity thresholds, sampling and de-duplication
+	logFilter LogFilterConfig
+
+	// Failover settings
+	failoverEndpoints     []string      // Secondary endpoints, in priority order after endpoint
+	failoverProbeInterval time.Duration // How long a failed endpoint is skipped before it is retried
+
+	// Fan-out destinations (exported in addition to the primary endpoint)
+	destinations []*Destination
+
+	// Exporters (primary OTLP endpoint and/or console output)
+	exporters          map[ExporterType]bool
+	consoleWriter      io.Writer // nil: stdout
+	fileExporter       FileExporterConfig
+	inProcess          bool   // telemetry is also consumed by processors or readers registered in code
+	prometheusEndpoint string // listen address of the Prometheus metrics endpoint (empty: in-process handler only)
+
 	// TFO API Version settings (aligned with tfoexporter)
 	useV2API        bool   // Use v2 API endpoints (/v2/traces, /v2/metrics, /v2/logs)
 	v2Only          bool   // v2-only mode - reject v1 endpoints
@@ -98,14 +135,26 @@ type TelemetryConfig struct {
 	customAttributes map[string]string
 
 	// Batch settings
-	batchTimeout time.Duration
-	batchMaxSize int
+	batchTimeout   time.Duration
+	batchMaxSize   int
+	maxRequestSize int // encoded bytes per export request (0: no limit beyond the gRPC max send size)
 
 	// Rate limiting (client-side)
 	rateLimit int // requests per minute
 
 	// Exemplars support (for metrics-to-traces correlation)
 	exemplarsEnabled bool
+
+	// Go runtime metrics (goroutines, GC, memory, scheduler)
+	runtimeMetrics         bool
+	runtimeMetricsInterval time.Duration // minimum time between runtime/metrics samples
+
+	// Process and host resource metrics read from /proc
+	processMetrics bool
+	hostMetrics    bool
+
+	// SDK self-observability (telemetryflow.sdk.* metrics)
+	selfMetrics bool
 }
 
 // NewTelemetryConfig creates a new configuration with required fields
@@ -119,18 +168,35 @@ func NewTelemetryConfig(credentials *Credentials, endpoint string, serviceName s
 	if serviceName == "" {
 		return nil, errors.New("service name cannot be empty")
 	}
+	parsed, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// URL endpoints imply the protocol and TLS defaults; With* setters still override them
+	protocol := ProtocolGRPC // default
+	if inferred, ok := parsed.InferredProtocol(); ok {
+		protocol = inferred
+	}
+	insecure, _ := parsed.InferredInsecure()
 
 	return &TelemetryConfig{
 		credentials:     credentials,
 		collectorID:     "", // auto-generated if empty
 		endpoint:        endpoint,
-		protocol:        ProtocolGRPC, // default
-		insecure:        false,
+		protocol:        protocol,
+		insecure:        insecure,
 		timeout:         30 * time.Second,
 		retryEnabled:    true,
 		maxRetries:      3,
 		retryBackoff:    5 * time.Second,
 		compressionGzip: true,
+		// Failover settings
+		failoverEndpoints:     nil, // single endpoint by default
+		failoverProbeInterval: 30 * time.Second,
+		// Exporters
+		exporters:    map[ExporterType]bool{ExporterOTLP: true},
+		fileExporter: DefaultFileExporterConfig(),
 		// TFO API Version settings (aligned with tfoexporter)
 		useV2API:        true, // v2 API enabled by default for TFO Platform
 		v2Only:          false,
@@ -158,16 +224,19 @@ func NewTelemetryConfig(credentials *Credentials, endpoint string, serviceName s
 			SignalLogs:    true,
 			SignalTraces:  true,
 		},
-		serviceName:      serviceName,
-		serviceNamespace: "telemetryflow", // default namespace
-		serviceVersion:   "1.0.0",
-		environment:      "production",
-		datacenter:       "default",
-		customAttributes: make(map[string]string),
-		batchTimeout:     10 * time.Second,
-		batchMaxSize:     512,
-		rateLimit:        1000,
-		exemplarsEnabled: true, // enabled by default for metrics-to-traces correlation
+		serviceName:            serviceName,
+		serviceNamespace:       "telemetryflow", // default namespace
+		serviceVersion:         "1.0.0",
+		environment:            "production",
+		datacenter:             "default",
+		customAttributes:       make(map[string]string),
+		batchTimeout:           10 * time.Second,
+		batchMaxSize:           512,
+		rateLimit:              1000,
+		exemplarsEnabled:       true, // enabled by default for metrics-to-traces correlation
+		runtimeMetricsInterval: 10 * time.Second,
+		timestampSkew:          DefaultTimestampSkewConfig(),
+		logFilter:              DefaultLogFilterConfig(),
 	}, nil
 }
 
@@ -177,9 +246,62 @@ func (c *TelemetryConfig) Credentials() *Credentials { return c.credentials }
 // CollectorID returns the unique identifier for this collector instance.
 func (c *TelemetryConfig) CollectorID() string { return c.collectorID }
 
-// Endpoint returns the OTLP collector endpoint address.
+// Endpoint returns the OTLP collector endpoint: a host:port, an http(s) URL with an
+// optional base path, or a unix:// socket.
 func (c *TelemetryConfig) Endpoint() string { return c.endpoint }
 
+// FailoverEndpoints returns the secondary endpoints used when the primary endpoint fails.
+func (c *TelemetryConfig) FailoverEndpoints() []string { return c.failoverEndpoints }
+
+// Endpoints returns all endpoints in priority order, starting with the primary endpoint.
+func (c *TelemetryConfig) Endpoints() []string {
+	endpoints := make([]string, 0, 1+len(c.failoverEndpoints))
+	endpoints = append(endpoints, c.endpoint)
+	return append(endpoints, c.failoverEndpoints...)
+}
+
+// IsFailoverEnabled returns true if at least one failover endpoint is configured.
+func (c *TelemetryConfig) IsFailoverEnabled() bool { return len(c.failoverEndpoints) > 0 }
+
+// FailoverProbeInterval returns how long a failed endpoint is skipped before it is retried.
+func (c *TelemetryConfig) FailoverProbeInterval() time.Duration { return c.failoverProbeInterval }
+
+// Destinations returns the additional export destinations.
+func (c *TelemetryConfig) Destinations() []*Destination { return c.destinations }
+
+// ForDestination returns a copy of the configuration that targets the given destination.
+// Service, resource, batch and transport tuning settings (including the HTTP proxy and
+// client) are shared; connection, credentials, export headers, signal selection and
+// retry policy come from the destination.
+func (c *TelemetryConfig) ForDestination(d *Destination) *TelemetryConfig {
+	derived := *c
+	derived.credentials = d.credentials
+	derived.endpoint = d.endpoint
+	derived.protocol = d.protocol
+	derived.insecure = d.insecure
+	derived.useV2API = d.useV2API
+	derived.v2Only = false
+	derived.tracesEndpoint = ""
+	derived.metricsEndpoint = ""
+	derived.logsEndpoint = ""
+	derived.retryEnabled = d.retryEnabled
+	derived.maxRetries = d.maxRetries
+	derived.retryBackoff = d.retryBackoff
+	derived.signalSettings = nil
+	derived.httpTransport.headers = d.headers
+	derived.httpTransport.headerProvider = d.headerProvider
+	derived.failoverEndpoints = nil
+	derived.destinations = nil
+	derived.exporters = map[ExporterType]bool{ExporterOTLP: true}
+	derived.inProcess = false
+	derived.enabledSignals = map[SignalType]bool{
+		SignalMetrics: c.enabledSignals[SignalMetrics] && d.IsSignalEnabled(SignalMetrics),
+		SignalLogs:    c.enabledSignals[SignalLogs] && d.IsSignalEnabled(SignalLogs),
+		SignalTraces:  c.enabledSignals[SignalTraces] && d.IsSignalEnabled(SignalTraces),
+	}
+	return &derived
+}
+
 // Protocol returns the OTLP protocol type (gRPC or HTTP).
 func (c *TelemetryConfig) Protocol() Protocol { return c.protocol }
 
@@ -198,16 +320,25 @@ func (c *TelemetryConfig) MaxRetries() int { return c.maxRetries }
 // RetryBackoff returns the backoff duration between retries.
 func (c *TelemetryConfig) RetryBackoff() time.Duration { return c.retryBackoff }
 
+// RetryMaxBackoff returns the longest wait between retries. The backoff doubles after
+// every attempt up to this cap; server throttling hints may ask for longer waits.
+func (c *TelemetryConfig) RetryMaxBackoff() time.Duration {
+	if c.retryMaxBackoff <= 0 {
+		return 2 * c.retryBackoff
+	}
+	return max(c.retryMaxBackoff, c.retryBackoff)
+}
+
 // IsCompressionEnabled returns true if gzip compression is enabled.
 func (c *TelemetryConfig) IsCompressionEnabled() bool { return c.compressionGzip }
 
 // GRPCKeepalive returns the gRPC keepalive configuration.
 func (c *TelemetryConfig) GRPCKeepalive() *GRPCKeepaliveConfig { return c.grpcKeepalive }
 
-// GRPCMaxRecvMsgSize returns the maximum gRPC receive message size in bytes.
+// GRPCMaxRecvMsgSize returns the maximum gRPC receive message size in MiB.
 func (c *TelemetryConfig) GRPCMaxRecvMsgSize() int { return c.grpcMaxRecvMsgSize }
 
-// GRPCMaxSendMsgSize returns the maximum gRPC send message size in bytes.
+// GRPCMaxSendMsgSize returns the maximum gRPC send message size in MiB.
 func (c *TelemetryConfig) GRPCMaxSendMsgSize() int { return c.grpcMaxSendMsgSize }
 
 // GRPCReadBufferSize returns the gRPC read buffer size in bytes.
@@ -237,12 +368,42 @@ func (c *TelemetryConfig) BatchTimeout() time.Duration { return c.batchTimeout }
 // BatchMaxSize returns the maximum batch size for export.
 func (c *TelemetryConfig) BatchMaxSize() int { return c.batchMaxSize }
 
+// MaxRequestSize returns the configured byte budget of an export request (0: none).
+func (c *TelemetryConfig) MaxRequestSize() int { return c.maxRequestSize }
+
+// RequestSizeLimit returns the largest export request sent over protocol, in encoded
+// bytes (0: no limit). gRPC requests are also capped by the max send message size.
+func (c *TelemetryConfig) RequestSizeLimit(protocol Protocol) int {
+	limit := c.maxRequestSize
+	if protocol == ProtocolGRPC && c.grpcMaxSendMsgSize > 0 {
+		if grpcLimit := c.grpcMaxSendMsgSize << 20; limit == 0 || grpcLimit < limit {
+			limit = grpcLimit
+		}
+	}
+	return limit
+}
+
 // RateLimit returns the rate limit for telemetry data export.
 func (c *TelemetryConfig) RateLimit() int { return c.rateLimit }
 
 // IsExemplarsEnabled returns true if exemplars are enabled for metrics-to-traces correlation.
 func (c *TelemetryConfig) IsExemplarsEnabled() bool { return c.exemplarsEnabled }
 
+// IsRuntimeMetricsEnabled returns true if Go runtime metrics are collected.
+func (c *TelemetryConfig) IsRuntimeMetricsEnabled() bool { return c.runtimeMetrics }
+
+// RuntimeMetricsInterval returns the minimum time between two reads of runtime/metrics.
+func (c *TelemetryConfig) RuntimeMetricsInterval() time.Duration { return c.runtimeMetricsInterval }
+
+// IsProcessMetricsEnabled returns true if process CPU, memory, file descriptor and thread metrics are collected.
+func (c *TelemetryConfig) IsProcessMetricsEnabled() bool { return c.processMetrics }
+
+// IsHostMetricsEnabled returns true if host CPU, memory, load, network and disk metrics are collected.
+func (c *TelemetryConfig) IsHostMetricsEnabled() bool { return c.hostMetrics }
+
+// IsSelfMetricsEnabled returns true if the SDK reports its own telemetryflow.sdk.* metrics.
+func (c *TelemetryConfig) IsSelfMetricsEnabled() bool { return c.selfMetrics }
+
 // UseV2API returns true if v2 API endpoints are enabled.
 func (c *TelemetryConfig) UseV2API() bool { return c.useV2API }
 
@@ -333,12 +494,36 @@ func (c *TelemetryConfig) WithRetry(enabled bool, maxRetries int, backoff time.D
 	return c
 }
 
+// WithRetryMaxBackoff caps the exponential retry backoff (0: twice the initial backoff)
+func (c *TelemetryConfig) WithRetryMaxBackoff(maxBackoff time.Duration) *TelemetryConfig {
+	c.retryMaxBackoff = maxBackoff
+	return c
+}
+
 // WithCompression enables/disables gzip compression
 func (c *TelemetryConfig) WithCompression(enabled bool) *TelemetryConfig {
 	c.compressionGzip = enabled
 	return c
 }
 
+// WithFailoverEndpoints sets the secondary endpoints, in priority order
+func (c *TelemetryConfig) WithFailoverEndpoints(endpoints ...string) *TelemetryConfig {
+	c.failoverEndpoints = endpoints
+	return c
+}
+
+// WithFailoverProbeInterval sets how long a failed endpoint is skipped before it is retried
+func (c *TelemetryConfig) WithFailoverProbeInterval(interval time.Duration) *TelemetryConfig {
+	c.failoverProbeInterval = interval
+	return c
+}
+
+// WithDestination adds a named destination that receives telemetry in addition to the primary endpoint
+func (c *TelemetryConfig) WithDestination(destination *Destination) *TelemetryConfig {
+	c.destinations = append(c.destinations, destination)
+	return c
+}
+
 // WithSignals configures which signals to enable
 func (c *TelemetryConfig) WithSignals(metrics, logs, traces bool) *TelemetryConfig {
 	c.enabledSignals[SignalMetrics] = metrics
@@ -372,6 +557,13 @@ func (c *TelemetryConfig) WithBatchSettings(timeout time.Duration, maxSize int)
 	return c
 }
 
+// WithMaxRequestSize sets the byte budget of an export request; larger requests are
+// split, and single items that exceed it are truncated or dropped (0: no budget)
+func (c *TelemetryConfig) WithMaxRequestSize(bytes int) *TelemetryConfig {
+	c.maxRequestSize = bytes
+	return c
+}
+
 // WithRateLimit sets client-side rate limit (requests per minute)
 func (c *TelemetryConfig) WithRateLimit(limit int) *TelemetryConfig {
 	c.rateLimit = limit
@@ -420,6 +612,37 @@ func (c *TelemetryConfig) WithExemplars(enabled bool) *TelemetryConfig {
 	return c
 }
 
+// WithRuntimeMetrics enables/disables Go runtime metrics (goroutines, GC, memory, scheduler)
+func (c *TelemetryConfig) WithRuntimeMetrics(enabled bool) *TelemetryConfig {
+	c.runtimeMetrics = enabled
+	return c
+}
+
+// WithRuntimeMetricsInterval sets how often runtime/metrics is read; collections in
+// between reuse the last sample
+func (c *TelemetryConfig) WithRuntimeMetricsInterval(interval time.Duration) *TelemetryConfig {
+	c.runtimeMetricsInterval = interval
+	return c
+}
+
+// WithProcessMetrics enables/disables process resource metrics read from /proc/self
+func (c *TelemetryConfig) WithProcessMetrics(enabled bool) *TelemetryConfig {
+	c.processMetrics = enabled
+	return c
+}
+
+// WithHostMetrics enables/disables host resource metrics read from /proc
+func (c *TelemetryConfig) WithHostMetrics(enabled bool) *TelemetryConfig {
+	c.hostMetrics = enabled
+	return c
+}
+
+// WithSelfMetrics enables/disables the SDK's own queue, export and retry metrics
+func (c *TelemetryConfig) WithSelfMetrics(enabled bool) *TelemetryConfig {
+	c.selfMetrics = enabled
+	return c
+}
+
 // WithV2API enables/disables v2 API endpoints (aligned with tfoexporter)
 func (c *TelemetryConfig) WithV2API(enabled bool) *TelemetryConfig {
 	c.useV2API = enabled
@@ -509,12 +732,69 @@ func (c *TelemetryConfig) Validate() error {
 	if c.timeout <= 0 {
 		return errors.New("timeout must be positive")
 	}
+	for i, endpoint := range c.failoverEndpoints {
+		if endpoint == "" {
+			return fmt.Errorf("failover endpoint %d cannot be empty", i)
+		}
+		if endpoint == c.endpoint {
+			return fmt.Errorf("failover endpoint %s duplicates the primary endpoint", endpoint)
+		}
+	}
+	if c.IsFailoverEnabled() && c.failoverProbeInterval <= 0 {
+		return errors.New("failover probe interval must be positive")
+	}
+	if c.runtimeMetrics && c.runtimeMetricsInterval <= 0 {
+		return errors.New("runtime metrics interval must be positive")
+	}
+	if err := c.validateSignalSettings(); err != nil {
+		return err
+	}
+	if err := c.validateEndpoints(); err != nil {
+		return err
+	}
+	if err := c.validateHTTPTransport(); err != nil {
+		return err
+	}
+	if err := c.validateExporters(); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(c.destinations))
+	for _, destination := range c.destinations {
+		if destination == nil {
+			return errors.New("destination cannot be nil")
+		}
+		if err := destination.Validate(); err != nil {
+			return err
+		}
+		if names[destination.Name()] {
+			return fmt.Errorf("duplicate destination name: %s", destination.Name())
+		}
+		names[destination.Name()] = true
+	}
 	if c.maxRetries < 0 {
 		return errors.New("max retries cannot be negative")
 	}
+	if c.retryMaxBackoff < 0 {
+		return errors.New("retry max backoff cannot be negative")
+	}
+	if err := c.validateCircuitBreaker(); err != nil {
+		return err
+	}
+	if err := c.validateMemoryLimiter(); err != nil {
+		return err
+	}
+	if err := c.validateTimestampSkew(); err != nil {
+		return err
+	}
+	if err := c.validateLogFilter(); err != nil {
+		return err
+	}
 	if c.batchMaxSize <= 0 {
 		return errors.New("batch max size must be positive")
 	}
+	if c.maxRequestSize < 0 {
+		return errors.New("max request size cannot be negative")
+	}
 	if c.rateLimit < 0 {
 		return errors.New("rate limit cannot be negative")
 	}
@@ -0,0 +1,204 @@
+// Package domain provides core domain types for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Destination is an additional named export target. Telemetry is sent to every
+// destination in addition to the primary endpoint, each with its own credentials,
+// connection settings, export headers, signal selection and retry policy.
+type Destination struct {
+	name        string
+	credentials *Credentials // optional - nil sends no TelemetryFlow auth headers
+	endpoint    string
+	protocol    Protocol
+	insecure    bool
+	useV2API    bool
+
+	enabledSignals map[SignalType]bool
+
+	retryEnabled bool
+	maxRetries   int
+	retryBackoff time.Duration
+
+	headers        map[string]string
+	headerProvider HeaderProvider
+}
+
+// NewDestination creates a destination with required fields
+func NewDestination(name, endpoint string) (*Destination, error) {
+	if name == "" {
+		return nil, errors.New("destination name cannot be empty")
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("destination %s: endpoint cannot be empty", name)
+	}
+	parsed, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("destination %s: %w", name, err)
+	}
+
+	// URL endpoints imply the protocol and TLS defaults; With* setters still override them
+	protocol := ProtocolGRPC // default
+	if inferred, ok := parsed.InferredProtocol(); ok {
+		protocol = inferred
+	}
+	insecure, _ := parsed.InferredInsecure()
+
+	return &Destination{
+		name:     name,
+		endpoint: endpoint,
+		protocol: protocol,
+		insecure: insecure,
+		useV2API: true,
+		enabledSignals: map[SignalType]bool{
+			SignalMetrics: true,
+			SignalLogs:    true,
+			SignalTraces:  true,
+		},
+		retryEnabled: true,
+		maxRetries:   3,
+		retryBackoff: 5 * time.Second,
+	}, nil
+}
+
+// Name returns the destination name.
+func (d *Destination) Name() string { return d.name }
+
+// Credentials returns the destination credentials, or nil if none are set.
+func (d *Destination) Credentials() *Credentials { return d.credentials }
+
+// Endpoint returns the destination endpoint address.
+func (d *Destination) Endpoint() string { return d.endpoint }
+
+// Protocol returns the destination OTLP protocol.
+func (d *Destination) Protocol() Protocol { return d.protocol }
+
+// IsInsecure returns true if TLS verification is disabled for the destination.
+func (d *Destination) IsInsecure() bool { return d.insecure }
+
+// UseV2API returns true if the destination uses TFO v2 API endpoints.
+func (d *Destination) UseV2API() bool { return d.useV2API }
+
+// IsSignalEnabled checks if a signal type is sent to the destination
+func (d *Destination) IsSignalEnabled(signal SignalType) bool { return d.enabledSignals[signal] }
+
+// IsRetryEnabled returns true if automatic retries are enabled for the destination.
+func (d *Destination) IsRetryEnabled() bool { return d.retryEnabled }
+
+// MaxRetries returns the maximum number of retry attempts for the destination.
+func (d *Destination) MaxRetries() int { return d.maxRetries }
+
+// RetryBackoff returns the backoff duration between retries for the destination.
+func (d *Destination) RetryBackoff() time.Duration { return d.retryBackoff }
+
+// Headers returns the static headers sent with every HTTP export to the destination.
+func (d *Destination) Headers() map[string]string { return d.headers }
+
+// HeaderProvider returns the provider of per-request headers for the destination, if any.
+func (d *Destination) HeaderProvider() HeaderProvider { return d.headerProvider }
+
+// WithCredentials sets the destination credentials
+func (d *Destination) WithCredentials(credentials *Credentials) *Destination {
+	d.credentials = credentials
+	return d
+}
+
+// WithProtocol sets the destination protocol
+func (d *Destination) WithProtocol(protocol Protocol) *Destination {
+	d.protocol = protocol
+	return d
+}
+
+// WithInsecure sets insecure connection for the destination
+func (d *Destination) WithInsecure(insecure bool) *Destination {
+	d.insecure = insecure
+	return d
+}
+
+// WithV2API enables/disables TFO v2 API endpoints (disable for vanilla OTel collectors)
+func (d *Destination) WithV2API(enabled bool) *Destination {
+	d.useV2API = enabled
+	return d
+}
+
+// WithSignals configures which signals are sent to the destination
+func (d *Destination) WithSignals(metrics, logs, traces bool) *Destination {
+	d.enabledSignals[SignalMetrics] = metrics
+	d.enabledSignals[SignalLogs] = logs
+	d.enabledSignals[SignalTraces] = traces
+	return d
+}
+
+// WithRetry configures retry behavior for the destination
+func (d *Destination) WithRetry(enabled bool, maxRetries int, backoff time.Duration) *Destination {
+	d.retryEnabled = enabled
+	d.maxRetries = maxRetries
+	d.retryBackoff = backoff
+	return d
+}
+
+// WithHeader adds a static header sent with every HTTP export to the destination
+func (d *Destination) WithHeader(key, value string) *Destination {
+	if d.headers == nil {
+		d.headers = make(map[string]string)
+	}
+	d.headers[key] = value
+	return d
+}
+
+// WithHeaderProvider sets a function called for every HTTP export request to the
+// destination to add headers
+func (d *Destination) WithHeaderProvider(provider HeaderProvider) *Destination {
+	d.headerProvider = provider
+	return d
+}
+
+// Validate ensures the destination is valid
+func (d *Destination) Validate() error {
+	if d.name == "" {
+		return errors.New("destination name cannot be empty")
+	}
+	if d.endpoint == "" {
+		return fmt.Errorf("destination %s: endpoint cannot be empty", d.name)
+	}
+	if d.protocol != ProtocolGRPC && d.protocol != ProtocolHTTP {
+		return fmt.Errorf("destination %s: unsupported protocol: %s", d.name, d.protocol)
+	}
+	parsed, err := ParseEndpoint(d.endpoint)
+	if err != nil {
+		return fmt.Errorf("destination %s: %w", d.name, err)
+	}
+	if err := parsed.Validate(d.protocol); err != nil {
+		return fmt.Errorf("destination %s: %w", d.name, err)
+	}
+	if d.maxRetries < 0 {
+		return fmt.Errorf("destination %s: max retries cannot be negative", d.name)
+	}
+	return nil
+}
+
+// String returns a string representation of the destination
+func (d *Destination) String() string {
+	return fmt.Sprintf("Destination{name: %s, endpoint: %s, protocol: %s}", d.name, d.endpoint, d.protocol)
+}
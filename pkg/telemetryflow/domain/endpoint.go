@@ -0,0 +1,172 @@
+// Package domain provides core domain types for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Endpoint schemes
+const (
+	SchemeHTTP  = "http"
+	SchemeHTTPS = "https"
+	SchemeUnix  = "unix"
+)
+
+// signalPathPattern matches export paths that belong in the signal path overrides
+var signalPathPattern = regexp.MustCompile(`/v[12]/(traces|metrics|logs)$`)
+
+// EndpointURL is a parsed collector endpoint. It is either a bare host:port,
+// an http(s) URL with an optional base path, or a unix domain socket.
+type EndpointURL struct {
+	Scheme     string // http, https, unix, or empty for a bare host:port
+	Host       string // host[:port], empty for unix sockets
+	BasePath   string // path prefix for signal paths, without trailing slash
+	SocketPath string // unix domain socket path
+}
+
+// ParseEndpoint parses an endpoint such as "localhost:4317",
+// "https://gateway.example.com/otlp" or "unix:///var/run/tfo-agent.sock"
+func ParseEndpoint(raw string) (*EndpointURL, error) {
+	if raw == "" {
+		return nil, errors.New("endpoint cannot be empty")
+	}
+
+	if !strings.Contains(raw, "://") {
+		if strings.ContainsAny(raw, "/?#") {
+			return nil, fmt.Errorf("endpoint %s: use a URL with a scheme to set a path", raw)
+		}
+		return &EndpointURL{Host: raw}, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint %s: %w", raw, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case SchemeUnix:
+		if u.Host != "" || u.Path == "" {
+			return nil, fmt.Errorf("endpoint %s: unix endpoints must look like unix:///path/to/socket", raw)
+		}
+		return &EndpointURL{Scheme: SchemeUnix, SocketPath: u.Path}, nil
+	case SchemeHTTP, SchemeHTTPS:
+		if u.Host == "" {
+			return nil, fmt.Errorf("endpoint %s: host cannot be empty", raw)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return nil, fmt.Errorf("endpoint %s: query and fragment are not supported", raw)
+		}
+		return &EndpointURL{
+			Scheme:   strings.ToLower(u.Scheme),
+			Host:     u.Host,
+			BasePath: strings.TrimSuffix(u.Path, "/"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("endpoint %s: unsupported scheme %q (use http, https or unix)", raw, u.Scheme)
+	}
+}
+
+// IsUnix returns true if the endpoint is a unix domain socket.
+func (e *EndpointURL) IsUnix() bool { return e.Scheme == SchemeUnix }
+
+// InferredInsecure returns whether the scheme implies a plaintext connection.
+// ok is false for a bare host:port, which carries no hint.
+func (e *EndpointURL) InferredInsecure() (insecure bool, ok bool) {
+	switch e.Scheme {
+	case SchemeHTTP, SchemeUnix:
+		return true, true
+	case SchemeHTTPS:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// InferredProtocol returns the protocol implied by the scheme.
+// ok is false for bare host:port and unix endpoints, which work with either protocol.
+func (e *EndpointURL) InferredProtocol() (protocol Protocol, ok bool) {
+	switch e.Scheme {
+	case SchemeHTTP, SchemeHTTPS:
+		return ProtocolHTTP, true
+	default:
+		return "", false
+	}
+}
+
+// Validate ensures the endpoint can be used with the given protocol
+func (e *EndpointURL) Validate(protocol Protocol) error {
+	if e.BasePath == "" {
+		return nil
+	}
+	if protocol == ProtocolGRPC {
+		return fmt.Errorf("endpoint %s: grpc endpoints cannot have a URL path", e)
+	}
+	if signalPathPattern.MatchString(e.BasePath) {
+		return fmt.Errorf("endpoint %s: the URL path is a base path, set signal paths with the signal endpoint overrides", e)
+	}
+	return nil
+}
+
+// String returns the endpoint in URL form
+func (e *EndpointURL) String() string {
+	switch e.Scheme {
+	case "":
+		return e.Host
+	case SchemeUnix:
+		return SchemeUnix + "://" + e.SocketPath
+	default:
+		return e.Scheme + "://" + e.Host + e.BasePath
+	}
+}
+
+// EndpointInsecure returns true if TLS is disabled for an endpoint.
+// The primary endpoint follows IsInsecure; other endpoints follow their
+// scheme when they have one and fall back to IsInsecure otherwise.
+func (c *TelemetryConfig) EndpointInsecure(endpoint string) bool {
+	if endpoint == c.endpoint {
+		return c.insecure
+	}
+	if parsed, err := ParseEndpoint(endpoint); err == nil {
+		if insecure, ok := parsed.InferredInsecure(); ok {
+			return insecure
+		}
+	}
+	return c.insecure
+}
+
+// validateEndpoints ensures every endpoint parses and suits its signal's protocol
+func (c *TelemetryConfig) validateEndpoints() error {
+	for _, signal := range []SignalType{SignalTraces, SignalMetrics, SignalLogs} {
+		for _, endpoint := range c.SignalEndpoints(signal) {
+			parsed, err := ParseEndpoint(endpoint)
+			if err != nil {
+				return err
+			}
+			if err := parsed.Validate(c.SignalProtocol(signal)); err != nil {
+				return fmt.Errorf("%s: %w", signal, err)
+			}
+		}
+	}
+	return nil
+}
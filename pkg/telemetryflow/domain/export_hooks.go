@@ -0,0 +1,77 @@
+// Package domain provides the export callbacks and error handling settings for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCredentialsRejected is wrapped by export errors caused by the collector rejecting
+// the credentials (HTTP 401/403, gRPC Unauthenticated/PermissionDenied)
+var ErrCredentialsRejected = errors.New("collector rejected the credentials")
+
+// ExportEvent describes the outcome of one export call
+type ExportEvent struct {
+	Signal   SignalType
+	Exporter string        // otlp, console, file or the destination name
+	Items    int           // spans, metric data points or log records in the batch
+	Duration time.Duration // including retries
+	Retries  int
+	// Items that exceeded the request size limit alone: sent with shortened
+	// attribute and body values, or dropped when that was not enough
+	Truncated int
+	Dropped   int
+	Err       error // nil on success
+}
+
+// exportHooks holds the callbacks notified about export outcomes and SDK errors
+type exportHooks struct {
+	errorHandler func(error)
+	onSuccess    []func(ExportEvent)
+	onFailure    []func(ExportEvent)
+}
+
+// ErrorHandler returns the function receiving errors the OpenTelemetry SDK cannot
+// return to the caller, such as failed background exports, or nil for the default.
+func (c *TelemetryConfig) ErrorHandler() func(error) { return c.exportHooks.errorHandler }
+
+// ExportSuccessHooks returns the callbacks run after every successful export.
+func (c *TelemetryConfig) ExportSuccessHooks() []func(ExportEvent) { return c.exportHooks.onSuccess }
+
+// ExportFailureHooks returns the callbacks run after every failed export.
+func (c *TelemetryConfig) ExportFailureHooks() []func(ExportEvent) { return c.exportHooks.onFailure }
+
+// WithErrorHandler sets the function receiving background SDK errors
+func (c *TelemetryConfig) WithErrorHandler(handler func(error)) *TelemetryConfig {
+	c.exportHooks.errorHandler = handler
+	return c
+}
+
+// OnExportSuccess adds a callback run after every successful export
+func (c *TelemetryConfig) OnExportSuccess(hook func(ExportEvent)) *TelemetryConfig {
+	c.exportHooks.onSuccess = append(c.exportHooks.onSuccess, hook)
+	return c
+}
+
+// OnExportFailure adds a callback run after every failed export
+func (c *TelemetryConfig) OnExportFailure(hook func(ExportEvent)) *TelemetryConfig {
+	c.exportHooks.onFailure = append(c.exportHooks.onFailure, hook)
+	return c
+}
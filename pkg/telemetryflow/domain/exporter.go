@@ -0,0 +1,149 @@
+// Package domain provides core domain types for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// ExporterType identifies where telemetry is exported
+type ExporterType string
+
+const (
+	ExporterOTLP       ExporterType = "otlp"       // primary OTLP endpoint
+	ExporterConsole    ExporterType = "console"    // human-readable output for local development
+	ExporterFile       ExporterType = "file"       // OTLP/JSON lines in rotating files, for later replay
+	ExporterPrometheus ExporterType = "prometheus" // pull-based metrics endpoint for Prometheus scrapers
+)
+
+// FileExporterConfig configures the OTLP/JSON file exporter.
+// Each signal is written to <Directory>/<signal>.jsonl, one export request per line.
+type FileExporterConfig struct {
+	Directory string        // output directory, created if missing
+	MaxSize   int64         // rotate once the active file reaches this many bytes (0: no size rotation)
+	MaxAge    time.Duration // rotate once the active file is this old (0: no time rotation)
+	Compress  bool          // gzip rotated files
+}
+
+// DefaultFileExporterConfig returns the file exporter defaults: ./telemetry, 100 MiB, 1h, gzip
+func DefaultFileExporterConfig() FileExporterConfig {
+	return FileExporterConfig{
+		Directory: "telemetry",
+		MaxSize:   100 << 20,
+		MaxAge:    time.Hour,
+		Compress:  true,
+	}
+}
+
+// Exporters returns the enabled exporters in a stable order.
+func (c *TelemetryConfig) Exporters() []ExporterType {
+	exporters := make([]ExporterType, 0, len(c.exporters))
+	for exporter, enabled := range c.exporters {
+		if enabled {
+			exporters = append(exporters, exporter)
+		}
+	}
+	sort.Slice(exporters, func(i, j int) bool { return exporters[i] < exporters[j] })
+	return exporters
+}
+
+// IsExporterEnabled returns true if telemetry is sent to the given exporter.
+func (c *TelemetryConfig) IsExporterEnabled(exporter ExporterType) bool {
+	return c.exporters[exporter]
+}
+
+// PrometheusEndpoint returns the address the Prometheus metrics endpoint listens on
+// (empty: the handler is only available in-process).
+func (c *TelemetryConfig) PrometheusEndpoint() string { return c.prometheusEndpoint }
+
+// WithPrometheusEndpoint sets the listen address of the Prometheus metrics endpoint, e.g. ":9464";
+// enable it with WithExporters(..., ExporterPrometheus)
+func (c *TelemetryConfig) WithPrometheusEndpoint(addr string) *TelemetryConfig {
+	c.prometheusEndpoint = addr
+	return c
+}
+
+// ConsoleWriter returns the writer used by the console exporter (nil means stdout).
+func (c *TelemetryConfig) ConsoleWriter() io.Writer { return c.consoleWriter }
+
+// FileExporter returns the file exporter settings.
+func (c *TelemetryConfig) FileExporter() FileExporterConfig { return c.fileExporter }
+
+// WithFileExporter sets the file exporter settings; enable it with WithExporters(..., ExporterFile)
+func (c *TelemetryConfig) WithFileExporter(cfg FileExporterConfig) *TelemetryConfig {
+	c.fileExporter = cfg
+	return c
+}
+
+// WithExporters replaces the enabled exporters, e.g. WithExporters(ExporterOTLP, ExporterConsole)
+func (c *TelemetryConfig) WithExporters(exporters ...ExporterType) *TelemetryConfig {
+	c.exporters = make(map[ExporterType]bool, len(exporters))
+	for _, exporter := range exporters {
+		c.exporters[exporter] = true
+	}
+	return c
+}
+
+// WithConsoleWriter sets the writer used by the console exporter
+func (c *TelemetryConfig) WithConsoleWriter(w io.Writer) *TelemetryConfig {
+	c.consoleWriter = w
+	return c
+}
+
+// HasInProcessConsumers returns true if processors or readers registered in code receive telemetry.
+func (c *TelemetryConfig) HasInProcessConsumers() bool { return c.inProcess }
+
+// WithInProcessConsumers marks that telemetry is consumed in-process (for example by a test
+// recorder), so a configuration without exporters or destinations is still valid
+func (c *TelemetryConfig) WithInProcessConsumers(enabled bool) *TelemetryConfig {
+	c.inProcess = enabled
+	return c
+}
+
+// validateExporters ensures at least one known exporter is enabled, unless telemetry goes to
+// destinations or in-process consumers
+func (c *TelemetryConfig) validateExporters() error {
+	enabled := 0
+	for exporter, on := range c.exporters {
+		switch exporter {
+		case ExporterOTLP, ExporterConsole, ExporterFile, ExporterPrometheus:
+		default:
+			return fmt.Errorf("unknown exporter: %s", exporter)
+		}
+		if on {
+			enabled++
+		}
+	}
+	if enabled == 0 && len(c.destinations) == 0 && !c.inProcess {
+		return errors.New("at least one exporter must be enabled")
+	}
+	if c.exporters[ExporterFile] {
+		if c.fileExporter.Directory == "" {
+			return errors.New("file exporter directory cannot be empty")
+		}
+		if c.fileExporter.MaxSize < 0 || c.fileExporter.MaxAge < 0 {
+			return errors.New("file exporter rotation limits cannot be negative")
+		}
+	}
+	return nil
+}
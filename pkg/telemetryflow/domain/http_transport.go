@@ -0,0 +1,189 @@
+// Package domain provides core domain types for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HeaderProvider returns headers added to every HTTP export request, such as
+// short-lived tokens. An error fails the export request.
+type HeaderProvider func(ctx context.Context) (map[string]string, error)
+
+// HTTPEncoding is the body encoding of OTLP/HTTP export requests
+type HTTPEncoding string
+
+const (
+	HTTPEncodingProtobuf HTTPEncoding = "protobuf"
+	HTTPEncodingJSON     HTTPEncoding = "json"
+)
+
+// ContentType returns the Content-Type header of requests in this encoding
+func (e HTTPEncoding) ContentType() string {
+	if e == HTTPEncodingJSON {
+		return "application/json"
+	}
+	return "application/x-protobuf"
+}
+
+// httpTransportSettings holds the transport settings shared by all HTTP exporters
+type httpTransportSettings struct {
+	proxyURL       string
+	proxyUsername  string
+	proxyPassword  string
+	noProxy        []string
+	client         *http.Client
+	roundTripper   http.RoundTripper
+	headers        map[string]string
+	headerProvider HeaderProvider
+	encoding       HTTPEncoding
+}
+
+// ProxyURL returns the proxy used by HTTP exporters. Empty means the standard
+// HTTP_PROXY/HTTPS_PROXY environment variables apply.
+func (c *TelemetryConfig) ProxyURL() string { return c.httpTransport.proxyURL }
+
+// ProxyCredentials returns the username and password used to authenticate with the proxy.
+func (c *TelemetryConfig) ProxyCredentials() (username, password string) {
+	return c.httpTransport.proxyUsername, c.httpTransport.proxyPassword
+}
+
+// NoProxy returns the hosts, domains and CIDR ranges that bypass the proxy.
+func (c *TelemetryConfig) NoProxy() []string { return c.httpTransport.noProxy }
+
+// HTTPClient returns the custom HTTP client used by HTTP exporters, if any.
+func (c *TelemetryConfig) HTTPClient() *http.Client { return c.httpTransport.client }
+
+// HTTPTransport returns the custom HTTP transport used by HTTP exporters, if any.
+func (c *TelemetryConfig) HTTPTransport() http.RoundTripper { return c.httpTransport.roundTripper }
+
+// Headers returns the static headers sent with every HTTP export.
+func (c *TelemetryConfig) Headers() map[string]string { return c.httpTransport.headers }
+
+// HeaderProvider returns the provider of per-request headers, if any.
+func (c *TelemetryConfig) HeaderProvider() HeaderProvider { return c.httpTransport.headerProvider }
+
+// HTTPEncoding returns the body encoding of HTTP exports (protobuf by default).
+func (c *TelemetryConfig) HTTPEncoding() HTTPEncoding {
+	if c.httpTransport.encoding == "" {
+		return HTTPEncodingProtobuf
+	}
+	return c.httpTransport.encoding
+}
+
+// WithProxy routes HTTP exports through a proxy (http, https or socks5 URL)
+func (c *TelemetryConfig) WithProxy(proxyURL string) *TelemetryConfig {
+	c.httpTransport.proxyURL = proxyURL
+	return c
+}
+
+// WithProxyAuth sets the credentials used to authenticate with the proxy
+func (c *TelemetryConfig) WithProxyAuth(username, password string) *TelemetryConfig {
+	c.httpTransport.proxyUsername = username
+	c.httpTransport.proxyPassword = password
+	return c
+}
+
+// WithNoProxy sets the hosts that bypass the proxy, using NO_PROXY syntax
+// (host names, .domain suffixes, IP addresses and CIDR ranges)
+func (c *TelemetryConfig) WithNoProxy(hosts ...string) *TelemetryConfig {
+	c.httpTransport.noProxy = hosts
+	return c
+}
+
+// WithHTTPClient sets the HTTP client used by HTTP exporters.
+// Its transport is still wrapped for API version negotiation and dynamic headers.
+// It cannot be combined with WithProxy or WithNoProxy: the transport's own proxy
+// settings apply.
+func (c *TelemetryConfig) WithHTTPClient(client *http.Client) *TelemetryConfig {
+	c.httpTransport.client = client
+	return c
+}
+
+// WithHTTPTransport sets the HTTP transport used by HTTP exporters, e.g. for request signing.
+// It cannot be combined with WithProxy or WithNoProxy: the transport's own proxy
+// settings apply.
+func (c *TelemetryConfig) WithHTTPTransport(transport http.RoundTripper) *TelemetryConfig {
+	c.httpTransport.roundTripper = transport
+	return c
+}
+
+// WithHeader adds a static header sent with every HTTP export
+func (c *TelemetryConfig) WithHeader(key, value string) *TelemetryConfig {
+	if c.httpTransport.headers == nil {
+		c.httpTransport.headers = make(map[string]string)
+	}
+	c.httpTransport.headers[key] = value
+	return c
+}
+
+// WithHeaderProvider sets a function called for every HTTP export request to add headers
+func (c *TelemetryConfig) WithHeaderProvider(provider HeaderProvider) *TelemetryConfig {
+	c.httpTransport.headerProvider = provider
+	return c
+}
+
+// WithHTTPEncoding sets the body encoding of HTTP exports of all signals, e.g.
+// HTTPEncodingJSON for gateways that only inspect JSON
+func (c *TelemetryConfig) WithHTTPEncoding(encoding HTTPEncoding) *TelemetryConfig {
+	c.httpTransport.encoding = encoding
+	return c
+}
+
+// validateHTTPTransport ensures the HTTP transport settings are consistent
+func (c *TelemetryConfig) validateHTTPTransport() error {
+	settings := c.httpTransport
+	switch settings.encoding {
+	case "", HTTPEncodingProtobuf, HTTPEncodingJSON:
+	default:
+		return fmt.Errorf("unsupported HTTP encoding: %s (use protobuf or json)", settings.encoding)
+	}
+	if settings.client != nil && settings.roundTripper != nil {
+		return errors.New("set either a custom HTTP client or a custom HTTP transport, not both")
+	}
+	// The SDK does not change the proxy of a transport it did not create
+	if (settings.proxyURL != "" || len(settings.noProxy) > 0) && (settings.client != nil || settings.roundTripper != nil) {
+		return errors.New("proxy settings cannot be combined with a custom HTTP client or transport; configure the proxy on its transport")
+	}
+	if settings.proxyURL == "" {
+		if settings.proxyUsername != "" || settings.proxyPassword != "" {
+			return errors.New("proxy credentials require a proxy URL")
+		}
+		return nil
+	}
+
+	u, err := url.Parse(settings.proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("proxy URL %s: unsupported scheme %q (use http, https or socks5)", settings.proxyURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy URL %s: host cannot be empty", settings.proxyURL)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package domain provides the log filter configuration for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"time"
+)
+
+// LoggerNameAttribute is the log attribute naming the logger of a record, e.g. the name
+// of a zap logger. The log filter selects the minimum severity of a record by it.
+const LoggerNameAttribute = "logger.name"
+
+// LogFilterConfig configures which log records the SDK emits.
+//
+// Records below the minimum severity of their logger are dropped. The logger of a record
+// is its LoggerNameAttribute, matched against LoggerSeverities by the longest
+// dot-separated prefix, so "db" also applies to "db.pool"; other records use
+// MinSeverity.
+//
+// Sampling and de-duplication group records by severity, logger and message template,
+// the message with every run of digits replaced by "#". With sampling, the first
+// SamplingInitial records of a template in each SamplingInterval are emitted, then every
+// SamplingThereafter-th. With de-duplication, a record starts a DedupWindow in which
+// the repeats of its template are suppressed; a record "suppressed N similar messages"
+// is emitted when the window ends.
+type LogFilterConfig struct {
+	MinSeverity        string            // lowest severity emitted, e.g. "info" ("": all)
+	LoggerSeverities   map[string]string // lowest severity per logger name prefix
+	SamplingInterval   time.Duration     // sampling window (0: no sampling)
+	SamplingInitial    int               // records per template emitted in each window
+	SamplingThereafter int               // then every n-th record (0: none)
+	DedupWindow        time.Duration     // window suppressing repeats (0: no de-duplication)
+}
+
+// DefaultLogFilterConfig returns the log filter defaults: every record is emitted
+func DefaultLogFilterConfig() LogFilterConfig {
+	return LogFilterConfig{}
+}
+
+// Enabled returns true if the filter may drop records.
+func (c LogFilterConfig) Enabled() bool {
+	return c.MinSeverity != "" || len(c.LoggerSeverities) > 0 || c.SamplingInterval > 0 || c.DedupWindow > 0
+}
+
+// Clone returns a copy of the config that does not share LoggerSeverities
+func (c LogFilterConfig) Clone() LogFilterConfig {
+	c.LoggerSeverities = maps.Clone(c.LoggerSeverities)
+	return c
+}
+
+// logSeverityPattern matches the log severity names, with the numbered OTLP severities
+// such as "info2"
+var logSeverityPattern = regexp.MustCompile(`(?i)^((trace|debug|info|warn|error|fatal)[234]?|warning|critical)$`)
+
+// Validate checks the log filter settings
+func (c LogFilterConfig) Validate() error {
+	if c.MinSeverity != "" && !logSeverityPattern.MatchString(c.MinSeverity) {
+		return fmt.Errorf("unknown log filter severity: %s", c.MinSeverity)
+	}
+	for logger, severity := range c.LoggerSeverities {
+		if logger == "" {
+			return errors.New("log filter logger name cannot be empty")
+		}
+		if !logSeverityPattern.MatchString(severity) {
+			return fmt.Errorf("unknown log filter severity for logger %s: %s", logger, severity)
+		}
+	}
+	if c.SamplingInterval < 0 || c.DedupWindow < 0 {
+		return errors.New("log filter windows cannot be negative")
+	}
+	if c.SamplingInitial < 0 || c.SamplingThereafter < 0 {
+		return errors.New("log sampling counts cannot be negative")
+	}
+	if c.SamplingInterval > 0 && c.SamplingInitial == 0 && c.SamplingThereafter == 0 {
+		return errors.New("log sampling must keep initial or thereafter records")
+	}
+	return nil
+}
+
+// LogFilter returns the log filter settings.
+func (c *TelemetryConfig) LogFilter() LogFilterConfig { return c.logFilter.Clone() }
+
+// WithLogFilter sets the log filter
+func (c *TelemetryConfig) WithLogFilter(cfg LogFilterConfig) *TelemetryConfig {
+	c.logFilter = cfg.Clone()
+	return c
+}
+
+// validateLogFilter checks the log filter settings
+func (c *TelemetryConfig) validateLogFilter() error {
+	return c.logFilter.Validate()
+}
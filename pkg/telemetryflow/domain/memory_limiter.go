@@ -0,0 +1,98 @@
+// Package domain provides the memory limiter settings for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MemoryLimiterConfig configures the memory limiter. The limiter estimates the memory
+// held by spans and log records waiting in the SDK's batch queues and circuit breaker
+// buffers and, when heap limits are set, also reads the Go runtime heap. Above a soft
+// limit new traces are sampled at SamplingRatio and log records below MinSeverity are
+// dropped; above a hard limit all new spans and log records are dropped. Metrics are
+// aggregated in place and are not limited.
+type MemoryLimiterConfig struct {
+	SoftLimit     int64         // bytes of buffered data above which the SDK degrades (0: none)
+	HardLimit     int64         // bytes of buffered data above which new data is dropped (0: none)
+	HeapSoftLimit int64         // Go heap bytes above which the SDK degrades (0: not checked)
+	HeapHardLimit int64         // Go heap bytes above which new data is dropped (0: not checked)
+	SamplingRatio float64       // share of new traces sampled above a soft limit
+	MinSeverity   string        // lowest log severity kept above a soft limit, e.g. "warn" ("": all)
+	CheckInterval time.Duration // minimum time between Go heap reads
+}
+
+// DefaultMemoryLimiterConfig returns the memory limiter defaults: 32 MiB soft and 64 MiB
+// hard limits on buffered data, the heap unchecked, 10% sampling and warn logs above the
+// soft limit, and a heap read at most every second
+func DefaultMemoryLimiterConfig() MemoryLimiterConfig {
+	return MemoryLimiterConfig{
+		SoftLimit:     32 << 20,
+		HardLimit:     64 << 20,
+		SamplingRatio: 0.1,
+		MinSeverity:   "warn",
+		CheckInterval: time.Second,
+	}
+}
+
+// Enabled returns true if any limit is set.
+func (c MemoryLimiterConfig) Enabled() bool {
+	return c.SoftLimit > 0 || c.HardLimit > 0 || c.HeapSoftLimit > 0 || c.HeapHardLimit > 0
+}
+
+// MemoryLimiter returns the memory limiter settings.
+func (c *TelemetryConfig) MemoryLimiter() MemoryLimiterConfig { return c.memoryLimiter }
+
+// WithMemoryLimiter sets the memory limiter, e.g. WithMemoryLimiter(DefaultMemoryLimiterConfig())
+func (c *TelemetryConfig) WithMemoryLimiter(cfg MemoryLimiterConfig) *TelemetryConfig {
+	c.memoryLimiter = cfg
+	return c
+}
+
+// validateMemoryLimiter checks the memory limiter settings
+func (c *TelemetryConfig) validateMemoryLimiter() error {
+	ml := c.memoryLimiter
+	if ml.SoftLimit < 0 || ml.HardLimit < 0 || ml.HeapSoftLimit < 0 || ml.HeapHardLimit < 0 {
+		return errors.New("memory limits cannot be negative")
+	}
+	if !ml.Enabled() {
+		return nil
+	}
+	if ml.SoftLimit > 0 && ml.HardLimit > 0 && ml.SoftLimit > ml.HardLimit {
+		return errors.New("memory soft limit cannot exceed the hard limit")
+	}
+	if ml.HeapSoftLimit > 0 && ml.HeapHardLimit > 0 && ml.HeapSoftLimit > ml.HeapHardLimit {
+		return errors.New("heap soft limit cannot exceed the heap hard limit")
+	}
+	if ml.SamplingRatio < 0 || ml.SamplingRatio > 1 {
+		return errors.New("memory limiter sampling ratio must be between 0 and 1")
+	}
+	switch strings.ToLower(ml.MinSeverity) {
+	case "", "trace", "debug", "info", "warn", "warning", "error", "fatal", "critical":
+	default:
+		return fmt.Errorf("unknown memory limiter log severity: %s", ml.MinSeverity)
+	}
+	if (ml.HeapSoftLimit > 0 || ml.HeapHardLimit > 0) && ml.CheckInterval <= 0 {
+		return errors.New("memory limiter check interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Package domain provides core domain types for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// signalSettings holds connection overrides for a single signal.
+// Zero values fall back to the shared connection settings.
+type signalSettings struct {
+	endpoint    string
+	protocol    Protocol
+	headers     map[string]string
+	compression *bool
+	timeout     time.Duration
+}
+
+// settingsFor returns the overrides for a signal, creating them if needed
+func (c *TelemetryConfig) settingsFor(signal SignalType) *signalSettings {
+	if c.signalSettings == nil {
+		c.signalSettings = make(map[SignalType]*signalSettings)
+	}
+	settings, ok := c.signalSettings[signal]
+	if !ok {
+		settings = &signalSettings{}
+		c.signalSettings[signal] = settings
+	}
+	return settings
+}
+
+// SignalEndpoint returns the endpoint for a signal, falling back to the shared endpoint.
+func (c *TelemetryConfig) SignalEndpoint(signal SignalType) string {
+	if settings := c.signalSettings[signal]; settings != nil && settings.endpoint != "" {
+		return settings.endpoint
+	}
+	return c.endpoint
+}
+
+// SignalEndpoints returns all endpoints for a signal in priority order.
+// A signal with its own endpoint does not use the shared failover endpoints.
+func (c *TelemetryConfig) SignalEndpoints(signal SignalType) []string {
+	if settings := c.signalSettings[signal]; settings != nil && settings.endpoint != "" {
+		return []string{settings.endpoint}
+	}
+	return c.Endpoints()
+}
+
+// SignalProtocol returns the OTLP protocol for a signal, falling back to the shared protocol.
+// A signal endpoint given as an http(s) URL implies the HTTP protocol.
+func (c *TelemetryConfig) SignalProtocol(signal SignalType) Protocol {
+	settings := c.signalSettings[signal]
+	if settings == nil {
+		return c.protocol
+	}
+	if settings.protocol != "" {
+		return settings.protocol
+	}
+	if settings.endpoint != "" {
+		if parsed, err := ParseEndpoint(settings.endpoint); err == nil {
+			if protocol, ok := parsed.InferredProtocol(); ok {
+				return protocol
+			}
+		}
+	}
+	return c.protocol
+}
+
+// SignalHeaders returns the additional headers sent with a signal's exports.
+func (c *TelemetryConfig) SignalHeaders(signal SignalType) map[string]string {
+	if settings := c.signalSettings[signal]; settings != nil {
+		return settings.headers
+	}
+	return nil
+}
+
+// IsSignalCompressionEnabled returns true if gzip compression is enabled for a signal,
+// falling back to the shared compression setting.
+func (c *TelemetryConfig) IsSignalCompressionEnabled(signal SignalType) bool {
+	if settings := c.signalSettings[signal]; settings != nil && settings.compression != nil {
+		return *settings.compression
+	}
+	return c.compressionGzip
+}
+
+// SignalTimeout returns the export timeout for a signal, falling back to the shared timeout.
+func (c *TelemetryConfig) SignalTimeout(signal SignalType) time.Duration {
+	if settings := c.signalSettings[signal]; settings != nil && settings.timeout != 0 {
+		return settings.timeout
+	}
+	return c.timeout
+}
+
+// WithSignalEndpoint sets the endpoint (host:port, http(s) URL or unix socket) for a single signal
+func (c *TelemetryConfig) WithSignalEndpoint(signal SignalType, endpoint string) *TelemetryConfig {
+	c.settingsFor(signal).endpoint = endpoint
+	return c
+}
+
+// WithSignalProtocol sets the OTLP protocol for a single signal
+func (c *TelemetryConfig) WithSignalProtocol(signal SignalType, protocol Protocol) *TelemetryConfig {
+	c.settingsFor(signal).protocol = protocol
+	return c
+}
+
+// WithSignalHeader adds a header sent only with a single signal's exports
+func (c *TelemetryConfig) WithSignalHeader(signal SignalType, key, value string) *TelemetryConfig {
+	settings := c.settingsFor(signal)
+	if settings.headers == nil {
+		settings.headers = make(map[string]string)
+	}
+	settings.headers[key] = value
+	return c
+}
+
+// WithSignalCompression enables/disables gzip compression for a single signal
+func (c *TelemetryConfig) WithSignalCompression(signal SignalType, enabled bool) *TelemetryConfig {
+	c.settingsFor(signal).compression = &enabled
+	return c
+}
+
+// WithSignalTimeout sets the export timeout for a single signal
+func (c *TelemetryConfig) WithSignalTimeout(signal SignalType, timeout time.Duration) *TelemetryConfig {
+	c.settingsFor(signal).timeout = timeout
+	return c
+}
+
+// validateSignalSettings ensures every per-signal override is valid
+func (c *TelemetryConfig) validateSignalSettings() error {
+	for signal, settings := range c.signalSettings {
+		switch signal {
+		case SignalMetrics, SignalLogs, SignalTraces:
+		default:
+			return fmt.Errorf("unknown signal type: %s", signal)
+		}
+		if settings.protocol != "" && settings.protocol != ProtocolGRPC && settings.protocol != ProtocolHTTP {
+			return fmt.Errorf("%s: unsupported protocol: %s", signal, settings.protocol)
+		}
+		if settings.timeout < 0 {
+			return fmt.Errorf("%s: timeout cannot be negative", signal)
+		}
+	}
+	return nil
+}
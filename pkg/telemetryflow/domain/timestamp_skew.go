@@ -0,0 +1,75 @@
+// Package domain provides the timestamp skew limits for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimestampSkew is wrapped by the errors of metric and log commands whose explicit
+// timestamp is further from the current time than the skew limits allow
+var ErrTimestampSkew = errors.New("timestamp outside the allowed skew")
+
+// TimestampSkewConfig limits how far explicit timestamps on metrics and log records
+// may be from the current time. Data recorded without a timestamp is stamped with the
+// current time and never checked.
+type TimestampSkewConfig struct {
+	MaxPast   time.Duration // oldest timestamp accepted, relative to now (0: unlimited)
+	MaxFuture time.Duration // newest timestamp accepted, relative to now (0: unlimited)
+}
+
+// DefaultTimestampSkewConfig returns the skew defaults: 24h in the past, 5m in the future
+func DefaultTimestampSkewConfig() TimestampSkewConfig {
+	return TimestampSkewConfig{
+		MaxPast:   24 * time.Hour,
+		MaxFuture: 5 * time.Minute,
+	}
+}
+
+// Check returns an error wrapping ErrTimestampSkew if timestamp is outside the limits
+// around now.
+func (c TimestampSkewConfig) Check(timestamp, now time.Time) error {
+	if age := now.Sub(timestamp); c.MaxPast > 0 && age > c.MaxPast {
+		return fmt.Errorf("%w: %s is %s in the past (max %s)", ErrTimestampSkew, timestamp.Format(time.RFC3339Nano), age.Round(time.Millisecond), c.MaxPast)
+	}
+	if ahead := timestamp.Sub(now); c.MaxFuture > 0 && ahead > c.MaxFuture {
+		return fmt.Errorf("%w: %s is %s in the future (max %s)", ErrTimestampSkew, timestamp.Format(time.RFC3339Nano), ahead.Round(time.Millisecond), c.MaxFuture)
+	}
+	return nil
+}
+
+// TimestampSkew returns the limits on explicit metric and log timestamps.
+func (c *TelemetryConfig) TimestampSkew() TimestampSkewConfig { return c.timestampSkew }
+
+// WithTimestampSkew sets the limits on explicit metric and log timestamps, e.g. to
+// backfill data older than a day
+func (c *TelemetryConfig) WithTimestampSkew(cfg TimestampSkewConfig) *TelemetryConfig {
+	c.timestampSkew = cfg
+	return c
+}
+
+// validateTimestampSkew checks the timestamp skew limits
+func (c *TelemetryConfig) validateTimestampSkew() error {
+	if c.timestampSkew.MaxPast < 0 || c.timestampSkew.MaxFuture < 0 {
+		return errors.New("timestamp skew limits cannot be negative")
+	}
+	return nil
+}
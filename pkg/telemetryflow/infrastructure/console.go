@@ -0,0 +1,381 @@
+// Package infrastructure provides console exporters for local development with the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ANSI colors used for console log severities
+const (
+	colorReset  = "\033[0m"
+	colorGray   = "\033[90m"
+	colorCyan   = "\033[36m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+)
+
+// consoleOutput serializes writes from the console exporters to a shared writer
+type consoleOutput struct {
+	mu    sync.Mutex
+	w     io.Writer
+	color bool
+}
+
+// newConsoleOutput wraps w (stdout when nil). Colors are used only for terminals
+// and are disabled by the NO_COLOR environment variable.
+func newConsoleOutput(w io.Writer) *consoleOutput {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &consoleOutput{w: w, color: isTerminal(w) && os.Getenv("NO_COLOR") == ""}
+}
+
+func (o *consoleOutput) write(p []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := o.w.Write(p)
+	return err
+}
+
+func (o *consoleOutput) paint(color, text string) string {
+	if !o.color {
+		return text
+	}
+	return color + text + colorReset
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ===== SPANS =====
+
+// ConsoleSpanExporter prints spans as an indented tree per trace with durations.
+// Spans whose parent is not part of the same batch are printed as roots.
+type ConsoleSpanExporter struct {
+	out *consoleOutput
+}
+
+// NewConsoleSpanExporter creates a span exporter writing to w (stdout when nil)
+func NewConsoleSpanExporter(w io.Writer) *ConsoleSpanExporter {
+	return &ConsoleSpanExporter{out: newConsoleOutput(w)}
+}
+
+// ExportSpans implements sdktrace.SpanExporter
+func (e *ConsoleSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	var traceOrder []trace.TraceID
+	byTrace := make(map[trace.TraceID][]sdktrace.ReadOnlySpan)
+	for _, span := range spans {
+		traceID := span.SpanContext().TraceID()
+		if _, ok := byTrace[traceID]; !ok {
+			traceOrder = append(traceOrder, traceID)
+		}
+		byTrace[traceID] = append(byTrace[traceID], span)
+	}
+
+	var b strings.Builder
+	for _, traceID := range traceOrder {
+		e.writeTrace(&b, traceID, byTrace[traceID])
+	}
+	return e.out.write([]byte(b.String()))
+}
+
+func (e *ConsoleSpanExporter) writeTrace(b *strings.Builder, traceID trace.TraceID, spans []sdktrace.ReadOnlySpan) {
+	inBatch := make(map[trace.SpanID]bool, len(spans))
+	for _, span := range spans {
+		inBatch[span.SpanContext().SpanID()] = true
+	}
+
+	var roots []sdktrace.ReadOnlySpan
+	children := make(map[trace.SpanID][]sdktrace.ReadOnlySpan)
+	for _, span := range spans {
+		parent := span.Parent().SpanID()
+		if span.Parent().IsValid() && inBatch[parent] {
+			children[parent] = append(children[parent], span)
+		} else {
+			roots = append(roots, span)
+		}
+	}
+
+	fmt.Fprintf(b, "%s %s\n", e.out.paint(colorGray, "trace"), traceID)
+	e.writeSpans(b, roots, children, "")
+}
+
+func (e *ConsoleSpanExporter) writeSpans(b *strings.Builder, spans []sdktrace.ReadOnlySpan, children map[trace.SpanID][]sdktrace.ReadOnlySpan, indent string) {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].StartTime().Before(spans[j].StartTime()) })
+	for i, span := range spans {
+		branch, next := "├─ ", "│  "
+		if i == len(spans)-1 {
+			branch, next = "└─ ", "   "
+		}
+
+		fmt.Fprintf(b, "%s%s%s [%s] %s", indent, branch, span.Name(), span.SpanKind(),
+			e.out.paint(colorCyan, formatDuration(span.EndTime().Sub(span.StartTime()))))
+		if attrs := formatAttributes(span.Attributes()); attrs != "" {
+			b.WriteString("  " + e.out.paint(colorGray, attrs))
+		}
+		if status := span.Status(); status.Code == codes.Error {
+			b.WriteString("  " + e.out.paint(colorRed, strings.TrimSpace("ERROR "+status.Description)))
+		}
+		b.WriteString("\n")
+
+		for _, event := range span.Events() {
+			fmt.Fprintf(b, "%s%s• %s +%s", indent, next, event.Name, formatDuration(event.Time.Sub(span.StartTime())))
+			if attrs := formatAttributes(event.Attributes); attrs != "" {
+				b.WriteString("  " + e.out.paint(colorGray, attrs))
+			}
+			b.WriteString("\n")
+		}
+
+		e.writeSpans(b, children[span.SpanContext().SpanID()], children, indent+next)
+	}
+}
+
+// Shutdown implements sdktrace.SpanExporter
+func (e *ConsoleSpanExporter) Shutdown(context.Context) error { return nil }
+
+// ===== METRICS =====
+
+// ConsoleMetricExporter prints a table of metric values on each collection cycle
+type ConsoleMetricExporter struct {
+	out *consoleOutput
+}
+
+// NewConsoleMetricExporter creates a metric exporter writing to w (stdout when nil)
+func NewConsoleMetricExporter(w io.Writer) *ConsoleMetricExporter {
+	return &ConsoleMetricExporter{out: newConsoleOutput(w)}
+}
+
+// Temporality implements sdkmetric.Exporter
+func (e *ConsoleMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+// Aggregation implements sdkmetric.Exporter
+func (e *ConsoleMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export implements sdkmetric.Exporter
+func (e *ConsoleMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	var rows []string
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			rows = append(rows, metricRows(m)...)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", e.out.paint(colorGray, "metrics"), time.Now().Format(time.RFC3339))
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tVALUE\tATTRIBUTES")
+	for _, row := range rows {
+		fmt.Fprintln(tw, row)
+	}
+	_ = tw.Flush()
+	b.WriteString("\n")
+	return e.out.write([]byte(b.String()))
+}
+
+// metricRows formats one row per data point as tab-separated columns
+func metricRows(m metricdata.Metrics) []string {
+	name := m.Name
+	if m.Unit != "" {
+		name += " (" + m.Unit + ")"
+	}
+	row := func(kind, value string, attrs attribute.Set) string {
+		return fmt.Sprintf("%s\t%s\t%s\t%s", name, kind, value, formatAttributes(attrs.ToSlice()))
+	}
+
+	var rows []string
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("sum", fmt.Sprint(dp.Value), dp.Attributes))
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("sum", formatFloat(dp.Value), dp.Attributes))
+		}
+	case metricdata.Gauge[int64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("gauge", fmt.Sprint(dp.Value), dp.Attributes))
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("gauge", formatFloat(dp.Value), dp.Attributes))
+		}
+	case metricdata.Histogram[int64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("histogram", fmt.Sprintf("count=%d sum=%d", dp.Count, dp.Sum), dp.Attributes))
+		}
+	case metricdata.Histogram[float64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("histogram", fmt.Sprintf("count=%d sum=%s", dp.Count, formatFloat(dp.Sum)), dp.Attributes))
+		}
+	case metricdata.ExponentialHistogram[int64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("exp_histogram", fmt.Sprintf("count=%d sum=%d", dp.Count, dp.Sum), dp.Attributes))
+		}
+	case metricdata.ExponentialHistogram[float64]:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("exp_histogram", fmt.Sprintf("count=%d sum=%s", dp.Count, formatFloat(dp.Sum)), dp.Attributes))
+		}
+	case metricdata.Summary:
+		for _, dp := range data.DataPoints {
+			rows = append(rows, row("summary", fmt.Sprintf("count=%d sum=%s", dp.Count, formatFloat(dp.Sum)), dp.Attributes))
+		}
+	}
+	return rows
+}
+
+// ForceFlush implements sdkmetric.Exporter
+func (e *ConsoleMetricExporter) ForceFlush(context.Context) error { return nil }
+
+// Shutdown implements sdkmetric.Exporter
+func (e *ConsoleMetricExporter) Shutdown(context.Context) error { return nil }
+
+// ===== LOGS =====
+
+// ConsoleLogExporter prints one line per log record, colorized by severity on terminals
+type ConsoleLogExporter struct {
+	out *consoleOutput
+}
+
+// NewConsoleLogExporter creates a log exporter writing to w (stdout when nil)
+func NewConsoleLogExporter(w io.Writer) *ConsoleLogExporter {
+	return &ConsoleLogExporter{out: newConsoleOutput(w)}
+}
+
+// Export implements sdklog.Exporter
+func (e *ConsoleLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	for i := range records {
+		record := &records[i]
+		severity := record.SeverityText()
+		if severity == "" {
+			severity = record.Severity().String()
+		}
+
+		fmt.Fprintf(&b, "%s %s %s",
+			e.out.paint(colorGray, record.Timestamp().Format("15:04:05.000")),
+			e.out.paint(severityColor(record.Severity()), fmt.Sprintf("%-5s", strings.ToUpper(severity))),
+			record.Body().String(),
+		)
+
+		var attrs []string
+		record.WalkAttributes(func(kv otellog.KeyValue) bool {
+			attrs = append(attrs, kv.Key+"="+kv.Value.String())
+			return true
+		})
+		if record.TraceID().IsValid() {
+			attrs = append(attrs, "trace_id="+record.TraceID().String())
+		}
+		if record.SpanID().IsValid() {
+			attrs = append(attrs, "span_id="+record.SpanID().String())
+		}
+		if len(attrs) > 0 {
+			b.WriteString("  " + e.out.paint(colorGray, strings.Join(attrs, " ")))
+		}
+		b.WriteString("\n")
+	}
+	return e.out.write([]byte(b.String()))
+}
+
+// severityColor returns the ANSI color for a log severity
+func severityColor(severity otellog.Severity) string {
+	switch {
+	case severity >= otellog.SeverityError:
+		return colorRed
+	case severity >= otellog.SeverityWarn:
+		return colorYellow
+	case severity >= otellog.SeverityInfo:
+		return colorGreen
+	default:
+		return colorGray
+	}
+}
+
+// ForceFlush implements sdklog.Exporter
+func (e *ConsoleLogExporter) ForceFlush(context.Context) error { return nil }
+
+// Shutdown implements sdklog.Exporter
+func (e *ConsoleLogExporter) Shutdown(context.Context) error { return nil }
+
+// ===== FORMATTING HELPERS =====
+
+// formatAttributes formats attributes as space-separated key=value pairs
+func formatAttributes(attrs []attribute.KeyValue) string {
+	parts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		parts = append(parts, string(attr.Key)+"="+attr.Value.Emit())
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatDuration rounds a duration for display
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+func formatFloat(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,674 @@
+// Package infrastructure provides export outcome tracking for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// credentialRejectionLimit is the number of consecutive exports rejected for bad
+// credentials after which an exporter stops sending. Retrying cannot fix credentials,
+// and every attempt would fail the same way.
+const credentialRejectionLimit = 3
+
+// ExportStatus records the outcome of every export made through the SDK's exporters.
+// It backs the health and status queries and runs the configured export callbacks.
+type ExportStatus struct {
+	onSuccess []func(domain.ExportEvent)
+	onFailure []func(domain.ExportEvent)
+
+	mu             sync.Mutex
+	requests       int64
+	failedRequests int64
+	totalLatency   time.Duration
+	lastSuccess    time.Time
+	lastFailure    time.Time
+	lastError      error
+	backgroundAt   time.Time
+	background     error // latest error the OpenTelemetry SDK reported in the background
+	sent           map[domain.SignalType]int64
+	disabled       []string         // exporters stopped after repeated credential rejections
+	breakers       []*exportTracker // trackers with a circuit breaker, in creation order
+}
+
+// NewExportStatus creates export tracking running the callbacks configured in config
+func NewExportStatus(config *domain.TelemetryConfig) *ExportStatus {
+	return &ExportStatus{
+		onSuccess: config.ExportSuccessHooks(),
+		onFailure: config.ExportFailureHooks(),
+		sent:      make(map[domain.SignalType]int64),
+	}
+}
+
+// record updates the status with one export outcome and runs the callbacks
+func (s *ExportStatus) record(event domain.ExportEvent) {
+	s.mu.Lock()
+	s.requests++
+	s.totalLatency += event.Duration
+	if event.Err != nil {
+		s.failedRequests++
+		s.lastFailure = time.Now()
+		s.lastError = event.Err
+	} else {
+		s.lastSuccess = time.Now()
+		s.sent[event.Signal] += int64(event.Items - event.Dropped)
+	}
+	s.mu.Unlock()
+
+	hooks := s.onSuccess
+	if event.Err != nil {
+		hooks = s.onFailure
+	}
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+// recordBackground keeps the latest error the OpenTelemetry SDK reported in the
+// background. It shows in the health report but is not counted as an export.
+func (s *ExportStatus) recordBackground(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backgroundAt = time.Now()
+	s.background = err
+}
+
+// disable records that an exporter stopped after repeated credential rejections
+func (s *ExportStatus) disable(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disabled = append(s.disabled, name)
+}
+
+// addBreaker adds the circuit breaker of a tracker to the health report
+func (s *ExportStatus) addBreaker(t *exportTracker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.breakers = append(s.breakers, t)
+}
+
+// applyHealth fills the export outcome part of a health query result. lastFailure is
+// the time of result.LastError, which is only replaced by a newer export or background
+// error.
+func (s *ExportStatus) applyHealth(result *application.HealthQueryResult, lastFailure time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result.Metrics.TotalRequests = s.requests
+	result.Metrics.FailedRequests = s.failedRequests
+	if s.requests > 0 {
+		result.Metrics.AverageLatency = s.totalLatency / time.Duration(s.requests)
+	}
+	if s.lastSuccess.After(result.LastSuccess) {
+		result.LastSuccess = s.lastSuccess
+	}
+	if s.lastError != nil && s.lastFailure.After(lastFailure) {
+		result.LastError = s.lastError
+		lastFailure = s.lastFailure
+	}
+	if s.background != nil && s.backgroundAt.After(lastFailure) {
+		result.LastError = s.background
+	}
+
+	// An open breaker drops or holds everything its exporter is given
+	open, probing := false, false
+	for _, t := range s.breakers {
+		breaker := t.breaker.health(t.signal, t.exporter)
+		result.Breakers = append(result.Breakers, breaker)
+		open = open || breaker.State == breakerOpen.String()
+		probing = probing || breaker.State == breakerHalfOpen.String()
+	}
+
+	switch {
+	case len(s.disabled) > 0:
+		result.Status = "unhealthy"
+		result.Metrics.ConnectionState = "unauthorized"
+	case open:
+		result.Status = "unhealthy"
+	case (probing || s.lastFailure.After(s.lastSuccess)) && result.Status == "healthy":
+		result.Status = "degraded"
+	}
+}
+
+// defaultErrorHandler is the OpenTelemetry global error handler no one replaced yet. It
+// delegates to the first handler ever installed, so forwarding to it from that handler
+// would loop.
+var defaultErrorHandler = otel.GetErrorHandler()
+
+// sdkErrorHandler takes the place of the OpenTelemetry global error handler while the
+// SDK is initialized. It records errors in the export status and passes them on to the
+// configured handler, or else to the handler it replaced.
+type sdkErrorHandler struct {
+	status   *ExportStatus
+	handler  func(error) // nil unless configured
+	previous otel.ErrorHandler
+	active   atomic.Bool
+}
+
+// installErrorHandler replaces the global error handler with one reporting to status
+func installErrorHandler(status *ExportStatus, handler func(error)) *sdkErrorHandler {
+	e := &sdkErrorHandler{status: status, handler: handler, previous: otel.GetErrorHandler()}
+	e.active.Store(true)
+	otel.SetErrorHandler(e)
+	return e
+}
+
+// Handle implements otel.ErrorHandler
+func (e *sdkErrorHandler) Handle(err error) {
+	// A restored default handler still delegates here; log like it would
+	if !e.active.Load() {
+		log.Print(err)
+		return
+	}
+	e.status.recordBackground(err)
+	switch {
+	case e.handler != nil:
+		e.handler(err)
+	case !sameErrorHandler(e.previous, defaultErrorHandler):
+		e.previous.Handle(err)
+	default:
+		log.Print(err)
+	}
+}
+
+// restore puts the replaced handler back, unless another one was installed since
+func (e *sdkErrorHandler) restore() {
+	e.active.Store(false)
+	if sameErrorHandler(otel.GetErrorHandler(), e) {
+		otel.SetErrorHandler(e.previous)
+	}
+}
+
+// sameErrorHandler reports whether a and b are the same handler. Handlers of types that
+// cannot be compared, such as otel.ErrorHandlerFunc, never are.
+func sameErrorHandler(a, b otel.ErrorHandler) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
+
+// applyStatistics fills the export counters of an SDK status result
+func (s *ExportStatus) applyStatistics(stats *application.SDKStatistics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats.TracesSent = s.sent[domain.SignalTraces]
+	stats.MetricsSent = s.sent[domain.SignalMetrics]
+	stats.LogsSent = s.sent[domain.SignalLogs]
+	stats.ErrorsCount = s.failedRequests
+}
+
+// exportTracker follows the exports of one exporter: it retries failures the collector
+// reports as temporary, pauses exports while its circuit breaker is open, reports every
+// outcome to the export status and self metrics, and stops exporting after repeated
+// credential rejections. When admission is enabled, pending covers items queued, batched
+// or being exported; admitting at most queueSize of them means the batch processor's own
+// queue (of the same size) never drops, so every drop is counted here. Admitted items
+// also hold their estimated memory in the memory limiter until their export ends.
+type exportTracker struct {
+	status    *ExportStatus
+	metrics   *SelfMetrics   // nil unless self metrics are enabled
+	memory    *MemoryLimiter // nil unless a memory limiter is configured
+	signal    domain.SignalType
+	exporter  string
+	attrs     otelmetric.MeasurementOption
+	queueSize int64
+	pending   atomic.Int64
+
+	retry   retryPolicy
+	breaker *circuitBreaker // nil unless a circuit breaker is configured
+
+	rejections atomic.Int32
+	disabled   atomic.Bool
+}
+
+// newExportTracker creates the tracker of one exporter, retrying and breaking exports as
+// configured in config. metrics and memory may be nil.
+func newExportTracker(status *ExportStatus, metrics *SelfMetrics, memory *MemoryLimiter, config *domain.TelemetryConfig, signal domain.SignalType, exporter string) *exportTracker {
+	t := &exportTracker{
+		status:    status,
+		metrics:   metrics,
+		memory:    memory,
+		signal:    signal,
+		exporter:  exporter,
+		queueSize: selfMetricsQueueSize,
+		retry:     newRetryPolicy(config),
+		breaker:   newCircuitBreaker(config.CircuitBreaker(), memory),
+		attrs: otelmetric.WithAttributes(
+			attribute.String("signal", string(signal)),
+			attribute.String("exporter", exporter),
+		),
+	}
+	if t.breaker != nil {
+		status.addBreaker(t)
+	}
+	return t
+}
+
+// admit reserves a queue slot and size bytes of memory for one item, or counts it as
+// dropped
+func (t *exportTracker) admit(ctx context.Context, size int64) bool {
+	if !t.memory.admit(size) {
+		t.dropForMemory(ctx)
+		return false
+	}
+	if t.pending.Add(1) > t.queueSize {
+		t.pending.Add(-1)
+		t.memory.release(size)
+		t.metrics.recordDropped(ctx, t.attrs, 1)
+		return false
+	}
+	t.metrics.recordQueued(ctx, t.attrs)
+	return true
+}
+
+// dropForMemory counts an item dropped because of the memory limits
+func (t *exportTracker) dropForMemory(ctx context.Context) {
+	t.memory.countDropped(1)
+	t.metrics.recordDropped(ctx, t.attrs, 1)
+}
+
+// export runs one export call and records its outcome. queued tells whether the items
+// were admitted through the tracker and release their queue slots and the size bytes
+// of memory they hold. retain copies the exported data so it can be held while the
+// circuit breaker is open, or is nil for data that is not worth buffering.
+func (t *exportTracker) export(ctx context.Context, items int, queued bool, size int64, export func(context.Context) error, retain func() heldExport) error {
+	if queued {
+		defer func() {
+			t.pending.Add(-int64(items))
+			t.memory.release(size)
+		}()
+	}
+
+	// Stopped exporters drop their data without a request. The error was reported
+	// when the exporter stopped, so the batchers are not handed it again.
+	if t.disabled.Load() {
+		err := fmt.Errorf("%s export via %s stopped: %w", t.signal, t.exporter, domain.ErrCredentialsRejected)
+		t.metrics.recordExport(ctx, t.attrs, items, 0, 0, err)
+		t.status.record(domain.ExportEvent{Signal: t.signal, Exporter: t.exporter, Items: items, Err: err})
+		return nil
+	}
+	if t.breaker == nil {
+		return t.send(ctx, items, export)
+	}
+
+	// While the breaker is open the data is held or dropped without a request; the
+	// error that opened it was already returned, and the health query reports it.
+	if !t.breaker.allow(time.Now()) {
+		t.recordBreakerDrop(ctx, t.breaker.hold(items, retain))
+		return nil
+	}
+	err := t.send(ctx, items, export)
+	opened, held := t.breaker.result(err == nil, time.Now())
+	if opened {
+		err = fmt.Errorf("%w; %w: %s exports via %s paused for %s", err, domain.ErrCircuitOpen,
+			t.signal, t.exporter, t.breaker.config.Cooldown)
+	}
+	t.flush(ctx, held)
+	return err
+}
+
+// flush sends the data held while the breaker was open, oldest first, and holds it
+// again if the breaker opens or the export context ends before it is all sent
+func (t *exportTracker) flush(ctx context.Context, held []heldExport) {
+	for len(held) > 0 {
+		if ctx.Err() != nil || !t.breaker.closed() {
+			t.recordBreakerDrop(ctx, t.breaker.requeue(held))
+			return
+		}
+		next := held[0]
+		held = held[1:]
+		err := t.send(ctx, next.items, next.send)
+		_, more := t.breaker.result(err == nil, time.Now())
+		held = append(held, more...)
+	}
+}
+
+// recordBreakerDrop reports items discarded because the circuit breaker was open
+func (t *exportTracker) recordBreakerDrop(ctx context.Context, items int) {
+	if items == 0 {
+		return
+	}
+	err := fmt.Errorf("%s export via %s: %w, data dropped", t.signal, t.exporter, domain.ErrCircuitOpen)
+	t.metrics.recordDropped(ctx, t.attrs, items)
+	t.status.record(domain.ExportEvent{Signal: t.signal, Exporter: t.exporter, Items: items, Err: err})
+}
+
+// send runs one export, retrying failures the collector reports as temporary, and
+// records its outcome
+func (t *exportTracker) send(ctx context.Context, items int, export func(context.Context) error) error {
+	call := &exportCall{}
+	ctx = context.WithValue(ctx, exportCallKey{}, call)
+	start := time.Now()
+
+	var err error
+	for retry := 1; ; retry++ {
+		call.reset()
+		err = export(ctx)
+		if err == nil || !t.retry.enabled || retry > t.retry.maxRetries {
+			break
+		}
+		retryable, throttle := call.advice()
+		if !retryable {
+			break
+		}
+		wait := t.retry.delay(retry, throttle)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			err = fmt.Errorf("%w (not retried: the next attempt in %s would pass the export deadline)", err, wait.Round(time.Millisecond))
+			break
+		}
+		if !sleepContext(ctx, wait) {
+			break
+		}
+	}
+	elapsed := time.Since(start)
+
+	if rejection := call.rejection(); err != nil && rejection != "" {
+		err = fmt.Errorf("%s export via %s: %w (%s): %w", t.signal, t.exporter, domain.ErrCredentialsRejected, rejection, err)
+		if t.rejections.Add(1) >= credentialRejectionLimit && t.disabled.CompareAndSwap(false, true) {
+			t.status.disable(t.exporter)
+			err = fmt.Errorf("%w; exporting stopped after %d consecutive rejections, check the API key and restart the client",
+				err, credentialRejectionLimit)
+		}
+	} else {
+		t.rejections.Store(0)
+	}
+
+	retries := int(call.attempts.Load() - 1)
+	if retries < 0 {
+		retries = 0
+	}
+	truncated, dropped := call.oversizedItems()
+	t.metrics.recordExport(ctx, t.attrs, items-dropped, retries, elapsed, err)
+	t.metrics.recordOversized(ctx, t.attrs, truncated, dropped)
+	t.status.record(domain.ExportEvent{
+		Signal:    t.signal,
+		Exporter:  t.exporter,
+		Items:     items,
+		Duration:  elapsed,
+		Retries:   retries,
+		Truncated: truncated,
+		Dropped:   dropped,
+		Err:       err,
+	})
+	return err
+}
+
+// exportCallKey is the context key of the exportCall of an export
+type exportCallKey struct{}
+
+// exportCall collects what the transport sees of one export call: the number of
+// requests, the items truncated or dropped to fit the request size limit, the parts of a
+// split request already delivered, and for the last attempt whether it may be retried
+// and when, and the credential rejection, if any
+type exportCall struct {
+	attempts atomic.Int64
+
+	mu        sync.Mutex
+	retryable bool
+	throttle  time.Duration
+	rejected  string
+	truncated int // items of the last split request sent truncated
+	oversized int // items of the last split request dropped for their size
+	delivered int // leading parts of the split request accepted by the collector
+}
+
+// reset forgets the outcome of the previous attempt
+func (c *exportCall) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retryable, c.throttle, c.rejected = false, 0, ""
+}
+
+func (c *exportCall) advice() (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.retryable, c.throttle
+}
+
+func (c *exportCall) oversizedItems() (truncated, dropped int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.truncated, c.oversized
+}
+
+func (c *exportCall) rejection() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rejected
+}
+
+// exportCallFrom returns the export call in ctx, if tracked
+func exportCallFrom(ctx context.Context) *exportCall {
+	call, _ := ctx.Value(exportCallKey{}).(*exportCall)
+	return call
+}
+
+// countExportAttempt counts one request against the export call in ctx, if tracked
+func countExportAttempt(ctx context.Context) {
+	if call := exportCallFrom(ctx); call != nil {
+		call.attempts.Add(1)
+	}
+}
+
+// adviseExportRetry records whether the last request of the export call in ctx may be
+// retried, and the wait the collector asked for
+func adviseExportRetry(ctx context.Context, retryable bool, throttle time.Duration) {
+	if call := exportCallFrom(ctx); call != nil {
+		call.mu.Lock()
+		call.retryable, call.throttle = retryable, throttle
+		call.mu.Unlock()
+	}
+}
+
+// reportOversizedItems records the items of the export call in ctx that were truncated
+// or dropped to fit the request size limit. Every attempt splits the same data, so the
+// last report replaces the earlier ones.
+func reportOversizedItems(ctx context.Context, truncated, dropped int) {
+	if call := exportCallFrom(ctx); call != nil {
+		call.mu.Lock()
+		call.truncated, call.oversized = truncated, dropped
+		call.mu.Unlock()
+	}
+}
+
+// deliveredParts returns the number of leading parts of the split request of the export
+// call in ctx that earlier attempts delivered. Every attempt splits the same data into
+// the same parts, so retries skip them instead of sending their items again.
+func deliveredParts(ctx context.Context) int {
+	if call := exportCallFrom(ctx); call != nil {
+		call.mu.Lock()
+		defer call.mu.Unlock()
+		return call.delivered
+	}
+	return 0
+}
+
+// reportDeliveredParts records that the first n parts of the split request of the export
+// call in ctx were delivered
+func reportDeliveredParts(ctx context.Context, n int) {
+	if call := exportCallFrom(ctx); call != nil {
+		call.mu.Lock()
+		call.delivered = n
+		call.mu.Unlock()
+	}
+}
+
+// rejectExportCredentials marks the export call in ctx as rejected for its credentials
+func rejectExportCredentials(ctx context.Context, reason string) {
+	if call := exportCallFrom(ctx); call != nil {
+		call.mu.Lock()
+		call.rejected = reason
+		call.mu.Unlock()
+	}
+}
+
+// ===== TRACES =====
+
+// trackedSpanProcessor admits sampled spans into the wrapped batch processor
+type trackedSpanProcessor struct {
+	sdktrace.SpanProcessor
+	tracker *exportTracker
+}
+
+func (p *trackedSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	// The batch processor ignores unsampled spans
+	if !s.SpanContext().IsSampled() || p.tracker.admit(context.Background(), p.tracker.memory.spansSize(s)) {
+		p.SpanProcessor.OnEnd(s)
+	}
+}
+
+// trackedSpanExporter records the outcome of every span export
+type trackedSpanExporter struct {
+	sdktrace.SpanExporter
+	tracker *exportTracker
+	queued  bool
+}
+
+func (e *trackedSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var size int64
+	if e.queued {
+		size = e.tracker.memory.spansSize(spans...)
+	}
+	return e.tracker.export(ctx, len(spans), e.queued, size, func(ctx context.Context) error {
+		return e.SpanExporter.ExportSpans(ctx, spans)
+	}, func() heldExport {
+		// The batch processor reuses its batch slice, not the spans
+		held := slices.Clone(spans)
+		return heldExport{
+			items: len(held),
+			size:  e.tracker.memory.spansSize(held...),
+			send:  func(ctx context.Context) error { return e.SpanExporter.ExportSpans(ctx, held) },
+		}
+	})
+}
+
+// ===== METRICS =====
+
+// trackedMetricExporter records the outcome of every metric export
+type trackedMetricExporter struct {
+	sdkmetric.Exporter
+	tracker *exportTracker
+}
+
+func (e *trackedMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	// Metrics are not held while the breaker is open: the next collection supersedes them
+	return e.tracker.export(ctx, dataPointCount(rm), false, 0, func(ctx context.Context) error {
+		return e.Exporter.Export(ctx, rm)
+	}, nil)
+}
+
+// dataPointCount returns the number of data points in rm
+func dataPointCount(rm *metricdata.ResourceMetrics) int {
+	count := 0
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Gauge[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Histogram[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Histogram[float64]:
+				count += len(data.DataPoints)
+			case metricdata.ExponentialHistogram[int64]:
+				count += len(data.DataPoints)
+			case metricdata.ExponentialHistogram[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Summary:
+				count += len(data.DataPoints)
+			}
+		}
+	}
+	return count
+}
+
+// ===== LOGS =====
+
+// trackedLogProcessor admits log records into the wrapped batch processor. Above the
+// memory limiter's soft limit, records below its minimum severity are not admitted.
+type trackedLogProcessor struct {
+	sdklog.Processor
+	tracker *exportTracker
+}
+
+func (p *trackedLogProcessor) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	return p.tracker.memory.keepsSeverity(param.Severity) && p.Processor.Enabled(ctx, param)
+}
+
+func (p *trackedLogProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if !p.tracker.memory.keepsSeverity(record.Severity()) {
+		p.tracker.dropForMemory(ctx)
+		return nil
+	}
+	if !p.tracker.admit(ctx, p.tracker.memory.recordSize(record)) {
+		return nil
+	}
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// trackedLogExporter records the outcome of every log export
+type trackedLogExporter struct {
+	sdklog.Exporter
+	tracker *exportTracker
+	queued  bool
+}
+
+func (e *trackedLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	var size int64
+	if e.queued {
+		size = e.tracker.memory.recordsSize(records)
+	}
+	return e.tracker.export(ctx, len(records), e.queued, size, func(ctx context.Context) error {
+		return e.Exporter.Export(ctx, records)
+	}, func() heldExport {
+		// Records are only valid during Export, so held ones are cloned
+		held := make([]sdklog.Record, len(records))
+		for i := range records {
+			held[i] = records[i].Clone()
+		}
+		return heldExport{
+			items: len(held),
+			size:  e.tracker.memory.recordsSize(held),
+			send:  func(ctx context.Context) error { return e.Exporter.Export(ctx, held) },
+		}
+	})
+}
@@ -21,36 +21,52 @@ package infrastructure
 import (
 	"context"
 	"fmt"
-	"time"
+	"sync"
 
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// unixSocketHost is the HTTP host used for requests sent over a unix domain socket
+const unixSocketHost = "localhost"
+
 // OTLPExporterFactory creates OTLP exporters based on configuration
 type OTLPExporterFactory struct {
-	config *domain.TelemetryConfig
+	config      *domain.TelemetryConfig
+	apiVersions *APIVersionNegotiator
+	console     *consoleOutput
+	consoleOnce sync.Once
 }
 
 // NewOTLPExporterFactory creates a new exporter factory
 func NewOTLPExporterFactory(config *domain.TelemetryConfig) *OTLPExporterFactory {
 	return &OTLPExporterFactory{
-		config: config,
+		config:      config,
+		apiVersions: NewAPIVersionNegotiator(config.UseV2API(), config.IsV2Only()),
 	}
 }
 
+// APIVersions returns the negotiator shared by the factory's HTTP exporters
+func (f *OTLPExporterFactory) APIVersions() *APIVersionNegotiator { return f.apiVersions }
+
 // CreateResource creates an OTLP resource with service information
 func (f *OTLPExporterFactory) CreateResource(ctx context.Context) (*resource.Resource, error) {
 	attrs := []attribute.KeyValue{
@@ -83,14 +99,22 @@ func (f *OTLPExporterFactory) CreateTraceExporter(ctx context.Context) (sdktrace
 		return nil, fmt.Errorf("traces signal is not enabled")
 	}
 
-	switch f.config.Protocol() {
-	case domain.ProtocolGRPC:
-		return f.createGRPCTraceExporter(ctx)
-	case domain.ProtocolHTTP:
-		return f.createHTTPTraceExporter(ctx)
-	default:
-		return nil, fmt.Errorf("unsupported protocol: %s", f.config.Protocol())
+	endpoints := f.config.SignalEndpoints(domain.SignalTraces)
+	if len(endpoints) == 1 {
+		return f.createTraceExporter(ctx, endpoints[0])
+	}
+
+	exporters := make([]sdktrace.SpanExporter, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		exporter, err := f.createTraceExporter(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", endpoint, err)
+		}
+		exporters = append(exporters, exporter)
 	}
+
+	pool := NewEndpointPool(domain.SignalTraces, endpoints, f.config.FailoverProbeInterval())
+	return NewFailoverSpanExporter(pool, exporters), nil
 }
 
 // CreateMetricExporter creates a metric exporter based on protocol
@@ -99,148 +123,382 @@ func (f *OTLPExporterFactory) CreateMetricExporter(ctx context.Context) (sdkmetr
 		return nil, fmt.Errorf("metrics signal is not enabled")
 	}
 
-	switch f.config.Protocol() {
+	endpoints := f.config.SignalEndpoints(domain.SignalMetrics)
+	if len(endpoints) == 1 {
+		return f.createMetricExporter(ctx, endpoints[0])
+	}
+
+	exporters := make([]sdkmetric.Exporter, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		exporter, err := f.createMetricExporter(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", endpoint, err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	pool := NewEndpointPool(domain.SignalMetrics, endpoints, f.config.FailoverProbeInterval())
+	return NewFailoverMetricExporter(pool, exporters), nil
+}
+
+// CreateLogExporter creates a log exporter based on protocol
+func (f *OTLPExporterFactory) CreateLogExporter(ctx context.Context) (sdklog.Exporter, error) {
+	if !f.config.IsSignalEnabled(domain.SignalLogs) {
+		return nil, fmt.Errorf("logs signal is not enabled")
+	}
+
+	endpoints := f.config.SignalEndpoints(domain.SignalLogs)
+	if len(endpoints) == 1 {
+		return f.createLogExporter(ctx, endpoints[0])
+	}
+
+	exporters := make([]sdklog.Exporter, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		exporter, err := f.createLogExporter(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", endpoint, err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	pool := NewEndpointPool(domain.SignalLogs, endpoints, f.config.FailoverProbeInterval())
+	return NewFailoverLogExporter(pool, exporters), nil
+}
+
+// CreateDestinationTraceExporters creates a trace exporter for every destination that receives traces
+func (f *OTLPExporterFactory) CreateDestinationTraceExporters(ctx context.Context) ([]sdktrace.SpanExporter, error) {
+	var exporters []sdktrace.SpanExporter
+	for _, destination := range f.config.Destinations() {
+		factory := NewOTLPExporterFactory(f.config.ForDestination(destination))
+		if !factory.config.IsSignalEnabled(domain.SignalTraces) {
+			continue
+		}
+
+		exporter, err := factory.CreateTraceExporter(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("destination %s: %w", destination.Name(), err)
+		}
+		exporters = append(exporters, exporter)
+	}
+	return exporters, nil
+}
+
+// CreateDestinationMetricExporters creates a metric exporter for every destination that receives metrics
+func (f *OTLPExporterFactory) CreateDestinationMetricExporters(ctx context.Context) ([]sdkmetric.Exporter, error) {
+	var exporters []sdkmetric.Exporter
+	for _, destination := range f.config.Destinations() {
+		factory := NewOTLPExporterFactory(f.config.ForDestination(destination))
+		if !factory.config.IsSignalEnabled(domain.SignalMetrics) {
+			continue
+		}
+
+		exporter, err := factory.CreateMetricExporter(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("destination %s: %w", destination.Name(), err)
+		}
+		exporters = append(exporters, exporter)
+	}
+	return exporters, nil
+}
+
+// CreateDestinationLogExporters creates a log exporter for every destination that receives logs
+func (f *OTLPExporterFactory) CreateDestinationLogExporters(ctx context.Context) ([]sdklog.Exporter, error) {
+	var exporters []sdklog.Exporter
+	for _, destination := range f.config.Destinations() {
+		factory := NewOTLPExporterFactory(f.config.ForDestination(destination))
+		if !factory.config.IsSignalEnabled(domain.SignalLogs) {
+			continue
+		}
+
+		exporter, err := factory.CreateLogExporter(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("destination %s: %w", destination.Name(), err)
+		}
+		exporters = append(exporters, exporter)
+	}
+	return exporters, nil
+}
+
+// ===== CONSOLE EXPORTERS =====
+
+// CreateConsoleTraceExporter creates a span exporter printing to the configured console writer
+func (f *OTLPExporterFactory) CreateConsoleTraceExporter() sdktrace.SpanExporter {
+	return &ConsoleSpanExporter{out: f.consoleOutput()}
+}
+
+// CreateConsoleMetricExporter creates a metric exporter printing to the configured console writer
+func (f *OTLPExporterFactory) CreateConsoleMetricExporter() sdkmetric.Exporter {
+	return &ConsoleMetricExporter{out: f.consoleOutput()}
+}
+
+// CreateConsoleLogExporter creates a log exporter printing to the configured console writer
+func (f *OTLPExporterFactory) CreateConsoleLogExporter() sdklog.Exporter {
+	return &ConsoleLogExporter{out: f.consoleOutput()}
+}
+
+// consoleOutput returns the output shared by the console exporters so their lines do not interleave
+func (f *OTLPExporterFactory) consoleOutput() *consoleOutput {
+	f.consoleOnce.Do(func() {
+		f.console = newConsoleOutput(f.config.ConsoleWriter())
+	})
+	return f.console
+}
+
+func (f *OTLPExporterFactory) createTraceExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	target, err := domain.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	insecure := f.config.EndpointInsecure(endpoint)
+
+	protocol := f.config.SignalProtocol(domain.SignalTraces)
+	switch protocol {
 	case domain.ProtocolGRPC:
-		return f.createGRPCMetricExporter(ctx)
+		return f.createGRPCTraceExporter(ctx, target, insecure)
 	case domain.ProtocolHTTP:
-		return f.createHTTPMetricExporter(ctx)
+		return f.createHTTPTraceExporter(ctx, target, insecure)
 	default:
-		return nil, fmt.Errorf("unsupported protocol: %s", f.config.Protocol())
+		return nil, fmt.Errorf("unsupported protocol: %s", protocol)
+	}
+}
+
+func (f *OTLPExporterFactory) createMetricExporter(ctx context.Context, endpoint string) (sdkmetric.Exporter, error) {
+	target, err := domain.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	insecure := f.config.EndpointInsecure(endpoint)
+
+	protocol := f.config.SignalProtocol(domain.SignalMetrics)
+	switch protocol {
+	case domain.ProtocolGRPC:
+		return f.createGRPCMetricExporter(ctx, target, insecure)
+	case domain.ProtocolHTTP:
+		return f.createHTTPMetricExporter(ctx, target, insecure)
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %s", protocol)
+	}
+}
+
+func (f *OTLPExporterFactory) createLogExporter(ctx context.Context, endpoint string) (sdklog.Exporter, error) {
+	target, err := domain.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	insecure := f.config.EndpointInsecure(endpoint)
+
+	protocol := f.config.SignalProtocol(domain.SignalLogs)
+	switch protocol {
+	case domain.ProtocolGRPC:
+		return f.createGRPCLogExporter(ctx, target, insecure)
+	case domain.ProtocolHTTP:
+		return f.createHTTPLogExporter(ctx, target, insecure)
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %s", protocol)
 	}
 }
 
 // ===== GRPC EXPORTERS =====
 
-func (f *OTLPExporterFactory) createGRPCTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+func (f *OTLPExporterFactory) createGRPCTraceExporter(ctx context.Context, endpoint *domain.EndpointURL, insecure bool) (sdktrace.SpanExporter, error) {
 	opts := []otlptracegrpc.Option{
-		otlptracegrpc.WithEndpoint(f.config.Endpoint()),
-		otlptracegrpc.WithTimeout(f.config.Timeout()),
-		otlptracegrpc.WithHeaders(f.getAuthHeaders()),
-		otlptracegrpc.WithDialOption(grpc.WithUnaryInterceptor(f.authInterceptor())),
+		otlptracegrpc.WithEndpoint(grpcTarget(endpoint)),
+		otlptracegrpc.WithTimeout(f.config.SignalTimeout(domain.SignalTraces)),
+		otlptracegrpc.WithHeaders(f.signalHeaders(domain.SignalTraces)),
+		otlptracegrpc.WithDialOption(f.grpcDialOptions()...),
 	}
 
-	if f.config.IsInsecure() {
+	if insecure {
 		opts = append(opts, otlptracegrpc.WithInsecure())
 	} else {
 		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
 	}
 
-	if f.config.IsCompressionEnabled() {
+	if f.config.IsSignalCompressionEnabled(domain.SignalTraces) {
 		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
 	}
 
-	if f.config.IsRetryEnabled() {
-		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
-			Enabled:         true,
-			InitialInterval: f.config.RetryBackoff(),
-			MaxInterval:     f.config.RetryBackoff() * 2,
-			MaxElapsedTime:  time.Duration(f.config.MaxRetries()) * f.config.RetryBackoff(),
-		}))
-	}
+	// Retries are made by the export tracker, which honors throttling hints and
+	// feeds the circuit breaker
+	opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}))
 
 	return otlptracegrpc.New(ctx, opts...)
 }
 
-func (f *OTLPExporterFactory) createGRPCMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+func (f *OTLPExporterFactory) createGRPCMetricExporter(ctx context.Context, endpoint *domain.EndpointURL, insecure bool) (sdkmetric.Exporter, error) {
 	opts := []otlpmetricgrpc.Option{
-		otlpmetricgrpc.WithEndpoint(f.config.Endpoint()),
-		otlpmetricgrpc.WithTimeout(f.config.Timeout()),
-		otlpmetricgrpc.WithHeaders(f.getAuthHeaders()),
-		otlpmetricgrpc.WithDialOption(grpc.WithUnaryInterceptor(f.authInterceptor())),
+		otlpmetricgrpc.WithEndpoint(grpcTarget(endpoint)),
+		otlpmetricgrpc.WithTimeout(f.config.SignalTimeout(domain.SignalMetrics)),
+		otlpmetricgrpc.WithHeaders(f.signalHeaders(domain.SignalMetrics)),
+		otlpmetricgrpc.WithDialOption(f.grpcDialOptions()...),
 	}
 
-	if f.config.IsInsecure() {
+	if insecure {
 		opts = append(opts, otlpmetricgrpc.WithInsecure())
 	} else {
 		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
 	}
 
-	if f.config.IsCompressionEnabled() {
+	if f.config.IsSignalCompressionEnabled(domain.SignalMetrics) {
 		opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
 	}
 
-	if f.config.IsRetryEnabled() {
-		opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{
-			Enabled:         true,
-			InitialInterval: f.config.RetryBackoff(),
-			MaxInterval:     f.config.RetryBackoff() * 2,
-			MaxElapsedTime:  time.Duration(f.config.MaxRetries()) * f.config.RetryBackoff(),
-		}))
-	}
+	// Retries are made by the export tracker, which honors throttling hints and
+	// feeds the circuit breaker
+	opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{Enabled: false}))
 
 	return otlpmetricgrpc.New(ctx, opts...)
 }
 
+func (f *OTLPExporterFactory) createGRPCLogExporter(ctx context.Context, endpoint *domain.EndpointURL, insecure bool) (sdklog.Exporter, error) {
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(grpcTarget(endpoint)),
+		otlploggrpc.WithTimeout(f.config.SignalTimeout(domain.SignalLogs)),
+		otlploggrpc.WithHeaders(f.signalHeaders(domain.SignalLogs)),
+		otlploggrpc.WithDialOption(f.grpcDialOptions()...),
+	}
+
+	if insecure {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+
+	if f.config.IsSignalCompressionEnabled(domain.SignalLogs) {
+		opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+	}
+
+	// Retries are made by the export tracker, which honors throttling hints and
+	// feeds the circuit breaker
+	opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig{Enabled: false}))
+
+	return otlploggrpc.New(ctx, opts...)
+}
+
 // ===== HTTP EXPORTERS =====
 
-func (f *OTLPExporterFactory) createHTTPTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+func (f *OTLPExporterFactory) createHTTPTraceExporter(ctx context.Context, endpoint *domain.EndpointURL, insecure bool) (sdktrace.SpanExporter, error) {
 	opts := []otlptracehttp.Option{
-		otlptracehttp.WithEndpoint(f.config.Endpoint()),
-		otlptracehttp.WithTimeout(f.config.Timeout()),
-		otlptracehttp.WithHeaders(f.getAuthHeaders()),
+		otlptracehttp.WithEndpoint(httpHost(endpoint)),
+		otlptracehttp.WithTimeout(f.config.SignalTimeout(domain.SignalTraces)),
+		otlptracehttp.WithHeaders(f.signalHeaders(domain.SignalTraces)),
 		// Use v2 or v1 traces endpoint based on configuration (aligned with tfoexporter)
-		otlptracehttp.WithURLPath(f.config.TracesEndpoint()),
+		otlptracehttp.WithURLPath(endpoint.BasePath + f.config.TracesEndpoint()),
 	}
 
-	if f.config.IsInsecure() {
+	if insecure {
 		opts = append(opts, otlptracehttp.WithInsecure())
 	}
 
-	if f.config.IsCompressionEnabled() {
+	opts = append(opts, otlptracehttp.WithHTTPClient(f.httpClient(domain.SignalTraces, endpoint)))
+
+	if f.config.IsSignalCompressionEnabled(domain.SignalTraces) {
 		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
 	}
 
-	if f.config.IsRetryEnabled() {
-		opts = append(opts, otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
-			Enabled:         true,
-			InitialInterval: f.config.RetryBackoff(),
-			MaxInterval:     f.config.RetryBackoff() * 2,
-			MaxElapsedTime:  time.Duration(f.config.MaxRetries()) * f.config.RetryBackoff(),
-		}))
-	}
+	// Retries are made by the export tracker, which honors throttling hints and
+	// feeds the circuit breaker
+	opts = append(opts, otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}))
 
 	return otlptracehttp.New(ctx, opts...)
 }
 
-func (f *OTLPExporterFactory) createHTTPMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+func (f *OTLPExporterFactory) createHTTPMetricExporter(ctx context.Context, endpoint *domain.EndpointURL, insecure bool) (sdkmetric.Exporter, error) {
 	opts := []otlpmetrichttp.Option{
-		otlpmetrichttp.WithEndpoint(f.config.Endpoint()),
-		otlpmetrichttp.WithTimeout(f.config.Timeout()),
-		otlpmetrichttp.WithHeaders(f.getAuthHeaders()),
+		otlpmetrichttp.WithEndpoint(httpHost(endpoint)),
+		otlpmetrichttp.WithTimeout(f.config.SignalTimeout(domain.SignalMetrics)),
+		otlpmetrichttp.WithHeaders(f.signalHeaders(domain.SignalMetrics)),
 		// Use v2 or v1 metrics endpoint based on configuration (aligned with tfoexporter)
-		otlpmetrichttp.WithURLPath(f.config.MetricsEndpoint()),
+		otlpmetrichttp.WithURLPath(endpoint.BasePath + f.config.MetricsEndpoint()),
 	}
 
-	if f.config.IsInsecure() {
+	if insecure {
 		opts = append(opts, otlpmetrichttp.WithInsecure())
 	}
 
-	if f.config.IsCompressionEnabled() {
+	opts = append(opts, otlpmetrichttp.WithHTTPClient(f.httpClient(domain.SignalMetrics, endpoint)))
+
+	if f.config.IsSignalCompressionEnabled(domain.SignalMetrics) {
 		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
 	}
 
-	if f.config.IsRetryEnabled() {
-		opts = append(opts, otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
-			Enabled:         true,
-			InitialInterval: f.config.RetryBackoff(),
-			MaxInterval:     f.config.RetryBackoff() * 2,
-			MaxElapsedTime:  time.Duration(f.config.MaxRetries()) * f.config.RetryBackoff(),
-		}))
-	}
+	// Retries are made by the export tracker, which honors throttling hints and
+	// feeds the circuit breaker
+	opts = append(opts, otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{Enabled: false}))
 
 	return otlpmetrichttp.New(ctx, opts...)
 }
 
+func (f *OTLPExporterFactory) createHTTPLogExporter(ctx context.Context, endpoint *domain.EndpointURL, insecure bool) (sdklog.Exporter, error) {
+	opts := []otlploghttp.Option{
+		otlploghttp.WithEndpoint(httpHost(endpoint)),
+		otlploghttp.WithTimeout(f.config.SignalTimeout(domain.SignalLogs)),
+		otlploghttp.WithHeaders(f.signalHeaders(domain.SignalLogs)),
+		// Use v2 or v1 logs endpoint based on configuration (aligned with tfoexporter)
+		otlploghttp.WithURLPath(endpoint.BasePath + f.config.LogsEndpoint()),
+	}
+
+	if insecure {
+		opts = append(opts, otlploghttp.WithInsecure())
+	}
+
+	opts = append(opts, otlploghttp.WithHTTPClient(f.httpClient(domain.SignalLogs, endpoint)))
+
+	if f.config.IsSignalCompressionEnabled(domain.SignalLogs) {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+
+	// Retries are made by the export tracker, which honors throttling hints and
+	// feeds the circuit breaker
+	opts = append(opts, otlploghttp.WithRetry(otlploghttp.RetryConfig{Enabled: false}))
+
+	return otlploghttp.New(ctx, opts...)
+}
+
 // ===== HELPER METHODS =====
 
+// grpcTarget returns the gRPC dial target for an endpoint
+func grpcTarget(endpoint *domain.EndpointURL) string {
+	if endpoint.IsUnix() {
+		return endpoint.String() // unix:///path/to/socket
+	}
+	return endpoint.Host
+}
+
+// httpHost returns the HTTP host for an endpoint. Requests to unix sockets are
+// dialed directly, so they use a placeholder host.
+func httpHost(endpoint *domain.EndpointURL) string {
+	if endpoint.IsUnix() {
+		return unixSocketHost
+	}
+	return endpoint.Host
+}
+
+// signalHeaders returns the authentication headers merged with the extra export headers
+// and the signal's own headers. Signal headers take precedence over the shared headers.
+func (f *OTLPExporterFactory) signalHeaders(signal domain.SignalType) map[string]string {
+	headers := f.getAuthHeaders()
+	for key, value := range f.config.Headers() {
+		headers[key] = value
+	}
+	for key, value := range f.config.SignalHeaders(signal) {
+		headers[key] = value
+	}
+	return headers
+}
+
 // getAuthHeaders returns headers with TelemetryFlow authentication
 // Headers are aligned with TelemetryFlow Collector expected format (tfoexporter, tfoauthextension)
 func (f *OTLPExporterFactory) getAuthHeaders() map[string]string {
 	headers := map[string]string{
-		"authorization":              f.config.Credentials().AuthorizationHeader(),
-		"content-type":               "application/x-protobuf",
-		"X-TelemetryFlow-Key-ID":     f.config.Credentials().KeyID(),
-		"X-TelemetryFlow-Key-Secret": f.config.Credentials().KeySecret(),
+		"content-type": f.config.HTTPEncoding().ContentType(),
+	}
+
+	// Add authentication headers (destinations without credentials send none)
+	if creds := f.config.Credentials(); creds != nil {
+		headers["authorization"] = creds.AuthorizationHeader()
+		headers["X-TelemetryFlow-Key-ID"] = creds.KeyID()
+		headers["X-TelemetryFlow-Key-Secret"] = creds.KeySecret()
 	}
 
 	// Add collector identity headers (aligned with tfoidentityextension)
@@ -264,12 +522,29 @@ func (f *OTLPExporterFactory) getAuthHeaders() map[string]string {
 
 	// Add v2 API indicator if enabled
 	if f.config.UseV2API() {
-		headers["X-TelemetryFlow-API-Version"] = "v2"
+		headers[apiVersionHeader] = APIVersionV2
 	}
 
 	return headers
 }
 
+// grpcDialOptions returns the dial options of the gRPC exporters: the auth interceptor
+// and the configured message size limits
+func (f *OTLPExporterFactory) grpcDialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithUnaryInterceptor(f.authInterceptor())}
+	var callOpts []grpc.CallOption
+	if size := f.config.GRPCMaxSendMsgSize(); size > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(size<<20))
+	}
+	if size := f.config.GRPCMaxRecvMsgSize(); size > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(size<<20))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts
+}
+
 // authInterceptor creates a gRPC interceptor that adds authentication
 // Headers are aligned with TelemetryFlow Collector expected format (tfoexporter, tfoauthextension, tfoidentityextension)
 func (f *OTLPExporterFactory) authInterceptor() grpc.UnaryClientInterceptor {
@@ -281,12 +556,17 @@ func (f *OTLPExporterFactory) authInterceptor() grpc.UnaryClientInterceptor {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
+		// Every invocation is one request; repeats within an export call are retries
+		countExportAttempt(ctx)
+
 		// Add TelemetryFlow authentication headers to context (aligned with tfoauthextension)
-		ctx = metadata.AppendToOutgoingContext(ctx,
-			"authorization", f.config.Credentials().AuthorizationHeader(),
-			"x-telemetryflow-key-id", f.config.Credentials().KeyID(),
-			"x-telemetryflow-key-secret", f.config.Credentials().KeySecret(),
-		)
+		if creds := f.config.Credentials(); creds != nil {
+			ctx = metadata.AppendToOutgoingContext(ctx,
+				"authorization", creds.AuthorizationHeader(),
+				"x-telemetryflow-key-id", creds.KeyID(),
+				"x-telemetryflow-key-secret", creds.KeySecret(),
+			)
+		}
 
 		// Add collector identity headers (aligned with tfoidentityextension)
 		if f.config.CollectorID() != "" {
@@ -324,6 +604,23 @@ func (f *OTLPExporterFactory) authInterceptor() grpc.UnaryClientInterceptor {
 			)
 		}
 
-		return invoker(ctx, method, req, reply, cc, opts...)
+		// Requests over the size limit are sent in parts within this attempt; a retry
+		// skips the parts already delivered
+		var err error
+		if msg, ok := req.(proto.Message); ok {
+			err = sendWithinLimit(ctx, msg, f.config.RequestSizeLimit(domain.ProtocolGRPC), func(part proto.Message) error {
+				return invoker(ctx, method, part, reply, cc, opts...)
+			})
+		} else {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if code := status.Code(err); code == codes.Unauthenticated || code == codes.PermissionDenied {
+			rejectExportCredentials(ctx, "gRPC "+code.String())
+		}
+		if err != nil {
+			retryable, throttle := grpcRetryAdvice(err)
+			adviseExportRetry(ctx, retryable, throttle)
+		}
+		return err
 	}
 }
@@ -0,0 +1,344 @@
+// Package infrastructure provides multi-endpoint failover for the TelemetryFlow SDK exporters.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FailoverEvent describes an export moving from one endpoint to another
+type FailoverEvent struct {
+	Signal domain.SignalType
+	From   string
+	To     string
+	Time   time.Time
+}
+
+// endpointState tracks the health of a single endpoint
+type endpointState struct {
+	endpoint            string
+	healthy             bool
+	consecutiveFailures int
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	lastError           error
+	retryAt             time.Time
+}
+
+// EndpointPool tracks the health of an ordered list of endpoints for one signal.
+// Exports always prefer the highest-priority endpoint that is healthy or due for
+// a retry, so traffic falls back to the primary once it recovers.
+type EndpointPool struct {
+	signal        domain.SignalType
+	probeInterval time.Duration
+	endpoints     []*endpointState
+	active        int
+	failovers     int64
+	listeners     []func(FailoverEvent)
+	mu            sync.RWMutex
+}
+
+// NewEndpointPool creates a pool for the given endpoints in priority order
+func NewEndpointPool(signal domain.SignalType, endpoints []string, probeInterval time.Duration) *EndpointPool {
+	states := make([]*endpointState, len(endpoints))
+	for i, endpoint := range endpoints {
+		states[i] = &endpointState{endpoint: endpoint, healthy: true}
+	}
+	return &EndpointPool{
+		signal:        signal,
+		probeInterval: probeInterval,
+		endpoints:     states,
+	}
+}
+
+// OnFailover registers a listener that is called whenever the active endpoint changes
+func (p *EndpointPool) OnFailover(listener func(FailoverEvent)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listeners = append(p.listeners, listener)
+}
+
+// Signal returns the signal this pool exports
+func (p *EndpointPool) Signal() domain.SignalType { return p.signal }
+
+// Failovers returns the number of times the active endpoint changed
+func (p *EndpointPool) Failovers() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.failovers
+}
+
+// ActiveEndpoint returns the endpoint used by the last successful export
+func (p *EndpointPool) ActiveEndpoint() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.endpoints[p.active].endpoint
+}
+
+// candidates returns endpoint indexes in the order they should be tried.
+// Endpoints skipped after a failure are only included once their retry time
+// has passed, unless every endpoint is down, in which case all are tried.
+func (p *EndpointPool) candidates() []int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	now := time.Now()
+	order := make([]int, 0, len(p.endpoints))
+	for i, state := range p.endpoints {
+		if state.healthy || !now.Before(state.retryAt) {
+			order = append(order, i)
+		}
+	}
+	if len(order) == 0 {
+		for i := range p.endpoints {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+func (p *EndpointPool) markSuccess(index int) {
+	p.mu.Lock()
+	state := p.endpoints[index]
+	state.healthy = true
+	state.consecutiveFailures = 0
+	state.lastSuccess = time.Now()
+
+	var event *FailoverEvent
+	if index != p.active {
+		event = &FailoverEvent{
+			Signal: p.signal,
+			From:   p.endpoints[p.active].endpoint,
+			To:     state.endpoint,
+			Time:   state.lastSuccess,
+		}
+		p.active = index
+		p.failovers++
+	}
+	listeners := p.listeners
+	p.mu.Unlock()
+
+	if event != nil {
+		for _, listener := range listeners {
+			listener(*event)
+		}
+	}
+}
+
+func (p *EndpointPool) markFailure(index int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := p.endpoints[index]
+	state.healthy = false
+	state.consecutiveFailures++
+	state.lastFailure = time.Now()
+	state.lastError = err
+	state.retryAt = state.lastFailure.Add(p.probeInterval)
+}
+
+// Health returns a snapshot of every endpoint in the pool
+func (p *EndpointPool) Health() []application.EndpointHealth {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result := make([]application.EndpointHealth, len(p.endpoints))
+	for i, state := range p.endpoints {
+		result[i] = application.EndpointHealth{
+			Signal:              string(p.signal),
+			Endpoint:            state.endpoint,
+			Priority:            i,
+			Healthy:             state.healthy,
+			Active:              i == p.active,
+			ConsecutiveFailures: state.consecutiveFailures,
+			LastSuccess:         state.lastSuccess,
+			LastFailure:         state.lastFailure,
+			LastError:           state.lastError,
+		}
+	}
+	return result
+}
+
+// export tries each candidate endpoint in order until one succeeds
+func (p *EndpointPool) export(ctx context.Context, fn func(index int) error) error {
+	var errs []error
+	for _, index := range p.candidates() {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+		err := fn(index)
+		if err == nil {
+			p.markSuccess(index)
+			return nil
+		}
+		p.markFailure(index, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.endpoints[index].endpoint, err))
+	}
+	return fmt.Errorf("all %s endpoints failed: %w", p.signal, errors.Join(errs...))
+}
+
+// ===== TRACE FAILOVER =====
+
+// FailoverSpanExporter exports spans to the first available endpoint
+type FailoverSpanExporter struct {
+	pool      *EndpointPool
+	exporters []sdktrace.SpanExporter
+}
+
+// NewFailoverSpanExporter creates a span exporter that fails over between exporters.
+// The exporters must be given in the same order as the pool endpoints.
+func NewFailoverSpanExporter(pool *EndpointPool, exporters []sdktrace.SpanExporter) *FailoverSpanExporter {
+	return &FailoverSpanExporter{pool: pool, exporters: exporters}
+}
+
+// Pool returns the endpoint pool used by the exporter
+func (e *FailoverSpanExporter) Pool() *EndpointPool { return e.pool }
+
+// ExportSpans exports spans to the highest-priority available endpoint
+func (e *FailoverSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return e.pool.export(ctx, func(index int) error {
+		return e.exporters[index].ExportSpans(ctx, spans)
+	})
+}
+
+// Shutdown shuts down every underlying exporter
+func (e *FailoverSpanExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, exporter := range e.exporters {
+		if err := exporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ===== METRIC FAILOVER =====
+
+// FailoverMetricExporter exports metrics to the first available endpoint
+type FailoverMetricExporter struct {
+	pool      *EndpointPool
+	exporters []sdkmetric.Exporter
+}
+
+// NewFailoverMetricExporter creates a metric exporter that fails over between exporters.
+// The exporters must be given in the same order as the pool endpoints.
+func NewFailoverMetricExporter(pool *EndpointPool, exporters []sdkmetric.Exporter) *FailoverMetricExporter {
+	return &FailoverMetricExporter{pool: pool, exporters: exporters}
+}
+
+// Pool returns the endpoint pool used by the exporter
+func (e *FailoverMetricExporter) Pool() *EndpointPool { return e.pool }
+
+// Temporality returns the temporality of the primary exporter
+func (e *FailoverMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.exporters[0].Temporality(kind)
+}
+
+// Aggregation returns the aggregation of the primary exporter
+func (e *FailoverMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return e.exporters[0].Aggregation(kind)
+}
+
+// Export exports metrics to the highest-priority available endpoint
+func (e *FailoverMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	return e.pool.export(ctx, func(index int) error {
+		return e.exporters[index].Export(ctx, rm)
+	})
+}
+
+// ForceFlush flushes every underlying exporter
+func (e *FailoverMetricExporter) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, exporter := range e.exporters {
+		if err := exporter.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down every underlying exporter
+func (e *FailoverMetricExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, exporter := range e.exporters {
+		if err := exporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ===== LOG FAILOVER =====
+
+// FailoverLogExporter exports log records to the first available endpoint
+type FailoverLogExporter struct {
+	pool      *EndpointPool
+	exporters []sdklog.Exporter
+}
+
+// NewFailoverLogExporter creates a log exporter that fails over between exporters.
+// The exporters must be given in the same order as the pool endpoints.
+func NewFailoverLogExporter(pool *EndpointPool, exporters []sdklog.Exporter) *FailoverLogExporter {
+	return &FailoverLogExporter{pool: pool, exporters: exporters}
+}
+
+// Pool returns the endpoint pool used by the exporter
+func (e *FailoverLogExporter) Pool() *EndpointPool { return e.pool }
+
+// Export exports log records to the highest-priority available endpoint
+func (e *FailoverLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	return e.pool.export(ctx, func(index int) error {
+		return e.exporters[index].Export(ctx, records)
+	})
+}
+
+// ForceFlush flushes every underlying exporter
+func (e *FailoverLogExporter) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, exporter := range e.exporters {
+		if err := exporter.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down every underlying exporter
+func (e *FailoverLogExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, exporter := range e.exporters {
+		if err := exporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,351 @@
+// Package infrastructure provides the OTLP/JSON file exporter for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// fileExporterHost is the placeholder endpoint of the OTLP/HTTP exporters behind the file exporter
+	fileExporterHost = "file.exporter.invalid"
+	// otlpJSONExt is the extension of OTLP/JSON lines files
+	otlpJSONExt = ".jsonl"
+	// rotatedTimeFormat is the timestamp appended to rotated file names
+	rotatedTimeFormat = "20060102T150405.000000000"
+)
+
+// ===== FACTORY =====
+
+// CreateFileTraceExporter creates a span exporter writing OTLP/JSON lines to rotating files
+func (f *OTLPExporterFactory) CreateFileTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	file, client, err := f.fileClient(domain.SignalTraces, func() proto.Message { return &coltracepb.ExportTraceServiceRequest{} })
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpoint(fileExporterHost),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithHTTPClient(client),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &fileSpanExporter{SpanExporter: exporter, file: file}, nil
+}
+
+// CreateFileMetricExporter creates a metric exporter writing OTLP/JSON lines to rotating files
+func (f *OTLPExporterFactory) CreateFileMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	file, client, err := f.fileClient(domain.SignalMetrics, func() proto.Message { return &colmetricspb.ExportMetricsServiceRequest{} })
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlpmetrichttp.New(ctx,
+		otlpmetrichttp.WithEndpoint(fileExporterHost),
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithHTTPClient(client),
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &fileMetricExporter{Exporter: exporter, file: file}, nil
+}
+
+// CreateFileLogExporter creates a log exporter writing OTLP/JSON lines to rotating files
+func (f *OTLPExporterFactory) CreateFileLogExporter(ctx context.Context) (sdklog.Exporter, error) {
+	file, client, err := f.fileClient(domain.SignalLogs, func() proto.Message { return &collogspb.ExportLogsServiceRequest{} })
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlploghttp.New(ctx,
+		otlploghttp.WithEndpoint(fileExporterHost),
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithHTTPClient(client),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &fileLogExporter{Exporter: exporter, file: file}, nil
+}
+
+// fileClient opens the rotating file for a signal and returns an HTTP client that
+// appends every OTLP/HTTP request to it. The OTLP/HTTP exporters do the encoding,
+// so files contain exactly what a collector would have received.
+func (f *OTLPExporterFactory) fileClient(signal domain.SignalType, newRequest func() proto.Message) (*RotatingFile, *http.Client, error) {
+	file, err := NewRotatingFile(string(signal), f.config.FileExporter())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s file: %w", signal, err)
+	}
+	return file, &http.Client{Transport: &fileTransport{file: file, newRequest: newRequest}}, nil
+}
+
+// fileTransport stores OTLP/HTTP protobuf requests as OTLP/JSON lines instead of sending them
+type fileTransport struct {
+	file       *RotatingFile
+	newRequest func() proto.Message
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *fileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := t.newRequest()
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode export request: %w", err)
+	}
+	line, err := MarshalOTLPJSON(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export request: %w", err)
+	}
+	if err := t.file.WriteLine(line); err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/x-protobuf"}},
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+// fileSpanExporter closes the file when the exporter shuts down
+type fileSpanExporter struct {
+	sdktrace.SpanExporter
+	file *RotatingFile
+}
+
+// Shutdown implements sdktrace.SpanExporter
+func (e *fileSpanExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// fileMetricExporter closes the file when the exporter shuts down
+type fileMetricExporter struct {
+	sdkmetric.Exporter
+	file *RotatingFile
+}
+
+// Shutdown implements sdkmetric.Exporter
+func (e *fileMetricExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// fileLogExporter closes the file when the exporter shuts down
+type fileLogExporter struct {
+	sdklog.Exporter
+	file *RotatingFile
+}
+
+// Shutdown implements sdklog.Exporter
+func (e *fileLogExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ===== ROTATING FILE =====
+
+// RotatingFile appends lines to <dir>/<name>.jsonl and rotates it by size and age.
+// Rotated files are renamed to <name>-<timestamp>.jsonl and optionally gzipped.
+type RotatingFile struct {
+	name   string
+	config domain.FileExporterConfig
+	file   *os.File
+	size   int64
+	opened time.Time
+	mu     sync.Mutex
+}
+
+// NewRotatingFile opens (or continues) the active file for name in the configured directory
+func NewRotatingFile(name string, config domain.FileExporterConfig) (*RotatingFile, error) {
+	if err := os.MkdirAll(config.Directory, 0o750); err != nil {
+		return nil, err
+	}
+	r := &RotatingFile{name: name, config: config}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Path returns the path of the active file
+func (r *RotatingFile) Path() string {
+	return filepath.Join(r.config.Directory, r.name+otlpJSONExt)
+}
+
+// WriteLine appends line and a newline, rotating first when a limit has been reached
+func (r *RotatingFile) WriteLine(line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return fmt.Errorf("%s: file is closed", r.Path())
+	}
+	if r.size > 0 && r.due(int64(len(line))+1) {
+		if err := r.rotate(); err != nil {
+			if r.file == nil {
+				return err
+			}
+			// Keep the line in the reopened file rather than losing it
+			otel.Handle(fmt.Errorf("failed to rotate %s: %w", r.Path(), err))
+		}
+	}
+
+	n, err := r.file.Write(append(line, '\n'))
+	r.size += int64(n)
+	return err
+}
+
+// Rotate closes the active file and starts a new one
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// Close closes the active file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) due(next int64) bool {
+	if r.config.MaxSize > 0 && r.size+next > r.config.MaxSize {
+		return true
+	}
+	return r.config.MaxAge > 0 && time.Since(r.opened) >= r.config.MaxAge
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.Path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	r.opened = time.Now()
+	return nil
+}
+
+// rotate renames the active file and opens a new one. The active file is reopened even
+// when the rename fails, so writing continues in the same file; a failed compression
+// is reported to the OpenTelemetry error handler and leaves the rotated file
+// uncompressed, which replay reads as well.
+func (r *RotatingFile) rotate() error {
+	var closeErr error
+	if r.file != nil {
+		closeErr = r.file.Close()
+		r.file = nil
+	}
+
+	rotated := filepath.Join(r.config.Directory,
+		r.name+"-"+time.Now().UTC().Format(rotatedTimeFormat)+otlpJSONExt)
+	renameErr := os.Rename(r.Path(), rotated)
+	if renameErr == nil && r.config.Compress {
+		if err := gzipFile(rotated); err != nil {
+			otel.Handle(fmt.Errorf("failed to compress %s: %w", rotated, err))
+		}
+	}
+	return errors.Join(closeErr, renameErr, r.open())
+}
+
+// gzipFile compresses path to path.gz and removes the original
+func gzipFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
@@ -21,15 +21,24 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/telemetryflow/telemetryflow-go-sdk/internal/version"
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
 	otelmetric "go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
@@ -40,10 +49,28 @@ type TelemetryCommandHandler struct {
 	config         *domain.TelemetryConfig
 	tracerProvider *sdktrace.TracerProvider
 	meterProvider  *sdkmetric.MeterProvider
+	loggerProvider *sdklog.LoggerProvider
 	tracer         trace.Tracer
 	meter          otelmetric.Meter
+	logger         otellog.Logger
 	activeSpans    map[string]trace.Span
+	spanBaggage    map[string]baggage.Baggage // baggage of the active spans that have any
 	spansMutex     sync.RWMutex
+	endpointPools  []*EndpointPool
+	apiVersions    *APIVersionNegotiator
+	spanProcessors []sdktrace.SpanProcessor
+	metricReaders  []sdkmetric.Reader
+	logProcessors  []sdklog.Processor
+	prometheus     *PrometheusReader
+	runtime        *RuntimeMetrics
+	timestamped    *timestampedMetrics
+	instruments    *instrumentCache
+	logFilter      *LogFilter
+	exportStatus   *ExportStatus
+	errorHandler   *sdkErrorHandler
+	selfMetrics    *SelfMetrics
+	memory         *MemoryLimiter
+	selfProvider   otelmetric.MeterProvider
 	initialized    bool
 	initMutex      sync.Mutex
 }
@@ -53,10 +80,46 @@ func NewTelemetryCommandHandler(config *domain.TelemetryConfig) *TelemetryComman
 	return &TelemetryCommandHandler{
 		config:      config,
 		activeSpans: make(map[string]trace.Span),
+		spanBaggage: make(map[string]baggage.Baggage),
 		initialized: false,
 	}
 }
 
+// AddSpanProcessor registers a span processor next to the configured exporters.
+// Processors must be added before the SDK is initialized.
+func (h *TelemetryCommandHandler) AddSpanProcessor(processor sdktrace.SpanProcessor) {
+	h.spanProcessors = append(h.spanProcessors, processor)
+}
+
+// AddMetricReader registers a metric reader next to the configured exporters.
+// Readers must be added before the SDK is initialized.
+func (h *TelemetryCommandHandler) AddMetricReader(reader sdkmetric.Reader) {
+	h.metricReaders = append(h.metricReaders, reader)
+}
+
+// AddLogProcessor registers a log processor next to the configured exporters.
+// Processors must be added before the SDK is initialized.
+func (h *TelemetryCommandHandler) AddLogProcessor(processor sdklog.Processor) {
+	h.logProcessors = append(h.logProcessors, processor)
+}
+
+// SetSelfMetricsMeterProvider sends the SDK's own telemetryflow.sdk.* metrics to provider
+// instead of the client's meter provider. It must be called before the SDK is initialized.
+func (h *TelemetryCommandHandler) SetSelfMetricsMeterProvider(provider otelmetric.MeterProvider) {
+	h.selfProvider = provider
+}
+
+// PrometheusHandler returns the handler serving metrics in the Prometheus format, or nil
+// unless the SDK is initialized with the Prometheus exporter.
+func (h *TelemetryCommandHandler) PrometheusHandler() http.Handler {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+	if h.prometheus == nil {
+		return nil
+	}
+	return h.prometheus.Handler()
+}
+
 // Handle dispatches commands to appropriate handlers
 func (h *TelemetryCommandHandler) Handle(ctx context.Context, cmd application.Command) error {
 	switch c := cmd.(type) {
@@ -74,14 +137,28 @@ func (h *TelemetryCommandHandler) Handle(ctx context.Context, cmd application.Co
 		return h.handleRecordGauge(ctx, c)
 	case *application.RecordHistogramCommand:
 		return h.handleRecordHistogram(ctx, c)
+	case *application.RecordMetricsCommand:
+		return batchErr(h.RecordMetricsDirect(ctx, c))
 	case *application.EmitLogCommand:
 		return h.handleEmitLog(ctx, c)
+	case *application.EmitBatchLogsCommand:
+		return batchErr(h.EmitLogsDirect(ctx, c))
 	case *application.StartSpanCommand:
 		return h.handleStartSpan(ctx, c)
 	case *application.EndSpanCommand:
 		return h.handleEndSpan(ctx, c)
 	case *application.AddSpanEventCommand:
 		return h.handleAddSpanEvent(ctx, c)
+	case *application.SetSpanAttributesCommand:
+		return h.handleSetSpanAttributes(ctx, c)
+	case *application.SetSpanStatusCommand:
+		return h.handleSetSpanStatus(ctx, c)
+	case *application.SetSpanNameCommand:
+		return h.handleSetSpanName(ctx, c)
+	case *application.AddSpanLinkCommand:
+		return h.handleAddSpanLink(ctx, c)
+	case *application.SetSpanBaggageCommand:
+		return h.handleSetSpanBaggage(ctx, c)
 	default:
 		return fmt.Errorf("unknown command type: %T", cmd)
 	}
@@ -89,7 +166,7 @@ func (h *TelemetryCommandHandler) Handle(ctx context.Context, cmd application.Co
 
 // ===== SDK LIFECYCLE HANDLERS =====
 
-func (h *TelemetryCommandHandler) handleInitializeSDK(ctx context.Context, cmd *application.InitializeSDKCommand) error {
+func (h *TelemetryCommandHandler) handleInitializeSDK(ctx context.Context, cmd *application.InitializeSDKCommand) (err error) {
 	h.initMutex.Lock()
 	defer h.initMutex.Unlock()
 
@@ -97,6 +174,15 @@ func (h *TelemetryCommandHandler) handleInitializeSDK(ctx context.Context, cmd *
 		return fmt.Errorf("SDK already initialized")
 	}
 
+	// Everything started below is shut down again when a later step fails, so a failed
+	// initialization leaves nothing running and can be retried
+	var started []func(context.Context) error
+	defer func() {
+		if err != nil {
+			h.abortInitialize(ctx, started)
+		}
+	}()
+
 	h.config = cmd.Config
 
 	// Validate configuration
@@ -105,6 +191,18 @@ func (h *TelemetryCommandHandler) handleInitializeSDK(ctx context.Context, cmd *
 	}
 
 	factory := NewOTLPExporterFactory(h.config)
+	h.apiVersions = factory.APIVersions()
+
+	// Exporters and batch processors are wrapped as they are created, so this comes first
+	h.exportStatus = NewExportStatus(h.config)
+
+	// Background SDK errors, such as failed batch exports, show in the health report and
+	// go to the configured handler
+	h.errorHandler = installErrorHandler(h.exportStatus, h.config.ErrorHandler())
+	if h.config.IsSelfMetricsEnabled() {
+		h.selfMetrics = NewSelfMetrics()
+	}
+	h.memory = NewMemoryLimiter(h.config.MemoryLimiter())
 
 	// Create resource
 	resource, err := factory.CreateResource(ctx)
@@ -114,43 +212,371 @@ func (h *TelemetryCommandHandler) handleInitializeSDK(ctx context.Context, cmd *
 
 	// Initialize traces if enabled
 	if h.config.IsSignalEnabled(domain.SignalTraces) {
-		traceExporter, err := factory.CreateTraceExporter(ctx)
+		tracerOpts := []sdktrace.TracerProviderOption{
+			sdktrace.WithResource(resource),
+		}
+		if h.memory != nil {
+			tracerOpts = append(tracerOpts, sdktrace.WithSampler(h.memory.sampler(sdktrace.ParentBased(sdktrace.AlwaysSample()))))
+		}
+
+		if h.config.IsExporterEnabled(domain.ExporterOTLP) {
+			traceExporter, err := factory.CreateTraceExporter(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create trace exporter: %w", err)
+			}
+			started = append(started, traceExporter.Shutdown)
+			if failover, ok := traceExporter.(*FailoverSpanExporter); ok {
+				h.endpointPools = append(h.endpointPools, failover.Pool())
+			}
+			tracerOpts = append(tracerOpts, h.spanBatcher(traceExporter, string(domain.ExporterOTLP), h.config))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterConsole) {
+			tracerOpts = append(tracerOpts, h.spanBatcher(factory.CreateConsoleTraceExporter(), string(domain.ExporterConsole), h.config))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterFile) {
+			fileExporter, err := factory.CreateFileTraceExporter(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create trace file exporter: %w", err)
+			}
+			started = append(started, fileExporter.Shutdown)
+			tracerOpts = append(tracerOpts, h.spanBatcher(fileExporter, string(domain.ExporterFile), h.config))
+		}
+
+		// Each destination gets its own batcher so a failing destination cannot block the others
+		destinationExporters, err := factory.CreateDestinationTraceExporters(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create trace exporter: %w", err)
+			return fmt.Errorf("failed to create destination trace exporters: %w", err)
+		}
+		for i, exporter := range destinationExporters {
+			started = append(started, exporter.Shutdown)
+			name, config := h.destination(domain.SignalTraces, i)
+			tracerOpts = append(tracerOpts, h.spanBatcher(exporter, name, config))
+		}
+		for _, processor := range h.spanProcessors {
+			tracerOpts = append(tracerOpts, sdktrace.WithSpanProcessor(processor))
 		}
 
-		h.tracerProvider = sdktrace.NewTracerProvider(
-			sdktrace.WithBatcher(traceExporter,
-				sdktrace.WithBatchTimeout(h.config.BatchTimeout()),
-				sdktrace.WithMaxExportBatchSize(h.config.BatchMaxSize()),
-			),
-			sdktrace.WithResource(resource),
-		)
-		otel.SetTracerProvider(h.tracerProvider)
+		h.tracerProvider = sdktrace.NewTracerProvider(tracerOpts...)
+		started = append(started, h.tracerProvider.Shutdown)
 		h.tracer = h.tracerProvider.Tracer(h.config.ServiceName())
 	}
 
 	// Initialize metrics if enabled
 	if h.config.IsSignalEnabled(domain.SignalMetrics) {
-		metricExporter, err := factory.CreateMetricExporter(ctx)
+		meterOpts := []sdkmetric.Option{
+			sdkmetric.WithResource(resource),
+		}
+
+		// Runtime histograms and timestamped points are produced per reader, so this must
+		// precede reader creation
+		if h.config.IsRuntimeMetricsEnabled() {
+			h.runtime = NewRuntimeMetrics(h.config.RuntimeMetricsInterval())
+		}
+		h.timestamped = newTimestampedMetrics(h.config.ServiceName())
+
+		if h.config.IsExporterEnabled(domain.ExporterOTLP) {
+			metricExporter, err := factory.CreateMetricExporter(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create metric exporter: %w", err)
+			}
+			started = append(started, metricExporter.Shutdown)
+			if failover, ok := metricExporter.(*FailoverMetricExporter); ok {
+				h.endpointPools = append(h.endpointPools, failover.Pool())
+			}
+			meterOpts = append(meterOpts, h.periodicReader(metricExporter, string(domain.ExporterOTLP), h.config))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterConsole) {
+			meterOpts = append(meterOpts, h.periodicReader(factory.CreateConsoleMetricExporter(), string(domain.ExporterConsole), h.config))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterFile) {
+			fileExporter, err := factory.CreateFileMetricExporter(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create metric file exporter: %w", err)
+			}
+			started = append(started, fileExporter.Shutdown)
+			meterOpts = append(meterOpts, h.periodicReader(fileExporter, string(domain.ExporterFile), h.config))
+		}
+
+		// Each destination gets its own reader so a failing destination cannot block the others
+		destinationExporters, err := factory.CreateDestinationMetricExporters(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create metric exporter: %w", err)
+			return fmt.Errorf("failed to create destination metric exporters: %w", err)
+		}
+		for i, exporter := range destinationExporters {
+			started = append(started, exporter.Shutdown)
+			name, config := h.destination(domain.SignalMetrics, i)
+			meterOpts = append(meterOpts, h.periodicReader(exporter, name, config))
+		}
+		for _, reader := range h.metricReaders {
+			meterOpts = append(meterOpts, sdkmetric.WithReader(reader))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterPrometheus) {
+			prometheusReader, err := factory.CreatePrometheusReader(h.producers()...)
+			if err != nil {
+				return fmt.Errorf("failed to create prometheus reader: %w", err)
+			}
+			h.prometheus = prometheusReader
+			meterOpts = append(meterOpts, sdkmetric.WithReader(prometheusReader.Reader()))
 		}
 
-		h.meterProvider = sdkmetric.NewMeterProvider(
-			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter,
-				sdkmetric.WithInterval(h.config.BatchTimeout()),
-			)),
-			sdkmetric.WithResource(resource),
-		)
-		otel.SetMeterProvider(h.meterProvider)
+		h.meterProvider = sdkmetric.NewMeterProvider(meterOpts...)
+		started = append(started, h.meterProvider.Shutdown)
 		h.meter = h.meterProvider.Meter(h.config.ServiceName())
+		h.instruments = newInstrumentCache(h.meter)
+
+		if h.runtime != nil {
+			if _, err := h.runtime.Register(h.meterProvider.Meter(RuntimeScopeName)); err != nil {
+				return fmt.Errorf("failed to register runtime metrics: %w", err)
+			}
+		}
+		if h.config.IsProcessMetricsEnabled() || h.config.IsHostMetricsEnabled() {
+			hostMetrics := NewHostMetrics(DefaultProcRoot, h.config.IsProcessMetricsEnabled(), h.config.IsHostMetricsEnabled())
+			if err := hostMetrics.Check(); err != nil {
+				return err
+			}
+			if _, err := hostMetrics.Register(h.meterProvider.Meter(HostScopeName)); err != nil {
+				return fmt.Errorf("failed to register host metrics: %w", err)
+			}
+		}
+	}
+
+	// Initialize logs if enabled
+	if h.config.IsSignalEnabled(domain.SignalLogs) {
+		loggerOpts := []sdklog.LoggerProviderOption{
+			sdklog.WithResource(resource),
+		}
+
+		if h.config.IsExporterEnabled(domain.ExporterOTLP) {
+			logExporter, err := factory.CreateLogExporter(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create log exporter: %w", err)
+			}
+			started = append(started, logExporter.Shutdown)
+			if failover, ok := logExporter.(*FailoverLogExporter); ok {
+				h.endpointPools = append(h.endpointPools, failover.Pool())
+			}
+			loggerOpts = append(loggerOpts, h.logProcessor(logExporter, string(domain.ExporterOTLP), h.config))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterConsole) {
+			loggerOpts = append(loggerOpts, h.logProcessor(factory.CreateConsoleLogExporter(), string(domain.ExporterConsole), h.config))
+		}
+		if h.config.IsExporterEnabled(domain.ExporterFile) {
+			fileExporter, err := factory.CreateFileLogExporter(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create log file exporter: %w", err)
+			}
+			started = append(started, fileExporter.Shutdown)
+			loggerOpts = append(loggerOpts, h.logProcessor(fileExporter, string(domain.ExporterFile), h.config))
+		}
+
+		// Each destination gets its own processor so a failing destination cannot block the others
+		destinationExporters, err := factory.CreateDestinationLogExporters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create destination log exporters: %w", err)
+		}
+		for i, exporter := range destinationExporters {
+			started = append(started, exporter.Shutdown)
+			name, config := h.destination(domain.SignalLogs, i)
+			loggerOpts = append(loggerOpts, h.logProcessor(exporter, name, config))
+		}
+		for _, processor := range h.logProcessors {
+			loggerOpts = append(loggerOpts, sdklog.WithProcessor(processor))
+		}
+
+		h.loggerProvider = sdklog.NewLoggerProvider(loggerOpts...)
+		started = append(started, h.loggerProvider.Shutdown)
+		h.logger = h.loggerProvider.Logger(h.config.ServiceName())
+		h.logFilter = NewLogFilter(h.config.LogFilter(), h.emitLogSummary)
+	}
+
+	// Failover metrics cover every signal, so register them once all pools are known
+	if h.meter != nil {
+		if err := h.registerFailoverMetrics(); err != nil {
+			return fmt.Errorf("failed to register failover metrics: %w", err)
+		}
+	}
+
+	// The endpoint starts serving once everything it reports on is set up
+	if h.prometheus != nil {
+		if addr := h.config.PrometheusEndpoint(); addr != "" {
+			if err := h.prometheus.Listen(addr); err != nil {
+				return fmt.Errorf("failed to start prometheus endpoint: %w", err)
+			}
+		}
+	}
+
+	// Self metrics go to the client's meter provider unless another one was given
+	if h.selfMetrics != nil {
+		provider := h.selfProvider
+		if provider == nil && h.meterProvider != nil {
+			provider = h.meterProvider
+		}
+		if provider != nil {
+			if err := h.selfMetrics.Register(selfMetricsMeter(provider), h.activeSpanCount, h.memory); err != nil {
+				return fmt.Errorf("failed to register self metrics: %w", err)
+			}
+		}
+	}
+
+	// The providers only become global once initialization can no longer fail
+	if h.tracerProvider != nil {
+		otel.SetTracerProvider(h.tracerProvider)
+	}
+	if h.meterProvider != nil {
+		otel.SetMeterProvider(h.meterProvider)
+	}
+	if h.loggerProvider != nil {
+		global.SetLoggerProvider(h.loggerProvider)
 	}
 
 	h.initialized = true
 	return nil
 }
 
+// abortInitialize shuts down what a failed initialization started, newest first, and
+// forgets it. Exporters already owned by a provider are shut down twice; the second
+// call has nothing left to do.
+func (h *TelemetryCommandHandler) abortInitialize(ctx context.Context, started []func(context.Context) error) {
+	if h.logFilter != nil {
+		h.logFilter.Close()
+	}
+	if h.prometheus != nil {
+		_ = h.prometheus.Shutdown(ctx)
+	}
+	for i := len(started) - 1; i >= 0; i-- {
+		_ = started[i](ctx)
+	}
+
+	h.tracerProvider, h.tracer = nil, nil
+	h.meterProvider, h.meter, h.instruments = nil, nil, nil
+	h.loggerProvider, h.logger, h.logFilter = nil, nil, nil
+	h.prometheus = nil
+	h.endpointPools = nil
+	h.runtime = nil
+	h.selfMetrics = nil
+	h.restoreErrorHandler()
+}
+
+// restoreErrorHandler puts back the global error handler replaced on initialization
+func (h *TelemetryCommandHandler) restoreErrorHandler() {
+	if h.errorHandler != nil {
+		h.errorHandler.restore()
+		h.errorHandler = nil
+	}
+}
+
+// destination returns the name and configuration of the i-th destination receiving
+// signal, in the order the factory creates destination exporters
+func (h *TelemetryCommandHandler) destination(signal domain.SignalType, i int) (string, *domain.TelemetryConfig) {
+	for _, destination := range h.config.Destinations() {
+		config := h.config.ForDestination(destination)
+		if !config.IsSignalEnabled(signal) {
+			continue
+		}
+		if i == 0 {
+			return destination.Name(), config
+		}
+		i--
+	}
+	return "", h.config
+}
+
+// activeSpanCount returns the number of spans started through the client and not ended
+func (h *TelemetryCommandHandler) activeSpanCount() int64 {
+	h.spansMutex.RLock()
+	defer h.spansMutex.RUnlock()
+	return int64(len(h.activeSpans))
+}
+
+// spanBatcher wraps a span exporter in a batch processor using the configured batch settings.
+// config holds the retry and circuit breaker settings of the exporter.
+func (h *TelemetryCommandHandler) spanBatcher(exporter sdktrace.SpanExporter, name string, config *domain.TelemetryConfig) sdktrace.TracerProviderOption {
+	opts := []sdktrace.BatchSpanProcessorOption{
+		sdktrace.WithBatchTimeout(h.config.BatchTimeout()),
+		sdktrace.WithMaxExportBatchSize(h.config.BatchMaxSize()),
+	}
+	tracker := newExportTracker(h.exportStatus, h.selfMetrics, h.memory, config, domain.SignalTraces, name)
+	if h.selfMetrics == nil && h.memory == nil {
+		return sdktrace.WithBatcher(&trackedSpanExporter{SpanExporter: exporter, tracker: tracker}, opts...)
+	}
+
+	// Self metrics count queued and dropped spans and the memory limiter accounts for
+	// them, so admission moves in front of the batcher
+	opts = append(opts, sdktrace.WithMaxQueueSize(selfMetricsQueueSize))
+	batcher := sdktrace.NewBatchSpanProcessor(&trackedSpanExporter{SpanExporter: exporter, tracker: tracker, queued: true}, opts...)
+	return sdktrace.WithSpanProcessor(&trackedSpanProcessor{SpanProcessor: batcher, tracker: tracker})
+}
+
+// periodicReader wraps a metric exporter in a periodic reader using the configured batch timeout.
+// config holds the retry and circuit breaker settings of the exporter.
+func (h *TelemetryCommandHandler) periodicReader(exporter sdkmetric.Exporter, name string, config *domain.TelemetryConfig) sdkmetric.Option {
+	exporter = &trackedMetricExporter{
+		Exporter: exporter,
+		tracker:  newExportTracker(h.exportStatus, h.selfMetrics, h.memory, config, domain.SignalMetrics, name),
+	}
+	opts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(h.config.BatchTimeout())}
+	for _, producer := range h.producers() {
+		opts = append(opts, sdkmetric.WithProducer(producer))
+	}
+	// Timestamped points are only held for readers that collect on their own
+	opts = append(opts, sdkmetric.WithProducer(h.timestamped.producer()))
+	return sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, opts...))
+}
+
+// producers returns the metric producers every reader created by the SDK includes
+func (h *TelemetryCommandHandler) producers() []sdkmetric.Producer {
+	if h.runtime == nil {
+		return nil
+	}
+	return []sdkmetric.Producer{h.runtime}
+}
+
+// logProcessor wraps a log exporter in a batch processor using the configured batch settings.
+// config holds the retry and circuit breaker settings of the exporter.
+func (h *TelemetryCommandHandler) logProcessor(exporter sdklog.Exporter, name string, config *domain.TelemetryConfig) sdklog.LoggerProviderOption {
+	opts := []sdklog.BatchProcessorOption{
+		sdklog.WithExportInterval(h.config.BatchTimeout()),
+		sdklog.WithExportMaxBatchSize(h.config.BatchMaxSize()),
+	}
+	tracker := newExportTracker(h.exportStatus, h.selfMetrics, h.memory, config, domain.SignalLogs, name)
+	if h.selfMetrics == nil && h.memory == nil {
+		return sdklog.WithProcessor(sdklog.NewBatchProcessor(&trackedLogExporter{Exporter: exporter, tracker: tracker}, opts...))
+	}
+
+	// Self metrics count queued and dropped records and the memory limiter accounts for
+	// them, so admission moves in front of the batcher
+	opts = append(opts, sdklog.WithMaxQueueSize(selfMetricsQueueSize))
+	batcher := sdklog.NewBatchProcessor(&trackedLogExporter{Exporter: exporter, tracker: tracker, queued: true}, opts...)
+	return sdklog.WithProcessor(&trackedLogProcessor{Processor: batcher, tracker: tracker})
+}
+
+// registerFailoverMetrics reports failover events through the SDK's own meter
+func (h *TelemetryCommandHandler) registerFailoverMetrics() error {
+	if len(h.endpointPools) == 0 {
+		return nil
+	}
+
+	failovers, err := h.meter.Int64Counter(
+		"telemetryflow.sdk.exporter.failovers",
+		otelmetric.WithDescription("Number of times exports moved to another collector endpoint"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Each series counts one transition, so it keeps growing across later failovers
+	for _, pool := range h.endpointPools {
+		pool.OnFailover(func(event FailoverEvent) {
+			failovers.Add(context.Background(), 1, otelmetric.WithAttributes(
+				attribute.String("signal", string(event.Signal)),
+				attribute.String("from", event.From),
+				attribute.String("to", event.To),
+			))
+		})
+	}
+	return nil
+}
+
 func (h *TelemetryCommandHandler) handleShutdownSDK(ctx context.Context, cmd *application.ShutdownSDKCommand) error {
 	if !h.initialized {
 		return fmt.Errorf("SDK not initialized")
@@ -175,7 +601,32 @@ func (h *TelemetryCommandHandler) handleShutdownSDK(ctx context.Context, cmd *ap
 		}
 	}
 
+	// Emit the summaries of the log de-duplication windows in progress before the
+	// logger provider flushes
+	if h.logFilter != nil {
+		h.logFilter.Close()
+	}
+
+	// Shutdown logger provider
+	if h.loggerProvider != nil {
+		if err := h.loggerProvider.Shutdown(shutdownCtx); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("logger provider shutdown: %w", err))
+		}
+	}
+
+	// Stop serving Prometheus scrapes
+	if h.prometheus != nil {
+		if err := h.prometheus.Shutdown(shutdownCtx); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("prometheus endpoint shutdown: %w", err))
+		}
+		h.prometheus = nil
+	}
+
 	h.initialized = false
+	h.endpointPools = nil
+	h.runtime = nil
+	h.selfMetrics = nil
+	h.restoreErrorHandler()
 
 	if len(shutdownErrors) > 0 {
 		return fmt.Errorf("shutdown errors: %v", shutdownErrors)
@@ -208,6 +659,13 @@ func (h *TelemetryCommandHandler) handleFlushTelemetry(ctx context.Context, cmd
 		}
 	}
 
+	// Force flush logger provider
+	if h.loggerProvider != nil {
+		if err := h.loggerProvider.ForceFlush(flushCtx); err != nil {
+			flushErrors = append(flushErrors, fmt.Errorf("logger provider flush: %w", err))
+		}
+	}
+
 	if len(flushErrors) > 0 {
 		return fmt.Errorf("flush errors: %v", flushErrors)
 	}
@@ -215,34 +673,146 @@ func (h *TelemetryCommandHandler) handleFlushTelemetry(ctx context.Context, cmd
 	return nil
 }
 
-// ===== METRIC HANDLERS =====
+// ===== QUERY HANDLERS =====
+
+// Query answers health and status queries about the SDK itself
+func (h *TelemetryCommandHandler) Query(ctx context.Context, query interface{}) (interface{}, error) {
+	switch q := query.(type) {
+	case *application.GetHealthQuery:
+		return h.handleGetHealth(ctx)
+	case *application.GetSDKStatusQuery:
+		return h.handleGetSDKStatus(ctx)
+	case *application.GetSpanBaggageQuery:
+		return h.handleGetSpanBaggage(ctx, q)
+	default:
+		return nil, fmt.Errorf("unknown query type: %T", query)
+	}
+}
 
-func (h *TelemetryCommandHandler) handleRecordMetric(ctx context.Context, cmd *application.RecordMetricCommand) error {
-	if !h.initialized || h.meter == nil {
-		return fmt.Errorf("metrics not initialized")
+func (h *TelemetryCommandHandler) handleGetHealth(ctx context.Context) (*application.HealthQueryResult, error) {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+
+	result := &application.HealthQueryResult{
+		Status: "healthy",
+		Metrics: application.HealthMetrics{
+			ConnectionState: "connected",
+		},
 	}
 
-	// Create a float64 gauge
-	gauge, err := h.meter.Float64ObservableGauge(
-		cmd.Name,
-		otelmetric.WithUnit(cmd.Unit),
-		otelmetric.WithDescription(fmt.Sprintf("Metric: %s", cmd.Name)),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create gauge: %w", err)
+	if !h.initialized {
+		result.Status = "unhealthy"
+		result.Metrics.ConnectionState = "disconnected"
+		return result, nil
 	}
 
-	// Record the value
-	_, err = h.meter.RegisterCallback(
-		func(ctx context.Context, o otelmetric.Observer) error {
-			attrs := convertAttributes(cmd.Attributes)
-			o.ObserveFloat64(gauge, cmd.Value, otelmetric.WithAttributes(attrs...))
-			return nil
+	var lastFailure time.Time
+	for _, pool := range h.endpointPools {
+		endpoints := pool.Health()
+		result.Endpoints = append(result.Endpoints, endpoints...)
+		result.Failovers += pool.Failovers()
+
+		anyHealthy := false
+		for _, endpoint := range endpoints {
+			if endpoint.Healthy {
+				anyHealthy = true
+			}
+			if endpoint.Active && endpoint.Priority > 0 && result.Status == "healthy" {
+				result.Status = "degraded"
+			}
+			if endpoint.LastSuccess.After(result.LastSuccess) {
+				result.LastSuccess = endpoint.LastSuccess
+			}
+			if endpoint.LastError != nil && endpoint.LastFailure.After(lastFailure) {
+				lastFailure = endpoint.LastFailure
+				result.LastError = endpoint.LastError
+			}
+		}
+		if !anyHealthy {
+			result.Status = "unhealthy"
+			result.Metrics.ConnectionState = "disconnected"
+		}
+	}
+
+	// Export outcomes cover every exporter, including those without failover
+	h.exportStatus.applyHealth(result, lastFailure)
+
+	return result, nil
+}
+
+func (h *TelemetryCommandHandler) handleGetSDKStatus(ctx context.Context) (*application.SDKStatusResult, error) {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+
+	result := &application.SDKStatusResult{
+		Initialized: h.initialized,
+		Version:     version.Version,
+		Config: map[string]interface{}{
+			"endpoint":  h.config.Endpoint(),
+			"endpoints": h.config.Endpoints(),
+			"protocol":  string(h.config.Protocol()),
+			"service":   h.config.ServiceName(),
 		},
-		gauge,
-	)
+	}
 
-	return err
+	for _, signal := range []domain.SignalType{domain.SignalMetrics, domain.SignalLogs, domain.SignalTraces} {
+		if h.config.IsSignalEnabled(signal) {
+			result.EnabledSignals = append(result.EnabledSignals, string(signal))
+		}
+	}
+
+	exporters := make([]string, 0, 2)
+	for _, exporter := range h.config.Exporters() {
+		exporters = append(exporters, string(exporter))
+	}
+	result.Config["exporters"] = exporters
+
+	activeEndpoints := make(map[string]string, len(h.endpointPools))
+	for _, pool := range h.endpointPools {
+		activeEndpoints[string(pool.Signal())] = pool.ActiveEndpoint()
+	}
+	if len(activeEndpoints) > 0 {
+		result.Config["active_endpoints"] = activeEndpoints
+	}
+
+	// Report the negotiated TFO API version (v2 exports may have fallen back to v1)
+	apiVersions := h.apiVersions
+	if apiVersions == nil {
+		apiVersions = NewAPIVersionNegotiator(h.config.UseV2API(), h.config.IsV2Only())
+	}
+	result.Config["api_version"] = apiVersions.Version(h.config.Endpoint())
+	if negotiated := apiVersions.Versions(); len(negotiated) > 0 {
+		result.Config["api_versions"] = negotiated
+	}
+
+	if h.exportStatus != nil {
+		h.exportStatus.applyStatistics(&result.Statistics)
+	}
+	result.Memory = h.memory.status()
+
+	return result, nil
+}
+
+// ===== METRIC HANDLERS =====
+
+func (h *TelemetryCommandHandler) handleRecordMetric(ctx context.Context, cmd *application.RecordMetricCommand) error {
+	result, err := h.RecordMetricsDirect(ctx, &application.RecordMetricsCommand{
+		Measurements: []application.Measurement{{
+			Kind:       instrumentGauge,
+			Name:       cmd.Name,
+			Value:      cmd.Value,
+			Unit:       cmd.Unit,
+			Attributes: cmd.Attributes,
+			Timestamp:  cmd.Timestamp,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors[0].Err
+	}
+	return nil
 }
 
 func (h *TelemetryCommandHandler) handleRecordCounter(ctx context.Context, cmd *application.RecordCounterCommand) error {
@@ -250,17 +820,12 @@ func (h *TelemetryCommandHandler) handleRecordCounter(ctx context.Context, cmd *
 		return fmt.Errorf("metrics not initialized")
 	}
 
-	counter, err := h.meter.Int64Counter(
-		cmd.Name,
-		otelmetric.WithDescription(fmt.Sprintf("Counter: %s", cmd.Name)),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create counter: %w", err)
-	}
-
-	attrs := convertAttributes(cmd.Attributes)
-	counter.Add(ctx, cmd.Value, otelmetric.WithAttributes(attrs...))
-	return nil
+	return h.instruments.record(ctx, []instrumentValue{{
+		kind:       instrumentCounter,
+		name:       cmd.Name,
+		attributes: attribute.NewSet(convertAttributes(cmd.Attributes)...),
+		count:      cmd.Value,
+	}})[0]
 }
 
 func (h *TelemetryCommandHandler) handleRecordGauge(ctx context.Context, cmd *application.RecordGaugeCommand) error {
@@ -269,7 +834,6 @@ func (h *TelemetryCommandHandler) handleRecordGauge(ctx context.Context, cmd *ap
 		Name:       cmd.Name,
 		Value:      cmd.Value,
 		Attributes: cmd.Attributes,
-		Timestamp:  time.Now(),
 	})
 }
 
@@ -278,41 +842,212 @@ func (h *TelemetryCommandHandler) handleRecordHistogram(ctx context.Context, cmd
 		return fmt.Errorf("metrics not initialized")
 	}
 
-	histogram, err := h.meter.Float64Histogram(
-		cmd.Name,
-		otelmetric.WithUnit(cmd.Unit),
-		otelmetric.WithDescription(fmt.Sprintf("Histogram: %s", cmd.Name)),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create histogram: %w", err)
+	return h.instruments.record(ctx, []instrumentValue{{
+		kind:       instrumentHistogram,
+		name:       cmd.Name,
+		unit:       cmd.Unit,
+		attributes: attribute.NewSet(convertAttributes(cmd.Attributes)...),
+		value:      cmd.Value,
+	}})[0]
+}
+
+// RecordMetricsDirect records a batch of measurements and reports the outcome of each.
+// The batch is checked against a single current time, and its gauge values are written
+// together: a collection reports either none or all of the values the batch records for
+// a gauge, across attribute sets.
+func (h *TelemetryCommandHandler) RecordMetricsDirect(ctx context.Context, cmd *application.RecordMetricsCommand) (*application.BatchResult, error) {
+	if !h.initialized || h.meter == nil {
+		return nil, fmt.Errorf("metrics not initialized")
 	}
 
-	attrs := convertAttributes(cmd.Attributes)
-	histogram.Record(ctx, cmd.Value, otelmetric.WithAttributes(attrs...))
-	return nil
+	skew := h.config.TimestampSkew()
+	now := time.Now()
+	errs := make([]error, len(cmd.Measurements))
+	var values []instrumentValue
+	var valueIndex []int
+	var points []timestampedPoint
+	var pointIndex []int
+	for i, m := range cmd.Measurements {
+		kind := m.Kind
+		if kind == "" {
+			kind = instrumentGauge
+		}
+		attrs := attribute.NewSet(convertAttributes(m.Attributes)...)
+
+		// A timestamped value is exported once with its own time instead of being
+		// observed at every collection
+		if !m.Timestamp.IsZero() {
+			if kind != instrumentGauge {
+				errs[i] = fmt.Errorf("metric %s: timestamps are only supported for gauges", m.Name)
+				continue
+			}
+			if err := skew.Check(m.Timestamp, now); err != nil {
+				errs[i] = fmt.Errorf("metric %s: %w", m.Name, err)
+				continue
+			}
+			points = append(points, timestampedPoint{name: m.Name, unit: m.Unit, attributes: attrs, time: m.Timestamp, value: m.Value})
+			pointIndex = append(pointIndex, i)
+			continue
+		}
+
+		value := instrumentValue{kind: kind, name: m.Name, unit: m.Unit, attributes: attrs, value: m.Value}
+		if kind == instrumentCounter {
+			if m.Value < 0 || m.Value != math.Trunc(m.Value) || m.Value >= math.MaxInt64 {
+				errs[i] = fmt.Errorf("metric %s: counter increments must be whole, non-negative numbers", m.Name)
+				continue
+			}
+			value.count = int64(m.Value)
+		}
+		values = append(values, value)
+		valueIndex = append(valueIndex, i)
+	}
+
+	for j, err := range h.instruments.record(ctx, values) {
+		errs[valueIndex[j]] = err
+	}
+	recorded, err := h.timestamped.record(points...)
+	for _, i := range pointIndex[recorded:] {
+		errs[i] = err
+	}
+	return batchResult(errs), nil
 }
 
 // ===== LOG HANDLERS =====
 
 func (h *TelemetryCommandHandler) handleEmitLog(ctx context.Context, cmd *application.EmitLogCommand) error {
-	// For logs, we'll use the trace span to emit log events
-	// In a production SDK, you'd integrate with go.opentelemetry.io/otel/log when stable
+	if !h.initialized || h.logger == nil {
+		return fmt.Errorf("logs not initialized")
+	}
+
+	now := time.Now()
+	record, err := newLogRecord(cmd, h.config.TimestampSkew(), now)
+	if err != nil {
+		return err
+	}
+	if !h.logFilter.Allow(record.Severity(), cmd.Severity, loggerName(cmd.Attributes), cmd.Message, now) {
+		return nil
+	}
+
+	// The log record is correlated with the span in ctx, or with the explicit IDs on the command
+	h.logger.Emit(logContext(ctx, cmd.TraceID, cmd.SpanID), record)
+	return nil
+}
+
+// EmitLogsDirect emits a batch of logs and reports the outcome of each record. The
+// batch is checked against a single current time, which is also the observed time of
+// every record.
+func (h *TelemetryCommandHandler) EmitLogsDirect(ctx context.Context, cmd *application.EmitBatchLogsCommand) (*application.BatchResult, error) {
+	if !h.initialized || h.logger == nil {
+		return nil, fmt.Errorf("logs not initialized")
+	}
 
-	if h.tracer != nil {
-		span := trace.SpanFromContext(ctx)
-		if !span.IsRecording() {
-			// If no active span, this is a standalone log
-			// In production, use proper log exporter
-			return fmt.Errorf("log export not fully implemented - use trace spans for now")
+	skew := h.config.TimestampSkew()
+	now := time.Now()
+	errs := make([]error, len(cmd.Logs))
+	for i := range cmd.Logs {
+		entry := &cmd.Logs[i]
+		record, err := newLogRecord(entry, skew, now)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if !h.logFilter.Allow(record.Severity(), entry.Severity, loggerName(entry.Attributes), entry.Message, now) {
+			continue
 		}
+		h.logger.Emit(logContext(ctx, entry.TraceID, entry.SpanID), record)
+	}
+	return batchResult(errs), nil
+}
 
-		attrs := convertAttributes(cmd.Attributes)
-		span.AddEvent(cmd.Message, trace.WithAttributes(attrs...))
+// SetLogFilter replaces the log filter settings. It takes effect immediately, also while
+// logs are emitted.
+func (h *TelemetryCommandHandler) SetLogFilter(cfg domain.LogFilterConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
 	}
 
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+	h.config.WithLogFilter(cfg)
+	if h.logFilter != nil {
+		h.logFilter.Update(cfg)
+	}
 	return nil
 }
 
+// LogFilter returns the log filter settings in effect
+func (h *TelemetryCommandHandler) LogFilter() domain.LogFilterConfig {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+	return h.config.LogFilter()
+}
+
+// emitLogSummary emits the record summarizing the logs suppressed by the log filter
+func (h *TelemetryCommandHandler) emitLogSummary(summary LogSummary) {
+	now := time.Now()
+	var record otellog.Record
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(summary.Severity)
+	record.SetSeverityText(summary.SeverityText)
+	record.SetBody(otellog.StringValue(fmt.Sprintf("suppressed %d similar messages: %s", summary.Suppressed, summary.Template)))
+	record.AddAttributes(
+		otellog.String("log.template", summary.Template),
+		otellog.Int("log.suppressed_count", summary.Suppressed),
+	)
+	if summary.Logger != "" {
+		record.AddAttributes(otellog.String(domain.LoggerNameAttribute, summary.Logger))
+	}
+	h.logger.Emit(context.Background(), record)
+}
+
+// loggerName returns the logger of a log record, named by its domain.LoggerNameAttribute
+func loggerName(attrs map[string]interface{}) string {
+	name, _ := attrs[domain.LoggerNameAttribute].(string)
+	return name
+}
+
+// newLogRecord builds the log record of cmd, observed at now
+func newLogRecord(cmd *application.EmitLogCommand, skew domain.TimestampSkewConfig, now time.Time) (otellog.Record, error) {
+	var record otellog.Record
+	timestamp := cmd.Timestamp
+	if timestamp.IsZero() {
+		timestamp = now
+	} else if err := skew.Check(timestamp, now); err != nil {
+		return record, fmt.Errorf("log record: %w", err)
+	}
+
+	record.SetTimestamp(timestamp)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(parseSeverity(cmd.Severity))
+	record.SetSeverityText(cmd.Severity)
+	record.SetBody(otellog.StringValue(cmd.Message))
+	record.AddAttributes(convertLogAttributes(cmd.Attributes)...)
+	return record, nil
+}
+
+// batchResult collects the per-item errors of a batch command
+func batchResult(errs []error) *application.BatchResult {
+	result := &application.BatchResult{}
+	for i, err := range errs {
+		if err != nil {
+			result.Errors = append(result.Errors, application.BatchError{Index: i, Err: err})
+			continue
+		}
+		result.Accepted++
+	}
+	return result
+}
+
+// batchErr returns the error of a batch command handled through Handle: err, or the
+// errors of its rejected items
+func batchErr(result *application.BatchResult, err error) error {
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
 // ===== TRACE HANDLERS =====
 
 // StartSpanDirect starts a new span and returns its ID directly
@@ -345,10 +1080,13 @@ func (h *TelemetryCommandHandler) StartSpanDirect(ctx context.Context, name stri
 		trace.WithAttributes(attrs...),
 	)
 
-	// Store active span
+	// Store active span, with the baggage of ctx
 	spanID := span.SpanContext().SpanID().String()
 	h.spansMutex.Lock()
 	h.activeSpans[spanID] = span
+	if bag := baggage.FromContext(ctx); bag.Len() > 0 {
+		h.spanBaggage[spanID] = bag
+	}
 	h.spansMutex.Unlock()
 
 	return spanID, nil
@@ -364,6 +1102,7 @@ func (h *TelemetryCommandHandler) handleEndSpan(ctx context.Context, cmd *applic
 	span, exists := h.activeSpans[cmd.SpanID]
 	if exists {
 		delete(h.activeSpans, cmd.SpanID)
+		delete(h.spanBaggage, cmd.SpanID)
 	}
 	h.spansMutex.Unlock()
 
@@ -380,12 +1119,9 @@ func (h *TelemetryCommandHandler) handleEndSpan(ctx context.Context, cmd *applic
 }
 
 func (h *TelemetryCommandHandler) handleAddSpanEvent(ctx context.Context, cmd *application.AddSpanEventCommand) error {
-	h.spansMutex.RLock()
-	span, exists := h.activeSpans[cmd.SpanID]
-	h.spansMutex.RUnlock()
-
-	if !exists {
-		return fmt.Errorf("span not found: %s", cmd.SpanID)
+	span, err := h.activeSpan(cmd.SpanID)
+	if err != nil {
+		return err
 	}
 
 	attrs := convertAttributes(cmd.Attributes)
@@ -397,8 +1133,217 @@ func (h *TelemetryCommandHandler) handleAddSpanEvent(ctx context.Context, cmd *a
 	return nil
 }
 
+func (h *TelemetryCommandHandler) handleSetSpanAttributes(ctx context.Context, cmd *application.SetSpanAttributesCommand) error {
+	span, err := h.activeSpan(cmd.SpanID)
+	if err != nil {
+		return err
+	}
+
+	span.SetAttributes(convertAttributes(cmd.Attributes)...)
+	return nil
+}
+
+func (h *TelemetryCommandHandler) handleSetSpanStatus(ctx context.Context, cmd *application.SetSpanStatusCommand) error {
+	var code codes.Code
+	switch strings.ToLower(cmd.Code) {
+	case "unset", "":
+		code = codes.Unset
+	case "ok":
+		code = codes.Ok
+	case "error":
+		code = codes.Error
+	default:
+		return fmt.Errorf("unsupported span status: %s (use unset, ok or error)", cmd.Code)
+	}
+
+	span, err := h.activeSpan(cmd.SpanID)
+	if err != nil {
+		return err
+	}
+
+	span.SetStatus(code, cmd.Description)
+	return nil
+}
+
+func (h *TelemetryCommandHandler) handleSetSpanName(ctx context.Context, cmd *application.SetSpanNameCommand) error {
+	if cmd.Name == "" {
+		return fmt.Errorf("span name cannot be empty")
+	}
+
+	span, err := h.activeSpan(cmd.SpanID)
+	if err != nil {
+		return err
+	}
+
+	span.SetName(cmd.Name)
+	return nil
+}
+
+func (h *TelemetryCommandHandler) handleAddSpanLink(ctx context.Context, cmd *application.AddSpanLinkCommand) error {
+	traceID, err := trace.TraceIDFromHex(cmd.LinkedTraceID)
+	if err != nil {
+		return fmt.Errorf("invalid linked trace ID %q: %w", cmd.LinkedTraceID, err)
+	}
+	spanID, err := trace.SpanIDFromHex(cmd.LinkedSpanID)
+	if err != nil {
+		return fmt.Errorf("invalid linked span ID %q: %w", cmd.LinkedSpanID, err)
+	}
+
+	span, err := h.activeSpan(cmd.SpanID)
+	if err != nil {
+		return err
+	}
+
+	span.AddLink(trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		}),
+		Attributes: convertAttributes(cmd.Attributes),
+	})
+	return nil
+}
+
+func (h *TelemetryCommandHandler) handleSetSpanBaggage(ctx context.Context, cmd *application.SetSpanBaggageCommand) error {
+	member, err := baggage.NewMemberRaw(cmd.Key, cmd.Value)
+	if err != nil {
+		return fmt.Errorf("invalid baggage member %q: %w", cmd.Key, err)
+	}
+
+	h.spansMutex.Lock()
+	defer h.spansMutex.Unlock()
+
+	if _, exists := h.activeSpans[cmd.SpanID]; !exists {
+		return fmt.Errorf("span not found: %s", cmd.SpanID)
+	}
+	bag, err := h.spanBaggage[cmd.SpanID].SetMember(member)
+	if err != nil {
+		return fmt.Errorf("invalid baggage member %q: %w", cmd.Key, err)
+	}
+	h.spanBaggage[cmd.SpanID] = bag
+	return nil
+}
+
+func (h *TelemetryCommandHandler) handleGetSpanBaggage(ctx context.Context, query *application.GetSpanBaggageQuery) (*application.SpanBaggageResult, error) {
+	h.spansMutex.RLock()
+	defer h.spansMutex.RUnlock()
+
+	if _, exists := h.activeSpans[query.SpanID]; !exists {
+		return nil, fmt.Errorf("span not found: %s", query.SpanID)
+	}
+
+	members := h.spanBaggage[query.SpanID].Members()
+	result := &application.SpanBaggageResult{Baggage: make(map[string]string, len(members))}
+	for _, member := range members {
+		result.Baggage[member.Key()] = member.Value()
+	}
+	return result, nil
+}
+
+// ContextWithSpan returns ctx carrying an active span and its baggage, so that spans
+// started from it are its children and propagators inject both
+func (h *TelemetryCommandHandler) ContextWithSpan(ctx context.Context, spanID string) (context.Context, error) {
+	h.spansMutex.RLock()
+	defer h.spansMutex.RUnlock()
+
+	span, exists := h.activeSpans[spanID]
+	if !exists {
+		return ctx, fmt.Errorf("span not found: %s", spanID)
+	}
+
+	ctx = trace.ContextWithSpan(ctx, span)
+	if bag, ok := h.spanBaggage[spanID]; ok {
+		ctx = baggage.ContextWithBaggage(ctx, bag)
+	}
+	return ctx, nil
+}
+
+// activeSpan returns a span started through the handler and not ended yet
+func (h *TelemetryCommandHandler) activeSpan(spanID string) (trace.Span, error) {
+	h.spansMutex.RLock()
+	span, exists := h.activeSpans[spanID]
+	h.spansMutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("span not found: %s", spanID)
+	}
+	return span, nil
+}
+
 // ===== HELPER FUNCTIONS =====
 
+// parseSeverity maps a severity name to its OpenTelemetry log severity
+func parseSeverity(severity string) otellog.Severity {
+	switch strings.ToLower(severity) {
+	case "trace":
+		return otellog.SeverityTrace
+	case "debug":
+		return otellog.SeverityDebug
+	case "info":
+		return otellog.SeverityInfo
+	case "warn", "warning":
+		return otellog.SeverityWarn
+	case "error":
+		return otellog.SeverityError
+	case "fatal", "critical":
+		return otellog.SeverityFatal
+	}
+
+	// Numbered OTLP severities, e.g. "info2" or "error4"
+	for s := otellog.SeverityTrace1; s <= otellog.SeverityFatal4; s++ {
+		if strings.EqualFold(severity, s.String()) {
+			return s
+		}
+	}
+	return otellog.SeverityUndefined
+}
+
+// logContext returns ctx carrying the given trace and span IDs, unless ctx already has a valid span
+func logContext(ctx context.Context, traceID, spanID string) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() || traceID == "" || spanID == "" {
+		return ctx
+	}
+
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return ctx
+	}
+	sid, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		return ctx
+	}
+
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+func convertLogAttributes(attrs map[string]interface{}) []otellog.KeyValue {
+	result := make([]otellog.KeyValue, 0, len(attrs))
+	for key, value := range attrs {
+		switch v := value.(type) {
+		case string:
+			result = append(result, otellog.String(key, v))
+		case int:
+			result = append(result, otellog.Int(key, v))
+		case int64:
+			result = append(result, otellog.Int64(key, v))
+		case float64:
+			result = append(result, otellog.Float64(key, v))
+		case bool:
+			result = append(result, otellog.Bool(key, v))
+		default:
+			result = append(result, otellog.String(key, fmt.Sprintf("%v", v)))
+		}
+	}
+	return result
+}
+
 func convertAttributes(attrs map[string]interface{}) []attribute.KeyValue {
 	result := make([]attribute.KeyValue, 0, len(attrs))
 	for key, value := range attrs {
@@ -0,0 +1,567 @@
+// Package infrastructure provides process and host metrics read from /proc for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// HostScopeName is the instrumentation scope of the process and host metrics
+const HostScopeName = "github.com/telemetryflow/telemetryflow-go-sdk/host"
+
+// DefaultProcRoot is where procfs is mounted on Linux
+const DefaultProcRoot = "/proc"
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc. It is 100 on every
+// mainstream Linux architecture and cannot be read without cgo.
+const clockTicks = 100
+
+// diskSectorSize is the fixed sector size /proc/diskstats counts in, whatever the device's
+const diskSectorSize = 512
+
+// cpuModes are the /proc/stat CPU columns, in order, and their cpu.mode attribute values
+var cpuModes = []string{"user", "nice", "system", "idle", "iowait", "interrupt", "softirq", "steal"}
+
+// HostMetrics reports process and host resource usage read from procfs under the
+// OpenTelemetry semantic convention names (process.*, system.*). It only works on
+// Linux; elsewhere the files are missing and nothing is observed.
+type HostMetrics struct {
+	root     string
+	process  bool
+	host     bool
+	pageSize int64
+}
+
+// NewHostMetrics creates a collector reading procfs at root. process enables the
+// process.* metrics of the current process, host the system.* metrics.
+func NewHostMetrics(root string, process, host bool) *HostMetrics {
+	return &HostMetrics{
+		root:     root,
+		process:  process,
+		host:     host,
+		pageSize: int64(os.Getpagesize()),
+	}
+}
+
+// Register creates the observable process and host instruments on meter
+func (m *HostMetrics) Register(meter otelmetric.Meter) (otelmetric.Registration, error) {
+	var instruments []otelmetric.Observable
+	var callbacks []func(otelmetric.Observer)
+
+	if m.process {
+		observables, callback, err := m.registerProcess(meter)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, observables...)
+		callbacks = append(callbacks, callback)
+	}
+	if m.host {
+		observables, callback, err := m.registerHost(meter)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, observables...)
+		callbacks = append(callbacks, callback)
+	}
+	if len(instruments) == 0 {
+		return nil, errors.New("no process or host metrics enabled")
+	}
+
+	return meter.RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		// A callback error makes the SDK drop the whole collection, so a file that
+		// cannot be read only drops its own metrics
+		for _, callback := range callbacks {
+			callback(o)
+		}
+		return nil
+	}, instruments...)
+}
+
+// Check verifies that the procfs files the enabled metrics read are present
+func (m *HostMetrics) Check() error {
+	var files []string
+	if m.process {
+		files = append(files, filepath.Join("self", "stat"), filepath.Join("self", "statm"))
+	}
+	if m.host {
+		files = append(files, "stat", "meminfo", "loadavg")
+	}
+	for _, name := range files {
+		if _, err := os.Stat(filepath.Join(m.root, name)); err != nil {
+			return fmt.Errorf("process and host metrics need procfs (Linux only): %w", err)
+		}
+	}
+	return nil
+}
+
+// registerProcess creates the process.* instruments
+func (m *HostMetrics) registerProcess(meter otelmetric.Meter) ([]otelmetric.Observable, func(otelmetric.Observer), error) {
+	cpuTime, err := meter.Float64ObservableCounter("process.cpu.time",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Total CPU seconds broken down by different CPU modes."))
+	if err != nil {
+		return nil, nil, err
+	}
+	memoryUsage, err := meter.Int64ObservableUpDownCounter("process.memory.usage",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("The amount of physical memory in use."))
+	if err != nil {
+		return nil, nil, err
+	}
+	memoryVirtual, err := meter.Int64ObservableUpDownCounter("process.memory.virtual",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("The amount of committed virtual memory."))
+	if err != nil {
+		return nil, nil, err
+	}
+	fileDescriptors, err := meter.Int64ObservableUpDownCounter("process.unix.file_descriptor.count",
+		otelmetric.WithUnit("{file_descriptor}"),
+		otelmetric.WithDescription("Number of unix file descriptors in use by the process."))
+	if err != nil {
+		return nil, nil, err
+	}
+	threads, err := meter.Int64ObservableUpDownCounter("process.thread.count",
+		otelmetric.WithUnit("{thread}"),
+		otelmetric.WithDescription("Process threads count."))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userAttrs := otelmetric.WithAttributes(attribute.String("cpu.mode", "user"))
+	systemAttrs := otelmetric.WithAttributes(attribute.String("cpu.mode", "system"))
+
+	callback := func(o otelmetric.Observer) {
+		if stat, err := m.readProcessStat(); err == nil {
+			o.ObserveFloat64(cpuTime, float64(stat.utime)/clockTicks, userAttrs)
+			o.ObserveFloat64(cpuTime, float64(stat.stime)/clockTicks, systemAttrs)
+			o.ObserveInt64(threads, stat.threads)
+		}
+		if size, resident, err := m.readProcessStatm(); err == nil {
+			o.ObserveInt64(memoryVirtual, size*m.pageSize)
+			o.ObserveInt64(memoryUsage, resident*m.pageSize)
+		}
+		// The listing includes the descriptor opened to read the directory itself
+		if entries, err := os.ReadDir(filepath.Join(m.root, "self", "fd")); err == nil {
+			o.ObserveInt64(fileDescriptors, int64(max(len(entries)-1, 0)))
+		}
+	}
+	return []otelmetric.Observable{cpuTime, memoryUsage, memoryVirtual, fileDescriptors, threads}, callback, nil
+}
+
+// registerHost creates the system.* instruments
+func (m *HostMetrics) registerHost(meter otelmetric.Meter) ([]otelmetric.Observable, func(otelmetric.Observer), error) {
+	cpuTime, err := meter.Float64ObservableCounter("system.cpu.time",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Seconds each logical CPU spent on each mode, summed over all CPUs."))
+	if err != nil {
+		return nil, nil, err
+	}
+	cpuCount, err := meter.Int64ObservableUpDownCounter("system.cpu.logical.count",
+		otelmetric.WithUnit("{cpu}"),
+		otelmetric.WithDescription("Reports the number of logical (virtual) processor cores created by the operating system to manage multitasking."))
+	if err != nil {
+		return nil, nil, err
+	}
+	memoryUsage, err := meter.Int64ObservableUpDownCounter("system.memory.usage",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Reports memory in use by state."))
+	if err != nil {
+		return nil, nil, err
+	}
+	memoryLimit, err := meter.Int64ObservableUpDownCounter("system.memory.limit",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Total memory available in the system."))
+	if err != nil {
+		return nil, nil, err
+	}
+	var loadAverages [3]otelmetric.Float64ObservableGauge
+	for i, window := range []string{"1m", "5m", "15m"} {
+		loadAverages[i], err = meter.Float64ObservableGauge("system.cpu.load_average."+window,
+			otelmetric.WithUnit("{thread}"),
+			otelmetric.WithDescription("Average CPU load over the last "+window+"."))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	networkIO, err := meter.Int64ObservableCounter("system.network.io",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Bytes transmitted and received per network interface."))
+	if err != nil {
+		return nil, nil, err
+	}
+	networkPackets, err := meter.Int64ObservableCounter("system.network.packets",
+		otelmetric.WithUnit("{packet}"),
+		otelmetric.WithDescription("Packets transmitted and received per network interface."))
+	if err != nil {
+		return nil, nil, err
+	}
+	networkErrors, err := meter.Int64ObservableCounter("system.network.errors",
+		otelmetric.WithUnit("{error}"),
+		otelmetric.WithDescription("Transmit and receive errors per network interface."))
+	if err != nil {
+		return nil, nil, err
+	}
+	networkDropped, err := meter.Int64ObservableCounter("system.network.dropped",
+		otelmetric.WithUnit("{packet}"),
+		otelmetric.WithDescription("Packets dropped on transmit and receive per network interface."))
+	if err != nil {
+		return nil, nil, err
+	}
+	diskIO, err := meter.Int64ObservableCounter("system.disk.io",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Bytes read from and written to each block device."))
+	if err != nil {
+		return nil, nil, err
+	}
+	diskOperations, err := meter.Int64ObservableCounter("system.disk.operations",
+		otelmetric.WithUnit("{operation}"),
+		otelmetric.WithDescription("Completed read and write operations per block device."))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	callback := func(o otelmetric.Observer) {
+		if cpu, err := m.readCPUStat(); err == nil {
+			for i, ticks := range cpu.total {
+				o.ObserveFloat64(cpuTime, float64(ticks)/clockTicks,
+					otelmetric.WithAttributes(attribute.String("cpu.mode", cpuModes[i])))
+			}
+			o.ObserveInt64(cpuCount, cpu.logical)
+		}
+		if mem, err := m.readMeminfo(); err == nil {
+			total := mem["MemTotal"]
+			free := mem["MemFree"]
+			buffers := mem["Buffers"]
+			cached := mem["Cached"] + mem["SReclaimable"]
+			o.ObserveInt64(memoryLimit, total)
+			for state, value := range map[string]int64{
+				"used":    total - free - buffers - cached,
+				"free":    free,
+				"buffers": buffers,
+				"cached":  cached,
+			} {
+				o.ObserveInt64(memoryUsage, value,
+					otelmetric.WithAttributes(attribute.String("system.memory.state", state)))
+			}
+		}
+		if loads, err := m.readLoadavg(); err == nil {
+			for i, load := range loads {
+				o.ObserveFloat64(loadAverages[i], load)
+			}
+		}
+		if interfaces, err := m.readNetDev(); err == nil {
+			for _, iface := range interfaces {
+				for direction, c := range map[string]netCounters{"receive": iface.receive, "transmit": iface.transmit} {
+					attrs := otelmetric.WithAttributes(
+						attribute.String("network.interface.name", iface.name),
+						attribute.String("network.io.direction", direction))
+					o.ObserveInt64(networkIO, c.bytes, attrs)
+					o.ObserveInt64(networkPackets, c.packets, attrs)
+					o.ObserveInt64(networkErrors, c.errors, attrs)
+					o.ObserveInt64(networkDropped, c.dropped, attrs)
+				}
+			}
+		}
+		if disks, err := m.readDiskstats(); err == nil {
+			for _, disk := range disks {
+				for direction, c := range map[string][2]int64{
+					"read":  {disk.readOps, disk.readSectors},
+					"write": {disk.writeOps, disk.writeSectors},
+				} {
+					attrs := otelmetric.WithAttributes(
+						attribute.String("system.device", disk.name),
+						attribute.String("disk.io.direction", direction))
+					o.ObserveInt64(diskOperations, c[0], attrs)
+					o.ObserveInt64(diskIO, c[1]*diskSectorSize, attrs)
+				}
+			}
+		}
+	}
+
+	instruments := []otelmetric.Observable{
+		cpuTime, cpuCount, memoryUsage, memoryLimit,
+		loadAverages[0], loadAverages[1], loadAverages[2],
+		networkIO, networkPackets, networkErrors, networkDropped,
+		diskIO, diskOperations,
+	}
+	return instruments, callback, nil
+}
+
+// processStat holds the /proc/self/stat fields the collector reports
+type processStat struct {
+	utime   int64
+	stime   int64
+	threads int64
+}
+
+// readProcessStat parses /proc/self/stat. The command name may contain spaces and
+// parentheses, so fields are counted from the last ')'.
+func (m *HostMetrics) readProcessStat() (processStat, error) {
+	data, err := os.ReadFile(filepath.Join(m.root, "self", "stat"))
+	if err != nil {
+		return processStat{}, err
+	}
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return processStat{}, errors.New("malformed /proc/self/stat")
+	}
+	// fields[0] is field 3 (state) of proc(5)
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 18 {
+		return processStat{}, errors.New("malformed /proc/self/stat")
+	}
+	values, err := parseInts(fields[11], fields[12], fields[17])
+	if err != nil {
+		return processStat{}, fmt.Errorf("malformed /proc/self/stat: %w", err)
+	}
+	return processStat{utime: values[0], stime: values[1], threads: values[2]}, nil
+}
+
+// readProcessStatm returns the virtual and resident size of the process in pages
+func (m *HostMetrics) readProcessStatm() (size, resident int64, err error) {
+	data, err := os.ReadFile(filepath.Join(m.root, "self", "statm"))
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0, 0, errors.New("malformed /proc/self/statm")
+	}
+	values, err := parseInts(fields[0], fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed /proc/self/statm: %w", err)
+	}
+	return values[0], values[1], nil
+}
+
+// cpuStat holds the aggregate CPU ticks per mode and the number of logical CPUs
+type cpuStat struct {
+	total   []int64
+	logical int64
+}
+
+// readCPUStat parses the cpu lines of /proc/stat
+func (m *HostMetrics) readCPUStat() (cpuStat, error) {
+	var stat cpuStat
+	err := m.scanLines("stat", func(line string) error {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu"):
+		case fields[0] == "cpu":
+			columns := fields[1:]
+			if len(columns) > len(cpuModes) {
+				columns = columns[:len(cpuModes)]
+			}
+			values, err := parseInts(columns...)
+			if err != nil {
+				return fmt.Errorf("malformed /proc/stat: %w", err)
+			}
+			stat.total = values
+		default:
+			stat.logical++
+		}
+		return nil
+	})
+	if err == nil && stat.total == nil {
+		err = errors.New("malformed /proc/stat: no cpu line")
+	}
+	return stat, err
+}
+
+// readMeminfo returns /proc/meminfo in bytes, keyed by field name
+func (m *HostMetrics) readMeminfo() (map[string]int64, error) {
+	values := make(map[string]int64)
+	err := m.scanLines("meminfo", func(line string) error {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return nil
+		}
+		value, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("malformed /proc/meminfo: %w", err)
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			value <<= 10
+		}
+		values[name] = value
+		return nil
+	})
+	if err == nil && values["MemTotal"] == 0 {
+		err = errors.New("malformed /proc/meminfo: no MemTotal")
+	}
+	return values, err
+}
+
+// readLoadavg returns the 1, 5 and 15 minute load averages
+func (m *HostMetrics) readLoadavg() ([3]float64, error) {
+	var loads [3]float64
+	data, err := os.ReadFile(filepath.Join(m.root, "loadavg"))
+	if err != nil {
+		return loads, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return loads, errors.New("malformed /proc/loadavg")
+	}
+	for i := range loads {
+		if loads[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return loads, fmt.Errorf("malformed /proc/loadavg: %w", err)
+		}
+	}
+	return loads, nil
+}
+
+// netCounters are the per-direction counters of one /proc/net/dev interface
+type netCounters struct {
+	bytes, packets, errors, dropped int64
+}
+
+// netInterface is one line of /proc/net/dev
+type netInterface struct {
+	name              string
+	receive, transmit netCounters
+}
+
+// readNetDev parses /proc/net/dev, skipping its two header lines
+func (m *HostMetrics) readNetDev() ([]netInterface, error) {
+	var interfaces []netInterface
+	err := m.scanLines(filepath.Join("net", "dev"), func(line string) error {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 12 {
+			return fmt.Errorf("malformed /proc/net/dev line for %s", strings.TrimSpace(name))
+		}
+		values, err := parseInts(fields[0], fields[1], fields[2], fields[3], fields[8], fields[9], fields[10], fields[11])
+		if err != nil {
+			return fmt.Errorf("malformed /proc/net/dev: %w", err)
+		}
+		interfaces = append(interfaces, netInterface{
+			name:     strings.TrimSpace(name),
+			receive:  netCounters{bytes: values[0], packets: values[1], errors: values[2], dropped: values[3]},
+			transmit: netCounters{bytes: values[4], packets: values[5], errors: values[6], dropped: values[7]},
+		})
+		return nil
+	})
+	return interfaces, err
+}
+
+// diskStat holds the /proc/diskstats counters of one block device
+type diskStat struct {
+	name                   string
+	readOps, readSectors   int64
+	writeOps, writeSectors int64
+}
+
+// readDiskstats parses /proc/diskstats. Loop and RAM devices and the partitions of listed
+// disks are skipped: they mirror I/O already counted on the backing disk or never touch
+// one.
+func (m *HostMetrics) readDiskstats() ([]diskStat, error) {
+	var disks []diskStat
+	listed := make(map[string]bool)
+	err := m.scanLines("diskstats", func(line string) error {
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			return nil
+		}
+		name := fields[2]
+		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
+			return nil
+		}
+		values, err := parseInts(fields[3], fields[5], fields[7], fields[9])
+		if err != nil {
+			return fmt.Errorf("malformed /proc/diskstats: %w", err)
+		}
+		disks = append(disks, diskStat{
+			name:         name,
+			readOps:      values[0],
+			readSectors:  values[1],
+			writeOps:     values[2],
+			writeSectors: values[3],
+		})
+		listed[name] = true
+		return nil
+	})
+	return slices.DeleteFunc(disks, func(disk diskStat) bool {
+		return listed[partitionParent(disk.name)]
+	}), err
+}
+
+// partitionParent returns the disk a device name would be a partition of under the
+// kernel's naming, sda for sda1 and nvme0n1 for nvme0n1p1, or "" if it cannot be one
+func partitionParent(name string) string {
+	parent := strings.TrimRight(name, "0123456789")
+	if parent == name {
+		return ""
+	}
+	// Disks whose names end in a digit separate the partition number with a "p"
+	if disk, ok := strings.CutSuffix(parent, "p"); ok && disk != "" && strings.ContainsAny(disk[len(disk)-1:], "0123456789") {
+		return disk
+	}
+	return parent
+}
+
+// scanLines calls fn for every line of the procfs file at name
+func (m *HostMetrics) scanLines(name string, fn func(line string) error) error {
+	file, err := os.Open(filepath.Join(m.root, name))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if err := fn(scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// parseInts parses decimal procfs fields
+func parseInts(fields ...string) ([]int64, error) {
+	values := make([]int64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
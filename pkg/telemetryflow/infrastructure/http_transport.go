@@ -0,0 +1,267 @@
+// Package infrastructure provides the HTTP transport used by the TelemetryFlow SDK HTTP exporters.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"golang.org/x/net/http/httpproxy"
+	"google.golang.org/protobuf/proto"
+)
+
+// httpClient returns the HTTP client of an exporter. The client is built from the
+// configured client or transport (or a proxy-aware clone of the default transport) and
+// wrapped for unix socket dialing, API version negotiation, dynamic headers, request
+// splitting and OTLP/JSON encoding as needed. The outermost transport reports each
+// request to the export tracking.
+func (f *OTLPExporterFactory) httpClient(signal domain.SignalType, endpoint *domain.EndpointURL) *http.Client {
+	negotiate := f.config.UseV2API() && !f.config.IsV2Only()
+	provider := f.config.HeaderProvider()
+
+	client := &http.Client{Timeout: f.config.SignalTimeout(signal)}
+	if configured := f.config.HTTPClient(); configured != nil {
+		copied := *configured // keep the caller's client untouched
+		client = &copied
+	}
+
+	roundTripper := f.baseTransport(endpoint, client.Transport)
+	if negotiate {
+		roundTripper = f.apiVersions.endpointTransport(endpoint, roundTripper)
+	}
+	if provider != nil {
+		roundTripper = &headerTransport{provider: provider, base: roundTripper}
+	}
+	encoding := f.config.HTTPEncoding()
+	if limit := f.config.RequestSizeLimit(domain.ProtocolHTTP); limit > 0 {
+		roundTripper = &splitTransport{base: roundTripper, limit: limit, signal: signal, encoding: encoding}
+	}
+	if encoding == domain.HTTPEncodingJSON {
+		// Encoded before splitting, so the size limit applies to the JSON bodies
+		roundTripper = &jsonTransport{base: roundTripper, signal: signal}
+	}
+	client.Transport = &exportCallTransport{base: roundTripper}
+	return client
+}
+
+// baseTransport returns the transport that sends export requests. Custom transports
+// are used as-is, with their own proxy settings (validation rejects WithProxy and
+// WithNoProxy alongside them), except that *http.Transport values are cloned to dial
+// unix sockets.
+func (f *OTLPExporterFactory) baseTransport(endpoint *domain.EndpointURL, clientTransport http.RoundTripper) http.RoundTripper {
+	base := f.config.HTTPTransport()
+	if base == nil {
+		base = clientTransport
+	}
+	if base != nil {
+		if transport, ok := base.(*http.Transport); ok && endpoint.IsUnix() {
+			transport = transport.Clone()
+			transport.DialContext = unixDialer(endpoint.SocketPath)
+			return transport
+		}
+		return base
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	switch {
+	case endpoint.IsUnix():
+		transport.Proxy = nil
+		transport.DialContext = unixDialer(endpoint.SocketPath)
+	case f.config.ProxyURL() != "" || len(f.config.NoProxy()) > 0:
+		transport.Proxy = f.proxyFunc()
+	}
+	return transport
+}
+
+// proxyFunc returns the proxy selection for export requests. The configured proxy
+// and NO_PROXY list override the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables.
+func (f *OTLPExporterFactory) proxyFunc() func(*http.Request) (*url.URL, error) {
+	cfg := httpproxy.FromEnvironment()
+	if proxyURL := f.config.ProxyURL(); proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return func(*http.Request) (*url.URL, error) {
+				return nil, fmt.Errorf("invalid proxy URL: %w", err)
+			}
+		}
+		if username, password := f.config.ProxyCredentials(); username != "" {
+			u.User = url.UserPassword(username, password)
+		}
+		cfg.HTTPProxy = u.String()
+		cfg.HTTPSProxy = u.String()
+	}
+	if noProxy := f.config.NoProxy(); len(noProxy) > 0 {
+		cfg.NoProxy = strings.Join(noProxy, ",")
+	}
+
+	proxy := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
+
+// unixDialer returns a dial function that connects to a unix domain socket
+func unixDialer(socketPath string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", socketPath)
+	}
+}
+
+// headerTransport adds the headers returned by a HeaderProvider to each request
+type headerTransport struct {
+	provider domain.HeaderProvider
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers, err := t.provider(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("header provider: %w", err)
+	}
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	clone := req.Clone(req.Context())
+	for key, value := range headers {
+		clone.Header.Set(key, value)
+	}
+	return t.base.RoundTrip(clone)
+}
+
+// jsonTransport re-encodes the protobuf requests of the OTLP/HTTP exporters as OTLP/JSON.
+// JSON responses are turned back into protobuf so the exporters still report partial
+// success.
+type jsonTransport struct {
+	base   http.RoundTripper
+	signal domain.SignalType
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *jsonTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.base.RoundTrip(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	gzipped := req.Header.Get("Content-Encoding") == "gzip"
+	msg, err := decodeRequestBody(t.signal, body, gzipped, domain.HTTPEncodingProtobuf)
+	if err != nil {
+		return nil, err
+	}
+	if body, err = encodeRequestBody(msg, gzipped, domain.HTTPEncodingJSON); err != nil {
+		return nil, err
+	}
+	clone := withBody(req, body)
+	clone.Header.Set("Content-Type", domain.HTTPEncodingJSON.ContentType())
+
+	resp, err := t.base.RoundTrip(clone)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 ||
+		!strings.HasPrefix(resp.Header.Get("Content-Type"), domain.HTTPEncodingJSON.ContentType()) {
+		return resp, err
+	}
+	return t.protobufResponse(resp)
+}
+
+// protobufResponse replaces an OTLP/JSON response body by its protobuf encoding.
+// Bodies that cannot be decoded are passed on as they are.
+func (t *jsonTransport) protobufResponse(resp *http.Response) (*http.Response, error) {
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return resp, nil
+	}
+
+	msg := newExportResponse(t.signal)
+	if err := UnmarshalOTLPJSON(data, msg); err != nil {
+		return resp, nil
+	}
+	encoded, err := proto.Marshal(msg)
+	if err != nil {
+		return resp, nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(encoded))
+	resp.ContentLength = int64(len(encoded))
+	resp.Header = resp.Header.Clone()
+	resp.Header.Set("Content-Type", domain.HTTPEncodingProtobuf.ContentType())
+	resp.Header.Set("Content-Length", strconv.Itoa(len(encoded)))
+	return resp, nil
+}
+
+// newExportResponse returns an empty OTLP export response of the signal
+func newExportResponse(signal domain.SignalType) proto.Message {
+	switch signal {
+	case domain.SignalMetrics:
+		return &colmetricspb.ExportMetricsServiceResponse{}
+	case domain.SignalLogs:
+		return &collogspb.ExportLogsServiceResponse{}
+	default:
+		return &coltracepb.ExportTraceServiceResponse{}
+	}
+}
+
+// exportCallTransport reports each request, its retry advice and credential rejections
+// to the export call that sent it, so retries can be counted and throttled, and rejected
+// credentials stop the exporter
+type exportCallTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *exportCallTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	countExportAttempt(ctx)
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil:
+		// Connection failures are worth retrying; an ended export context is not
+		adviseExportRetry(ctx, ctx.Err() == nil, 0)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		rejectExportCredentials(ctx, "HTTP "+resp.Status)
+	default:
+		retryable, throttle := httpRetryAdvice(resp.StatusCode, resp.Header)
+		adviseExportRetry(ctx, retryable, throttle)
+	}
+	return resp, err
+}
@@ -0,0 +1,226 @@
+// Package infrastructure provides the instruments behind metric commands for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// Instrument kinds of metric commands
+const (
+	instrumentGauge     = "gauge"
+	instrumentCounter   = "counter"
+	instrumentHistogram = "histogram"
+)
+
+// instrumentValue is a value to record on the instrument of its kind and name
+type instrumentValue struct {
+	kind       string
+	name       string
+	unit       string
+	attributes attribute.Set
+	value      float64
+	count      int64 // counter increment
+}
+
+// instrumentCache creates the instrument of each metric name once. Gauges keep the last
+// value of every attribute set and report it from a single callback, instead of
+// registering a callback per recorded value.
+//
+// Instruments are created without holding a lock of the cache: the meter takes the lock
+// of its pipelines, which collections hold while calling the gauge callbacks.
+type instrumentCache struct {
+	meter otelmetric.Meter
+
+	mu         sync.Mutex // guards the instruments, never held while calling the meter
+	counters   map[string]otelmetric.Int64Counter
+	histograms map[string]otelmetric.Float64Histogram
+	gauges     map[string]*gaugeRegistration
+
+	valuesMu    sync.Mutex // guards the gauge values, read by the gauge callbacks
+	gaugeValues map[string]map[attribute.Distinct]gaugeValue
+}
+
+// gaugeRegistration registers the observable gauge of a name once
+type gaugeRegistration struct {
+	once sync.Once
+	err  error
+}
+
+// gaugeValue is the last value of a gauge for one attribute set
+type gaugeValue struct {
+	attributes attribute.Set
+	value      float64
+}
+
+// newInstrumentCache returns an empty cache creating instruments from meter
+func newInstrumentCache(meter otelmetric.Meter) *instrumentCache {
+	return &instrumentCache{
+		meter:       meter,
+		counters:    make(map[string]otelmetric.Int64Counter),
+		histograms:  make(map[string]otelmetric.Float64Histogram),
+		gauges:      make(map[string]*gaugeRegistration),
+		gaugeValues: make(map[string]map[attribute.Distinct]gaugeValue),
+	}
+}
+
+// record records values in two passes. The instruments of every value are resolved or
+// created first, without holding a lock of the cache; the gauge values are then written
+// under a single acquisition of valuesMu, so a collection reports either none or all of
+// the values recorded for a gauge. It returns the error of each value, nil for the
+// values recorded.
+func (c *instrumentCache) record(ctx context.Context, values []instrumentValue) []error {
+	errs := make([]error, len(values))
+	counters := make([]otelmetric.Int64Counter, len(values))
+	histograms := make([]otelmetric.Float64Histogram, len(values))
+	gauges := 0
+	for i, v := range values {
+		var err error
+		switch v.kind {
+		case instrumentCounter:
+			if counters[i], err = c.counter(v.name); err != nil {
+				errs[i] = fmt.Errorf("failed to create counter: %w", err)
+			}
+		case instrumentHistogram:
+			if histograms[i], err = c.histogram(v.name, v.unit); err != nil {
+				errs[i] = fmt.Errorf("failed to create histogram: %w", err)
+			}
+		case instrumentGauge:
+			if err = c.gauge(v.name, v.unit); err != nil {
+				errs[i] = fmt.Errorf("failed to create gauge: %w", err)
+			} else {
+				gauges++
+			}
+		default:
+			errs[i] = fmt.Errorf("unsupported metric kind: %s (use gauge, counter or histogram)", v.kind)
+		}
+	}
+
+	if gauges > 0 {
+		c.valuesMu.Lock()
+		for i, v := range values {
+			if v.kind != instrumentGauge || errs[i] != nil {
+				continue
+			}
+			gaugeValues, ok := c.gaugeValues[v.name]
+			if !ok {
+				gaugeValues = make(map[attribute.Distinct]gaugeValue)
+				c.gaugeValues[v.name] = gaugeValues
+			}
+			gaugeValues[v.attributes.Equivalent()] = gaugeValue{attributes: v.attributes, value: v.value}
+		}
+		c.valuesMu.Unlock()
+	}
+
+	for i, v := range values {
+		switch {
+		case counters[i] != nil:
+			counters[i].Add(ctx, v.count, otelmetric.WithAttributeSet(v.attributes))
+		case histograms[i] != nil:
+			histograms[i].Record(ctx, v.value, otelmetric.WithAttributeSet(v.attributes))
+		}
+	}
+	return errs
+}
+
+// counter returns the counter of name, creating it if needed
+func (c *instrumentCache) counter(name string) (otelmetric.Int64Counter, error) {
+	c.mu.Lock()
+	counter, ok := c.counters[name]
+	c.mu.Unlock()
+	if ok {
+		return counter, nil
+	}
+
+	counter, err := c.meter.Int64Counter(
+		name,
+		otelmetric.WithDescription(fmt.Sprintf("Counter: %s", name)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.counters[name]; ok {
+		return existing, nil
+	}
+	c.counters[name] = counter
+	return counter, nil
+}
+
+// histogram returns the histogram of name, creating it if needed
+func (c *instrumentCache) histogram(name, unit string) (otelmetric.Float64Histogram, error) {
+	c.mu.Lock()
+	histogram, ok := c.histograms[name]
+	c.mu.Unlock()
+	if ok {
+		return histogram, nil
+	}
+
+	histogram, err := c.meter.Float64Histogram(
+		name,
+		otelmetric.WithUnit(unit),
+		otelmetric.WithDescription(fmt.Sprintf("Histogram: %s", name)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.histograms[name]; ok {
+		return existing, nil
+	}
+	c.histograms[name] = histogram
+	return histogram, nil
+}
+
+// gauge registers the observable gauge of name once, reporting the values held for it
+func (c *instrumentCache) gauge(name, unit string) error {
+	c.mu.Lock()
+	registration, ok := c.gauges[name]
+	if !ok {
+		registration = &gaugeRegistration{}
+		c.gauges[name] = registration
+	}
+	c.mu.Unlock()
+
+	registration.once.Do(func() {
+		_, registration.err = c.meter.Float64ObservableGauge(
+			name,
+			otelmetric.WithUnit(unit),
+			otelmetric.WithDescription(fmt.Sprintf("Metric: %s", name)),
+			otelmetric.WithFloat64Callback(func(_ context.Context, o otelmetric.Float64Observer) error {
+				c.valuesMu.Lock()
+				defer c.valuesMu.Unlock()
+				for _, v := range c.gaugeValues[name] {
+					o.Observe(v.value, otelmetric.WithAttributeSet(v.attributes))
+				}
+				return nil
+			}),
+		)
+	})
+	return registration.err
+}
@@ -0,0 +1,259 @@
+// Package infrastructure provides log severity thresholds, sampling and flood suppression for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// maxLogGroups is the number of message templates tracked before the groups whose
+// windows have ended are forgotten
+const maxLogGroups = 4096
+
+// LogSummary reports the records of a message template suppressed during a
+// de-duplication window
+type LogSummary struct {
+	Severity     otellog.Severity
+	SeverityText string
+	Logger       string
+	Template     string
+	Suppressed   int
+}
+
+// LogFilter drops log records below the minimum severity of their logger, samples them
+// by message template and suppresses repeats within a de-duplication window, as set by
+// domain.LogFilterConfig. Its settings can be replaced while records are logged.
+type LogFilter struct {
+	summarize func(LogSummary) // emits the summary of a de-duplication window
+
+	mu      sync.Mutex
+	config  domain.LogFilterConfig
+	minimum otellog.Severity
+	loggers map[string]otellog.Severity
+	groups  map[logGroupKey]*logGroup
+	closed  bool
+}
+
+// logGroupKey identifies the records sampled and de-duplicated together
+type logGroupKey struct {
+	severity otellog.Severity
+	logger   string
+	template string
+}
+
+// logGroup is the sampling and de-duplication state of a message template
+type logGroup struct {
+	severityText string
+	sampleStart  time.Time
+	sampled      int
+	dedupEnd     time.Time
+	suppressed   int
+	timer        *time.Timer
+}
+
+// NewLogFilter returns a filter applying config. summarize is called with the summary
+// of each de-duplication window that suppressed records, when the window ends.
+func NewLogFilter(config domain.LogFilterConfig, summarize func(LogSummary)) *LogFilter {
+	f := &LogFilter{summarize: summarize, groups: make(map[logGroupKey]*logGroup)}
+	f.Update(config)
+	return f
+}
+
+// Config returns the settings in effect
+func (f *LogFilter) Config() domain.LogFilterConfig {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config.Clone()
+}
+
+// Update replaces the settings. Windows in progress end at the time they were given.
+func (f *LogFilter) Update(config domain.LogFilterConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config.Clone()
+	f.minimum = otellog.SeverityUndefined
+	if config.MinSeverity != "" {
+		f.minimum = parseSeverity(config.MinSeverity)
+	}
+	f.loggers = make(map[string]otellog.Severity, len(config.LoggerSeverities))
+	for logger, severity := range config.LoggerSeverities {
+		f.loggers[logger] = parseSeverity(severity)
+	}
+}
+
+// Allow reports whether a record is emitted, counting it for sampling and
+// de-duplication
+func (f *LogFilter) Allow(severity otellog.Severity, severityText, logger, message string, now time.Time) bool {
+	allowed, summary := f.allow(severity, severityText, logger, message, now)
+	if summary != nil {
+		f.summarize(*summary)
+	}
+	return allowed
+}
+
+func (f *LogFilter) allow(severity otellog.Severity, severityText, logger, message string, now time.Time) (bool, *LogSummary) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed || !f.config.Enabled() {
+		return true, nil
+	}
+	if minimum := f.threshold(logger); severity != otellog.SeverityUndefined && severity < minimum {
+		return false, nil
+	}
+	if f.config.SamplingInterval <= 0 && f.config.DedupWindow <= 0 {
+		return true, nil
+	}
+
+	var summary *LogSummary
+	key := logGroupKey{severity: severity, logger: logger, template: messageTemplate(message)}
+	g, ok := f.groups[key]
+	if !ok {
+		if len(f.groups) >= maxLogGroups {
+			f.forget(now)
+		}
+		g = &logGroup{severityText: severityText}
+		f.groups[key] = g
+	}
+
+	if window := f.config.DedupWindow; window > 0 {
+		if now.Before(g.dedupEnd) {
+			g.suppressed++
+			if g.suppressed == 1 {
+				g.timer = time.AfterFunc(g.dedupEnd.Sub(now), func() { f.endWindow(key, g) })
+			}
+			return false, nil
+		}
+		// The previous window ended, but its timer has not fired yet
+		if g.suppressed > 0 {
+			summary = f.takeSummary(key, g)
+		}
+		g.dedupEnd = now.Add(window)
+	}
+
+	if interval := f.config.SamplingInterval; interval > 0 {
+		if !now.Before(g.sampleStart.Add(interval)) {
+			g.sampleStart = now
+			g.sampled = 0
+		}
+		g.sampled++
+		if g.sampled > f.config.SamplingInitial {
+			thereafter := f.config.SamplingThereafter
+			return thereafter > 0 && (g.sampled-f.config.SamplingInitial)%thereafter == 0, summary
+		}
+	}
+	return true, summary
+}
+
+// threshold returns the minimum severity of logger: that of its longest configured
+// prefix, or the filter's minimum
+func (f *LogFilter) threshold(logger string) otellog.Severity {
+	for name := logger; name != ""; {
+		if severity, ok := f.loggers[name]; ok {
+			return severity
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return f.minimum
+}
+
+// endWindow emits the summary of a de-duplication window when it ends
+func (f *LogFilter) endWindow(key logGroupKey, g *logGroup) {
+	f.mu.Lock()
+	if f.groups[key] != g || g.suppressed == 0 {
+		f.mu.Unlock()
+		return
+	}
+	summary := f.takeSummary(key, g)
+	f.mu.Unlock()
+	f.summarize(*summary)
+}
+
+// takeSummary returns the summary of the records suppressed in g and resets the count
+func (f *LogFilter) takeSummary(key logGroupKey, g *logGroup) *LogSummary {
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	summary := &LogSummary{
+		Severity:     key.severity,
+		SeverityText: g.severityText,
+		Logger:       key.logger,
+		Template:     key.template,
+		Suppressed:   g.suppressed,
+	}
+	g.suppressed = 0
+	return summary
+}
+
+// forget removes the groups with no window in progress
+func (f *LogFilter) forget(now time.Time) {
+	for key, g := range f.groups {
+		if g.suppressed == 0 && !now.Before(g.dedupEnd) && !now.Before(g.sampleStart.Add(f.config.SamplingInterval)) {
+			delete(f.groups, key)
+		}
+	}
+}
+
+// Close stops the filter and emits the summaries of the windows in progress. Records
+// are no longer filtered afterwards.
+func (f *LogFilter) Close() {
+	f.mu.Lock()
+	var summaries []*LogSummary
+	for key, g := range f.groups {
+		if g.suppressed > 0 {
+			summaries = append(summaries, f.takeSummary(key, g))
+		}
+	}
+	f.groups = make(map[logGroupKey]*logGroup)
+	f.closed = true
+	f.mu.Unlock()
+
+	for _, summary := range summaries {
+		f.summarize(*summary)
+	}
+}
+
+// messageTemplate returns message with every run of digits replaced by "#", so that
+// messages differing only in numbers, IDs or durations are grouped together
+func messageTemplate(message string) string {
+	var b strings.Builder
+	digits := false
+	for _, r := range message {
+		if r >= '0' && r <= '9' {
+			if !digits {
+				b.WriteByte('#')
+			}
+			digits = true
+			continue
+		}
+		digits = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
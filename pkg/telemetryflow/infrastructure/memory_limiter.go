@@ -0,0 +1,326 @@
+// Package infrastructure provides the memory limiter guarding the data buffered by the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// heapObjectsMetric is the runtime/metrics key of the memory held by live and
+// not yet swept heap objects
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// Estimated fixed cost of a buffered item, besides its names, attributes and body
+const (
+	spanOverhead   = 400
+	eventOverhead  = 64
+	linkOverhead   = 64
+	recordOverhead = 200
+)
+
+// memoryLevel is how far the memory limiter is above its limits
+type memoryLevel int
+
+const (
+	memoryNormal memoryLevel = iota // all data is kept
+	memorySoft                      // traces are sampled less and low severity logs dropped
+	memoryHard                      // new spans and log records are dropped
+)
+
+func (l memoryLevel) String() string {
+	switch l {
+	case memorySoft:
+		return "soft"
+	case memoryHard:
+		return "hard"
+	default:
+		return "normal"
+	}
+}
+
+// MemoryLimiter bounds the memory held by spans and log records waiting in the SDK's
+// batch queues and circuit breaker buffers, and optionally the Go heap. Buffered memory
+// is estimated from the size of each item when it is admitted and released when its
+// export ends. All methods are no-ops on a nil limiter.
+type MemoryLimiter struct {
+	config      domain.MemoryLimiterConfig
+	minSeverity otellog.Severity
+
+	buffered atomic.Int64 // estimated bytes of buffered items
+	dropped  atomic.Int64 // items dropped because of the limits
+
+	heapMu     sync.Mutex
+	heapSample []metrics.Sample
+	heapReadAt time.Time
+	heapBytes  atomic.Int64
+}
+
+// NewMemoryLimiter creates the memory limiter configured in config, or returns nil if
+// no limit is set
+func NewMemoryLimiter(config domain.MemoryLimiterConfig) *MemoryLimiter {
+	if !config.Enabled() {
+		return nil
+	}
+	m := &MemoryLimiter{config: config}
+	if config.MinSeverity != "" {
+		m.minSeverity = parseSeverity(strings.ToLower(config.MinSeverity))
+	}
+	if config.HeapSoftLimit > 0 || config.HeapHardLimit > 0 {
+		m.heapSample = []metrics.Sample{{Name: heapObjectsMetric}}
+	}
+	return m
+}
+
+// level returns the current limiter level
+func (m *MemoryLimiter) level() memoryLevel {
+	if m == nil {
+		return memoryNormal
+	}
+	buffered, heap := m.buffered.Load(), m.heap()
+	switch {
+	case exceeds(buffered, m.config.HardLimit) || exceeds(heap, m.config.HeapHardLimit):
+		return memoryHard
+	case exceeds(buffered, m.config.SoftLimit) || exceeds(heap, m.config.HeapSoftLimit):
+		return memorySoft
+	default:
+		return memoryNormal
+	}
+}
+
+// exceeds reports whether usage is at or above limit, ignoring unset limits
+func exceeds(usage, limit int64) bool {
+	return limit > 0 && usage >= limit
+}
+
+// heap returns the Go heap in use, read again once the check interval has passed, or 0
+// unless heap limits are set
+func (m *MemoryLimiter) heap() int64 {
+	if m.heapSample == nil {
+		return 0
+	}
+	m.heapMu.Lock()
+	defer m.heapMu.Unlock()
+	if now := time.Now(); now.Sub(m.heapReadAt) >= m.config.CheckInterval {
+		metrics.Read(m.heapSample)
+		if m.heapSample[0].Value.Kind() == metrics.KindUint64 {
+			m.heapBytes.Store(int64(m.heapSample[0].Value.Uint64()))
+		}
+		m.heapReadAt = now
+	}
+	return m.heapBytes.Load()
+}
+
+// admit reserves size bytes for a new item, or returns false if the item must be
+// dropped because the limiter is above a hard limit or the item would take it there
+func (m *MemoryLimiter) admit(size int64) bool {
+	if m == nil {
+		return true
+	}
+	if m.level() == memoryHard || exceeds(m.buffered.Load()+size, m.config.HardLimit) {
+		return false
+	}
+	m.buffered.Add(size)
+	return true
+}
+
+// hold accounts for size bytes kept regardless of the limits, such as data put back
+// into a breaker buffer
+func (m *MemoryLimiter) hold(size int64) {
+	if m != nil {
+		m.buffered.Add(size)
+	}
+}
+
+// release returns the bytes of items that left the SDK's buffers
+func (m *MemoryLimiter) release(size int64) {
+	if m != nil {
+		m.buffered.Add(-size)
+	}
+}
+
+// countDropped counts items dropped because of the limits
+func (m *MemoryLimiter) countDropped(items int) {
+	if m != nil {
+		m.dropped.Add(int64(items))
+	}
+}
+
+// keepsSeverity reports whether log records of severity are kept at the current level.
+// Records without a severity are kept until the hard limit.
+func (m *MemoryLimiter) keepsSeverity(severity otellog.Severity) bool {
+	if m == nil || m.minSeverity == otellog.SeverityUndefined || severity == otellog.SeverityUndefined {
+		return true
+	}
+	return severity >= m.minSeverity || m.level() == memoryNormal
+}
+
+// sampler returns base, sampling less above the soft limit and nothing above the hard limit
+func (m *MemoryLimiter) sampler(base sdktrace.Sampler) sdktrace.Sampler {
+	if m == nil {
+		return base
+	}
+	return &limitedSampler{
+		limiter: m,
+		base:    base,
+		reduced: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(m.config.SamplingRatio)),
+	}
+}
+
+// status returns the limiter state for the status query
+func (m *MemoryLimiter) status() *application.MemoryStatus {
+	if m == nil {
+		return nil
+	}
+	return &application.MemoryStatus{
+		State:         m.level().String(),
+		BufferedBytes: m.buffered.Load(),
+		HeapBytes:     m.heap(),
+		SoftLimit:     m.config.SoftLimit,
+		HardLimit:     m.config.HardLimit,
+		HeapSoftLimit: m.config.HeapSoftLimit,
+		HeapHardLimit: m.config.HeapHardLimit,
+		Dropped:       m.dropped.Load(),
+	}
+}
+
+// spansSize returns the estimated memory of spans, or 0 on a nil limiter
+func (m *MemoryLimiter) spansSize(spans ...sdktrace.ReadOnlySpan) int64 {
+	if m == nil {
+		return 0
+	}
+	var size int64
+	for _, s := range spans {
+		size += spanOverhead + int64(len(s.Name())) + attributesSize(s.Attributes())
+		for _, event := range s.Events() {
+			size += eventOverhead + int64(len(event.Name)) + attributesSize(event.Attributes)
+		}
+		for _, link := range s.Links() {
+			size += linkOverhead + attributesSize(link.Attributes)
+		}
+	}
+	return size
+}
+
+// recordSize returns the estimated memory of a log record, or 0 on a nil limiter
+func (m *MemoryLimiter) recordSize(r *sdklog.Record) int64 {
+	if m == nil {
+		return 0
+	}
+	size := recordOverhead + int64(len(r.SeverityText())) + logValueSize(r.Body())
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		size += int64(len(kv.Key)) + logValueSize(kv.Value)
+		return true
+	})
+	return size
+}
+
+// recordsSize returns the estimated memory of log records, or 0 on a nil limiter
+func (m *MemoryLimiter) recordsSize(records []sdklog.Record) int64 {
+	var size int64
+	for i := range records {
+		size += m.recordSize(&records[i])
+	}
+	return size
+}
+
+// attributesSize returns the estimated memory of span attributes
+func attributesSize(attrs []attribute.KeyValue) int64 {
+	var size int64
+	for _, kv := range attrs {
+		size += int64(len(kv.Key)) + 16
+		switch kv.Value.Type() {
+		case attribute.STRING:
+			size += int64(len(kv.Value.AsString()))
+		case attribute.STRINGSLICE:
+			for _, s := range kv.Value.AsStringSlice() {
+				size += int64(len(s)) + 16
+			}
+		case attribute.BOOLSLICE:
+			size += int64(len(kv.Value.AsBoolSlice()))
+		case attribute.INT64SLICE:
+			size += 8 * int64(len(kv.Value.AsInt64Slice()))
+		case attribute.FLOAT64SLICE:
+			size += 8 * int64(len(kv.Value.AsFloat64Slice()))
+		}
+	}
+	return size
+}
+
+// logValueSize returns the estimated memory of a log value
+func logValueSize(v otellog.Value) int64 {
+	size := int64(16)
+	switch v.Kind() {
+	case otellog.KindString:
+		size += int64(len(v.AsString()))
+	case otellog.KindBytes:
+		size += int64(len(v.AsBytes()))
+	case otellog.KindSlice:
+		for _, item := range v.AsSlice() {
+			size += logValueSize(item)
+		}
+	case otellog.KindMap:
+		for _, kv := range v.AsMap() {
+			size += int64(len(kv.Key)) + logValueSize(kv.Value)
+		}
+	}
+	return size
+}
+
+// limitedSampler lowers trace sampling while the memory limiter is above a limit
+type limitedSampler struct {
+	limiter *MemoryLimiter
+	base    sdktrace.Sampler
+	reduced sdktrace.Sampler
+}
+
+func (s *limitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	switch s.limiter.level() {
+	case memoryHard:
+		s.limiter.countDropped(1)
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	case memorySoft:
+		result := s.reduced.ShouldSample(p)
+		if result.Decision == sdktrace.Drop && s.base.ShouldSample(p).Decision != sdktrace.Drop {
+			s.limiter.countDropped(1)
+		}
+		return result
+	default:
+		return s.base.ShouldSample(p)
+	}
+}
+
+func (s *limitedSampler) Description() string {
+	return "MemoryLimited{" + s.base.Description() + "}"
+}
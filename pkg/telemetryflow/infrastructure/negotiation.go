@@ -0,0 +1,196 @@
+// Package infrastructure provides TFO API version negotiation for the TelemetryFlow SDK HTTP exporters.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// TFO API versions
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+)
+
+// apiVersionHeader announces the TFO API version to the collector (aligned with tfoexporter)
+const apiVersionHeader = "X-TelemetryFlow-API-Version"
+
+// APIVersionNegotiator remembers which TFO API version each collector endpoint supports.
+// HTTP exports start on v2 paths and fall back to v1 once when a collector that has
+// never accepted a v2 export replies 404 Not Found or 501 Not Implemented.
+// Fallback is disabled in v2-only mode.
+type APIVersionNegotiator struct {
+	defaultVersion string
+	v2Only         bool
+	versions       map[string]string // negotiationKey -> negotiated version
+	mu             sync.RWMutex
+}
+
+// NewAPIVersionNegotiator creates a negotiator starting from the configured API version
+func NewAPIVersionNegotiator(useV2API, v2Only bool) *APIVersionNegotiator {
+	defaultVersion := APIVersionV1
+	if useV2API || v2Only {
+		defaultVersion = APIVersionV2
+	}
+	return &APIVersionNegotiator{
+		defaultVersion: defaultVersion,
+		v2Only:         v2Only,
+		versions:       make(map[string]string),
+	}
+}
+
+// Version returns the API version used for an endpoint (host:port, URL or unix socket)
+func (n *APIVersionNegotiator) Version(endpoint string) string {
+	if parsed, err := domain.ParseEndpoint(endpoint); err == nil {
+		endpoint = negotiationKey(parsed)
+	}
+	return n.version(endpoint)
+}
+
+// version returns the API version used for a negotiation key
+func (n *APIVersionNegotiator) version(endpoint string) string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if version, ok := n.versions[endpoint]; ok {
+		return version
+	}
+	return n.defaultVersion
+}
+
+// Versions returns the API versions negotiated so far, keyed by host and base path
+// (e.g. gateway.example.com/otlp), or by unix:// socket URL
+func (n *APIVersionNegotiator) Versions() map[string]string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	versions := make(map[string]string, len(n.versions))
+	for endpoint, version := range n.versions {
+		versions[endpoint] = version
+	}
+	return versions
+}
+
+func (n *APIVersionNegotiator) negotiated(endpoint string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	_, ok := n.versions[endpoint]
+	return ok
+}
+
+func (n *APIVersionNegotiator) setVersion(endpoint, version string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.versions[endpoint]; !ok {
+		n.versions[endpoint] = version
+	}
+}
+
+// Transport wraps an HTTP transport so that requests follow the negotiated API version.
+// The version is remembered per request host and base path.
+func (n *APIVersionNegotiator) Transport(base http.RoundTripper) http.RoundTripper {
+	return &negotiatingTransport{negotiator: n, base: base}
+}
+
+// endpointTransport is Transport for requests to a single endpoint. Requests over a
+// unix socket all carry the same host, so only the endpoint tells sockets apart.
+func (n *APIVersionNegotiator) endpointTransport(endpoint *domain.EndpointURL, base http.RoundTripper) http.RoundTripper {
+	return &negotiatingTransport{negotiator: n, base: base, endpoint: negotiationKey(endpoint)}
+}
+
+// negotiationKey identifies a collector by its socket, or by its host and base path, so
+// gateways serving several collectors under one host negotiate separately
+func negotiationKey(endpoint *domain.EndpointURL) string {
+	if endpoint.IsUnix() {
+		return endpoint.String()
+	}
+	return endpoint.Host + endpoint.BasePath
+}
+
+// negotiatingTransport rewrites v2 export paths to v1 once an endpoint is known to lack v2
+type negotiatingTransport struct {
+	negotiator *APIVersionNegotiator
+	base       http.RoundTripper
+	endpoint   string // negotiation key, or empty to derive it from each request
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *negotiatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	v1, ok := v1Path(req.URL.Path)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	endpoint := t.endpoint
+	if endpoint == "" {
+		endpoint = req.URL.Host + req.URL.Path[:strings.LastIndex(req.URL.Path, "/v2/")]
+	}
+	if t.negotiator.version(endpoint) == APIVersionV1 {
+		return t.base.RoundTrip(downgradeRequest(req, v1, req.Body))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.negotiator.v2Only || t.negotiator.negotiated(endpoint) {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode < 300:
+		t.negotiator.setVersion(endpoint, APIVersionV2)
+		return resp, nil
+	case resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusNotImplemented:
+		return resp, nil
+	case req.GetBody == nil:
+		// The payload cannot be replayed; fall back for the next export
+		t.negotiator.setVersion(endpoint, APIVersionV1)
+		return resp, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	t.negotiator.setVersion(endpoint, APIVersionV1)
+	return t.base.RoundTrip(downgradeRequest(req, v1, body))
+}
+
+// v1Path returns the v1 equivalent of a v2 export path
+func v1Path(path string) (string, bool) {
+	i := strings.LastIndex(path, "/v2/")
+	if i < 0 {
+		return "", false
+	}
+	return path[:i] + "/v1/" + path[i+len("/v2/"):], true
+}
+
+// downgradeRequest clones req for the v1 path and announces v1 in the API version header
+func downgradeRequest(req *http.Request, path string, body io.ReadCloser) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.URL.Path = path
+	clone.URL.RawPath = ""
+	clone.Body = body
+	clone.Header.Set(apiVersionHeader, APIVersionV1)
+	return clone
+}
@@ -0,0 +1,126 @@
+// Package infrastructure provides OTLP/JSON encoding for the TelemetryFlow SDK file exporter and replay.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLP/JSON differs from the canonical protobuf JSON mapping: trace and span IDs are
+// hex strings instead of base64, and enums are encoded as integers.
+var (
+	otlpIDFields   = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+	otlpEnumFields = map[string]map[string]int32{
+		"kind":                   tracepb.Span_SpanKind_value,
+		"code":                   tracepb.Status_StatusCode_value,
+		"severityNumber":         logspb.SeverityNumber_value,
+		"aggregationTemporality": metricspb.AggregationTemporality_value,
+	}
+)
+
+// MarshalOTLPJSON encodes an OTLP export request as a single line of OTLP/JSON
+func MarshalOTLPJSON(msg proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return rewriteOTLPJSON(data, func(key string, value string) (interface{}, error) {
+		if otlpIDFields[key] {
+			id, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			return hex.EncodeToString(id), nil
+		}
+		if values, ok := otlpEnumFields[key]; ok {
+			if number, ok := values[value]; ok {
+				return number, nil
+			}
+		}
+		return value, nil
+	})
+}
+
+// UnmarshalOTLPJSON decodes one line of OTLP/JSON into an OTLP export request
+func UnmarshalOTLPJSON(data []byte, msg proto.Message) error {
+	data, err := rewriteOTLPJSON(data, func(key string, value string) (interface{}, error) {
+		if otlpIDFields[key] {
+			id, err := hex.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			return base64.StdEncoding.EncodeToString(id), nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		return err
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+// rewriteOTLPJSON applies fn to every string value of an object field
+func rewriteOTLPJSON(data []byte, fn func(key, value string) (interface{}, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if err := walkOTLPJSON(doc, fn); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+func walkOTLPJSON(node interface{}, fn func(key, value string) (interface{}, error)) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, value := range n {
+			if s, ok := value.(string); ok {
+				rewritten, err := fn(key, s)
+				if err != nil {
+					return err
+				}
+				n[key] = rewritten
+				continue
+			}
+			if err := walkOTLPJSON(value, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, value := range n {
+			if err := walkOTLPJSON(value, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package infrastructure provides the Prometheus scrape endpoint for the TelemetryFlow SDK metrics.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// prometheusMetricsPath is where the listening endpoint serves metrics
+const prometheusMetricsPath = "/metrics"
+
+// PrometheusReader is a pull-based metric reader that serves the SDK metrics in the
+// Prometheus exposition format. Metric names and units follow the OpenTelemetry to
+// Prometheus conventions (e.g. http.server.duration in s becomes
+// http_server_duration_seconds) and resource attributes are exposed as target_info.
+type PrometheusReader struct {
+	reader   *otelprom.Exporter
+	handler  http.Handler
+	server   *http.Server
+	listener net.Listener
+}
+
+// CreatePrometheusReader creates a Prometheus reader with its own registry, so several
+// clients in one process do not collide on the default registerer
+func (f *OTLPExporterFactory) CreatePrometheusReader(producers ...sdkmetric.Producer) (*PrometheusReader, error) {
+	registry := prometheus.NewRegistry()
+	opts := []otelprom.Option{otelprom.WithRegisterer(registry)}
+	for _, producer := range producers {
+		opts = append(opts, otelprom.WithProducer(producer))
+	}
+	reader, err := otelprom.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &PrometheusReader{
+		reader:  reader,
+		handler: promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	}, nil
+}
+
+// Reader returns the metric reader to register on the MeterProvider.
+func (p *PrometheusReader) Reader() sdkmetric.Reader { return p.reader }
+
+// Handler returns the HTTP handler serving the metrics, for mounting on an existing server.
+func (p *PrometheusReader) Handler() http.Handler { return p.handler }
+
+// Addr returns the address the endpoint listens on, or nil if it does not listen.
+func (p *PrometheusReader) Addr() net.Addr {
+	if p.listener == nil {
+		return nil
+	}
+	return p.listener.Addr()
+}
+
+// Listen serves the metrics on addr at /metrics until Shutdown
+func (p *PrometheusReader) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(prometheusMetricsPath, p.handler)
+	p.listener = listener
+	p.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = p.server.Serve(listener) }()
+	return nil
+}
+
+// Shutdown stops the listening endpoint, if any. The reader itself is shut down with the MeterProvider.
+func (p *PrometheusReader) Shutdown(ctx context.Context) error {
+	if p.server == nil {
+		return nil
+	}
+	if err := p.server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+// Package infrastructure provides replay of OTLP/JSON files for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip" // registers the gzip compressor
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxReplayLineSize bounds a single OTLP/JSON line (one export request)
+const maxReplayLineSize = 64 << 20
+
+// ReplayResult summarizes a replayed file
+type ReplayResult struct {
+	Path     string
+	Signal   domain.SignalType
+	Requests int // export requests sent
+}
+
+// Replayer sends OTLP/JSON files written by the file exporter to the configured
+// endpoints, using the same credentials, headers, protocol and transport settings
+// as the regular exporters.
+type Replayer struct {
+	factory *OTLPExporterFactory
+	conns   map[string]*grpc.ClientConn
+}
+
+// NewReplayer creates a replayer for the given configuration
+func NewReplayer(config *domain.TelemetryConfig) *Replayer {
+	return &Replayer{
+		factory: NewOTLPExporterFactory(config),
+		conns:   make(map[string]*grpc.ClientConn),
+	}
+}
+
+// ReplayPaths replays files and directories. Directories are scanned for .jsonl and
+// .jsonl.gz files, oldest rotation first. Replay stops at the first failing file and
+// keeps no checkpoint: the last result is that file, and its Requests counts the
+// requests delivered before the failure. Replaying the same paths again resends
+// everything, so move or delete the files that were replayed completely first.
+func (r *Replayer) ReplayPaths(ctx context.Context, paths ...string) ([]ReplayResult, error) {
+	files, err := ReplayFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ReplayResult, 0, len(files))
+	for _, path := range files {
+		result, err := r.ReplayFile(ctx, path)
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// ReplayFile sends every export request in an OTLP/JSON lines file. The signal is
+// taken from the file name (traces, metrics or logs).
+func (r *Replayer) ReplayFile(ctx context.Context, path string) (ReplayResult, error) {
+	result := ReplayResult{Path: path}
+	signal, err := replaySignal(path)
+	if err != nil {
+		return result, err
+	}
+	result.Signal = signal
+
+	file, err := os.Open(path)
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", path, err)
+		}
+		defer func() { _ = zr.Close() }()
+		reader = zr
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxReplayLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		msg := newExportRequest(signal)
+		if err := UnmarshalOTLPJSON(data, msg); err != nil {
+			return result, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if err := r.send(ctx, signal, msg); err != nil {
+			return result, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		result.Requests++
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
+
+// Close releases the gRPC connections opened during replay
+func (r *Replayer) Close() error {
+	var errs []error
+	for _, conn := range r.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// ReplayFiles expands paths into the OTLP/JSON files to replay, oldest rotation first
+func ReplayFiles(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var found []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && (strings.HasSuffix(name, otlpJSONExt) || strings.HasSuffix(name, otlpJSONExt+".gz")) {
+				found = append(found, filepath.Join(path, name))
+			}
+		}
+		// Rotated files carry a sortable timestamp; the active file sorts after them
+		sort.Slice(found, func(i, j int) bool { return replayOrder(found[i]) < replayOrder(found[j]) })
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+// replayOrder sorts rotated files by timestamp and places the active file last per signal
+func replayOrder(path string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".gz"), otlpJSONExt)
+	if !strings.Contains(name, "-") {
+		return name + "-~" // '~' sorts after the timestamp digits
+	}
+	return name
+}
+
+// replaySignal derives the signal from a file name such as traces-20260101T000000.jsonl.gz
+func replaySignal(path string) (domain.SignalType, error) {
+	name := filepath.Base(path)
+	if i := strings.IndexAny(name, "-."); i >= 0 {
+		name = name[:i]
+	}
+	switch signal := domain.SignalType(name); signal {
+	case domain.SignalTraces, domain.SignalMetrics, domain.SignalLogs:
+		return signal, nil
+	default:
+		return "", fmt.Errorf("%s: cannot tell the signal from the file name (expected traces, metrics or logs)", path)
+	}
+}
+
+func newExportRequest(signal domain.SignalType) proto.Message {
+	switch signal {
+	case domain.SignalMetrics:
+		return &colmetricspb.ExportMetricsServiceRequest{}
+	case domain.SignalLogs:
+		return &collogspb.ExportLogsServiceRequest{}
+	default:
+		return &coltracepb.ExportTraceServiceRequest{}
+	}
+}
+
+// send exports a request to the signal's endpoints, trying failover endpoints in order
+func (r *Replayer) send(ctx context.Context, signal domain.SignalType, msg proto.Message) error {
+	config := r.factory.config
+	var errs []error
+	for _, endpoint := range config.SignalEndpoints(signal) {
+		target, err := domain.ParseEndpoint(endpoint)
+		if err != nil {
+			return err
+		}
+		insecure := config.EndpointInsecure(endpoint)
+
+		switch config.SignalProtocol(signal) {
+		case domain.ProtocolGRPC:
+			err = r.sendGRPC(ctx, signal, target, insecure, msg)
+		case domain.ProtocolHTTP:
+			err = r.sendHTTP(ctx, signal, target, insecure, msg)
+		default:
+			return fmt.Errorf("unsupported protocol: %s", config.SignalProtocol(signal))
+		}
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("endpoint %s: %w", endpoint, err))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Replayer) sendHTTP(ctx context.Context, signal domain.SignalType, endpoint *domain.EndpointURL, insecure bool, msg proto.Message) error {
+	config := r.factory.config
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	headers := r.factory.signalHeaders(signal)
+	if config.IsSignalCompressionEnabled(signal) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+		headers["Content-Encoding"] = "gzip"
+	}
+
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+	url := scheme + "://" + httpHost(endpoint) + endpoint.BasePath + signalPath(config, signal)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := r.factory.httpClient(signal, endpoint).Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector replied %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (r *Replayer) sendGRPC(ctx context.Context, signal domain.SignalType, endpoint *domain.EndpointURL, insecureConn bool, msg proto.Message) error {
+	config := r.factory.config
+	conn, err := r.grpcConn(endpoint, insecureConn)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.SignalTimeout(signal))
+	defer cancel()
+	for key, value := range config.SignalHeaders(signal) {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(key), value)
+	}
+	for key, value := range config.Headers() {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(key), value)
+	}
+
+	var opts []grpc.CallOption
+	if config.IsSignalCompressionEnabled(signal) {
+		opts = append(opts, grpc.UseCompressor("gzip"))
+	}
+
+	switch req := msg.(type) {
+	case *coltracepb.ExportTraceServiceRequest:
+		_, err = coltracepb.NewTraceServiceClient(conn).Export(ctx, req, opts...)
+	case *colmetricspb.ExportMetricsServiceRequest:
+		_, err = colmetricspb.NewMetricsServiceClient(conn).Export(ctx, req, opts...)
+	case *collogspb.ExportLogsServiceRequest:
+		_, err = collogspb.NewLogsServiceClient(conn).Export(ctx, req, opts...)
+	}
+	return err
+}
+
+// grpcConn returns a cached connection to an endpoint, authenticated like the gRPC exporters
+func (r *Replayer) grpcConn(endpoint *domain.EndpointURL, insecureConn bool) (*grpc.ClientConn, error) {
+	target := grpcTarget(endpoint)
+	if conn, ok := r.conns[target]; ok {
+		return conn, nil
+	}
+
+	creds := credentials.NewClientTLSFromCert(nil, "")
+	if insecureConn {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(r.factory.authInterceptor()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.conns[target] = conn
+	return conn, nil
+}
+
+// signalPath returns the HTTP export path for a signal
+func signalPath(config *domain.TelemetryConfig, signal domain.SignalType) string {
+	switch signal {
+	case domain.SignalMetrics:
+		return config.MetricsEndpoint()
+	case domain.SignalLogs:
+		return config.LogsEndpoint()
+	default:
+		return config.TracesEndpoint()
+	}
+}
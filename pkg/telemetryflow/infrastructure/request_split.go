@@ -0,0 +1,440 @@
+// Package infrastructure provides size-aware splitting of OTLP export requests for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// minTruncatedValue is the shortest length attribute and body values of an oversized
+// item are truncated to before the item is dropped instead
+const minTruncatedValue = 64
+
+// sendWithinLimit sends req, split into requests of at most limit encoded bytes when it
+// is larger, and reports truncated and dropped items to the export call in ctx. Parts
+// are sent in order and the first failure is returned; the parts delivered by earlier
+// attempts of the export call are skipped.
+func sendWithinLimit(ctx context.Context, req proto.Message, limit int, send func(proto.Message) error) error {
+	if limit <= 0 || proto.Size(req) <= limit {
+		return send(req)
+	}
+	split := splitExportRequest(req, limit, proto.Size)
+	reportOversizedItems(ctx, split.truncated, split.dropped)
+	for i := deliveredParts(ctx); i < len(split.parts); i++ {
+		if err := send(split.parts[i]); err != nil {
+			return err
+		}
+		reportDeliveredParts(ctx, i+1)
+	}
+	return nil
+}
+
+// requestSplit is the outcome of splitting an export request
+type requestSplit struct {
+	size      func(proto.Message) int // encoded size of a request
+	parts     []proto.Message
+	truncated int // items sent with shortened values
+	dropped   int // items too large to send even when truncated
+}
+
+// splitExportRequest splits an OTLP export request into requests of at most limit
+// bytes as measured by size, halving the items until each part fits. An item that does not fit
+// alone has its longest attribute and body values truncated, or is dropped if that is
+// not enough. Items are spans, metric data points or log records.
+func splitExportRequest(req proto.Message, limit int, size func(proto.Message) int) requestSplit {
+	split := requestSplit{size: size}
+	split.add(req, limit)
+	return split
+}
+
+func (s *requestSplit) add(req proto.Message, limit int) {
+	if s.size(req) <= limit {
+		s.parts = append(s.parts, req)
+		return
+	}
+
+	switch items := requestItems(req); items {
+	case 0:
+		// Resource and scope alone exceed the limit; there is nothing to send
+	case 1:
+		if shrunk := truncateRequest(req, limit, s.size); shrunk != nil {
+			s.parts = append(s.parts, shrunk)
+			s.truncated++
+		} else {
+			s.dropped++
+		}
+	default:
+		s.add(sliceRequest(req, 0, items/2), limit)
+		s.add(sliceRequest(req, items/2, items), limit)
+	}
+}
+
+// truncateRequest returns a copy of a single-item request with its attribute and body
+// values shortened until it fits in limit bytes, or nil if it cannot fit
+func truncateRequest(req proto.Message, limit int, size func(proto.Message) int) proto.Message {
+	shrunk := proto.Clone(req)
+	for maxLen := limit / 2; maxLen >= minTruncatedValue; maxLen /= 2 {
+		truncateValues(shrunk.ProtoReflect(), maxLen)
+		if size(shrunk) <= limit {
+			return shrunk
+		}
+	}
+	return nil
+}
+
+// truncateValues shortens every string and bytes AnyValue in m to at most maxLen bytes
+func truncateValues(m protoreflect.Message, maxLen int) {
+	if value, ok := m.Interface().(*commonpb.AnyValue); ok {
+		switch v := value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			v.StringValue = truncateUTF8(v.StringValue, maxLen)
+		case *commonpb.AnyValue_BytesValue:
+			if len(v.BytesValue) > maxLen {
+				v.BytesValue = v.BytesValue[:maxLen]
+			}
+		}
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Message() == nil || fd.IsMap():
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				truncateValues(list.Get(i).Message(), maxLen)
+			}
+		default:
+			truncateValues(v.Message(), maxLen)
+		}
+		return true
+	})
+}
+
+// truncateUTF8 shortens s to at most maxLen bytes without splitting a character
+func truncateUTF8(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+// requestItems returns the number of spans, metric data points or log records in req
+func requestItems(req proto.Message) int {
+	count := 0
+	switch r := req.(type) {
+	case *coltracepb.ExportTraceServiceRequest:
+		for _, rs := range r.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				count += len(ss.Spans)
+			}
+		}
+	case *colmetricpb.ExportMetricsServiceRequest:
+		for _, rm := range r.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					count += metricPoints(m)
+				}
+			}
+		}
+	case *collogspb.ExportLogsServiceRequest:
+		for _, rl := range r.ResourceLogs {
+			for _, sl := range rl.ScopeLogs {
+				count += len(sl.LogRecords)
+			}
+		}
+	}
+	return count
+}
+
+// sliceRequest returns a request with the items [from, to) of req, in order. Resources,
+// scopes and metric descriptions are shared with req.
+func sliceRequest(req proto.Message, from, to int) proto.Message {
+	offset := 0
+	// window returns the part of [from, to) within the next n items, relative to them
+	window := func(n int) (int, int) {
+		lo, hi := max(from-offset, 0), min(to-offset, n)
+		offset += n
+		return lo, hi
+	}
+
+	switch r := req.(type) {
+	case *coltracepb.ExportTraceServiceRequest:
+		out := &coltracepb.ExportTraceServiceRequest{}
+		for _, rs := range r.ResourceSpans {
+			var scopes []*tracepb.ScopeSpans
+			for _, ss := range rs.ScopeSpans {
+				if lo, hi := window(len(ss.Spans)); lo < hi {
+					scopes = append(scopes, &tracepb.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl, Spans: ss.Spans[lo:hi]})
+				}
+			}
+			if len(scopes) > 0 {
+				out.ResourceSpans = append(out.ResourceSpans, &tracepb.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl, ScopeSpans: scopes})
+			}
+		}
+		return out
+	case *colmetricpb.ExportMetricsServiceRequest:
+		out := &colmetricpb.ExportMetricsServiceRequest{}
+		for _, rm := range r.ResourceMetrics {
+			var scopes []*metricspb.ScopeMetrics
+			for _, sm := range rm.ScopeMetrics {
+				var metrics []*metricspb.Metric
+				for _, m := range sm.Metrics {
+					if lo, hi := window(metricPoints(m)); lo < hi {
+						metrics = append(metrics, sliceMetric(m, lo, hi))
+					}
+				}
+				if len(metrics) > 0 {
+					scopes = append(scopes, &metricspb.ScopeMetrics{Scope: sm.Scope, SchemaUrl: sm.SchemaUrl, Metrics: metrics})
+				}
+			}
+			if len(scopes) > 0 {
+				out.ResourceMetrics = append(out.ResourceMetrics, &metricspb.ResourceMetrics{Resource: rm.Resource, SchemaUrl: rm.SchemaUrl, ScopeMetrics: scopes})
+			}
+		}
+		return out
+	case *collogspb.ExportLogsServiceRequest:
+		out := &collogspb.ExportLogsServiceRequest{}
+		for _, rl := range r.ResourceLogs {
+			var scopes []*logspb.ScopeLogs
+			for _, sl := range rl.ScopeLogs {
+				if lo, hi := window(len(sl.LogRecords)); lo < hi {
+					scopes = append(scopes, &logspb.ScopeLogs{Scope: sl.Scope, SchemaUrl: sl.SchemaUrl, LogRecords: sl.LogRecords[lo:hi]})
+				}
+			}
+			if len(scopes) > 0 {
+				out.ResourceLogs = append(out.ResourceLogs, &logspb.ResourceLogs{Resource: rl.Resource, SchemaUrl: rl.SchemaUrl, ScopeLogs: scopes})
+			}
+		}
+		return out
+	}
+	return req
+}
+
+// metricPoints returns the number of data points of m
+func metricPoints(m *metricspb.Metric) int {
+	switch data := m.Data.(type) {
+	case *metricspb.Metric_Gauge:
+		return len(data.Gauge.GetDataPoints())
+	case *metricspb.Metric_Sum:
+		return len(data.Sum.GetDataPoints())
+	case *metricspb.Metric_Histogram:
+		return len(data.Histogram.GetDataPoints())
+	case *metricspb.Metric_ExponentialHistogram:
+		return len(data.ExponentialHistogram.GetDataPoints())
+	case *metricspb.Metric_Summary:
+		return len(data.Summary.GetDataPoints())
+	}
+	return 0
+}
+
+// sliceMetric returns m with the data points [lo, hi)
+func sliceMetric(m *metricspb.Metric, lo, hi int) *metricspb.Metric {
+	out := &metricspb.Metric{Name: m.Name, Description: m.Description, Unit: m.Unit, Metadata: m.Metadata}
+	switch data := m.Data.(type) {
+	case *metricspb.Metric_Gauge:
+		out.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: data.Gauge.DataPoints[lo:hi]}}
+	case *metricspb.Metric_Sum:
+		out.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             data.Sum.DataPoints[lo:hi],
+			AggregationTemporality: data.Sum.AggregationTemporality,
+			IsMonotonic:            data.Sum.IsMonotonic,
+		}}
+	case *metricspb.Metric_Histogram:
+		out.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints:             data.Histogram.DataPoints[lo:hi],
+			AggregationTemporality: data.Histogram.AggregationTemporality,
+		}}
+	case *metricspb.Metric_ExponentialHistogram:
+		out.Data = &metricspb.Metric_ExponentialHistogram{ExponentialHistogram: &metricspb.ExponentialHistogram{
+			DataPoints:             data.ExponentialHistogram.DataPoints[lo:hi],
+			AggregationTemporality: data.ExponentialHistogram.AggregationTemporality,
+		}}
+	case *metricspb.Metric_Summary:
+		out.Data = &metricspb.Metric_Summary{Summary: &metricspb.Summary{DataPoints: data.Summary.DataPoints[lo:hi]}}
+	}
+	return out
+}
+
+// ===== HTTP =====
+
+// splitTransport splits OTLP/HTTP requests whose body, as sent, exceeds limit bytes.
+// The parts are sent one after the other; the first failed response, or the last
+// response, is returned. The parts delivered by earlier attempts of the export call are
+// skipped.
+type splitTransport struct {
+	base     http.RoundTripper
+	limit    int
+	signal   domain.SignalType
+	encoding domain.HTTPEncoding
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *splitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || (req.ContentLength >= 0 && req.ContentLength <= int64(t.limit)) {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(body) <= t.limit {
+		return t.base.RoundTrip(withBody(req, body))
+	}
+
+	gzipped := req.Header.Get("Content-Encoding") == "gzip"
+	msg, err := decodeRequestBody(t.signal, body, gzipped, t.encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parts are sized before compression, so their compressed bodies fit as well
+	size := proto.Size
+	if t.encoding == domain.HTTPEncodingJSON {
+		size = otlpJSONSize
+	}
+	split := splitExportRequest(msg, t.limit, size)
+	reportOversizedItems(req.Context(), split.truncated, split.dropped)
+
+	ctx := req.Context()
+	var resp *http.Response
+	for i := deliveredParts(ctx); i < len(split.parts); i++ {
+		partBody, err := encodeRequestBody(split.parts[i], gzipped, t.encoding)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = t.base.RoundTrip(withBody(req, partBody)); err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return resp, nil
+		}
+		reportDeliveredParts(ctx, i+1)
+		if i == len(split.parts)-1 {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	// Every item was dropped or delivered earlier; answer as the collector would have
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{t.encoding.ContentType()}},
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+// decodeRequestBody unmarshals the body of an OTLP/HTTP export request of signal
+func decodeRequestBody(signal domain.SignalType, body []byte, gzipped bool, encoding domain.HTTPEncoding) (proto.Message, error) {
+	if gzipped {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress export request: %w", err)
+		}
+		if body, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("failed to decompress export request: %w", err)
+		}
+	}
+	msg := newExportRequest(signal)
+	var err error
+	if encoding == domain.HTTPEncodingJSON {
+		err = UnmarshalOTLPJSON(body, msg)
+	} else {
+		err = proto.Unmarshal(body, msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode export request: %w", err)
+	}
+	return msg, nil
+}
+
+// encodeRequestBody marshals an export request in encoding, gzipped if asked
+func encodeRequestBody(msg proto.Message, gzipped bool, encoding domain.HTTPEncoding) ([]byte, error) {
+	var encoded []byte
+	var err error
+	if encoding == domain.HTTPEncodingJSON {
+		encoded, err = MarshalOTLPJSON(msg)
+	} else {
+		encoded, err = proto.Marshal(msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export request: %w", err)
+	}
+	if !gzipped {
+		return encoded, nil
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(encoded); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// otlpJSONSize returns the OTLP/JSON encoded size of an export request
+func otlpJSONSize(msg proto.Message) int {
+	encoded, err := MarshalOTLPJSON(msg)
+	if err != nil {
+		return proto.Size(msg)
+	}
+	return len(encoded)
+}
+
+// withBody returns a copy of req sending body
+func withBody(req *http.Request, body []byte) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.ContentLength = int64(len(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return clone
+}
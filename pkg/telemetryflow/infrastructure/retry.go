@@ -0,0 +1,308 @@
+// Package infrastructure provides throttle-aware export retries and circuit breaking for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// ===== RETRIES =====
+
+// retryPolicy decides how often and how long after a failed export it is sent again.
+// The OTLP exporters do not retry themselves, so a retry covers every failover endpoint
+// and is visible to the circuit breaker and the export callbacks.
+type retryPolicy struct {
+	enabled    bool
+	maxRetries int
+	initial    time.Duration
+	max        time.Duration
+}
+
+// newRetryPolicy creates the retry policy configured in config
+func newRetryPolicy(config *domain.TelemetryConfig) retryPolicy {
+	return retryPolicy{
+		enabled:    config.IsRetryEnabled() && config.MaxRetries() > 0,
+		maxRetries: config.MaxRetries(),
+		initial:    config.RetryBackoff(),
+		max:        config.RetryMaxBackoff(),
+	}
+}
+
+// delay returns the wait before the given retry (1 for the first). The backoff doubles
+// with each retry up to the cap and is jittered over its upper half, so exporters that
+// failed together do not retry together. A server throttling hint is the minimum wait.
+func (p retryPolicy) delay(retry int, throttle time.Duration) time.Duration {
+	backoff := p.initial
+	for i := 1; i < retry && backoff < p.max; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.max)
+	if backoff > 0 {
+		backoff = backoff/2 + rand.N(backoff/2+1)
+	}
+	return max(backoff, throttle)
+}
+
+// sleepContext waits for d, returning false if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// httpRetryAdvice returns whether a request answered with code may be retried, and the
+// wait the collector asked for in its Retry-After header
+func httpRetryAdvice(code int, header http.Header) (bool, time.Duration) {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, parseRetryAfter(header.Get("Retry-After"), time.Now())
+	}
+	return false, 0
+}
+
+// parseRetryAfter returns the wait requested by a Retry-After value, given in seconds
+// or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
+
+// grpcRetryAdvice returns whether a failed gRPC export may be retried, and the wait the
+// collector asked for in a RetryInfo detail. ResourceExhausted is only retried when the
+// collector says when, as the OTLP specification requires.
+func grpcRetryAdvice(err error) (bool, time.Duration) {
+	st := status.Convert(err)
+	var throttle time.Duration
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			throttle = info.GetRetryDelay().AsDuration()
+		}
+	}
+	switch st.Code() {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true, throttle
+	case codes.ResourceExhausted:
+		return throttle > 0, throttle
+	}
+	return false, 0
+}
+
+// ===== CIRCUIT BREAKER =====
+
+// breakerState is the state of a circuit breaker
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // exports are sent
+	breakerOpen                         // exports are paused until the cooldown ends
+	breakerHalfOpen                     // one export probes the collector
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// heldExport is data exported while a breaker was open, kept to be sent once it closes
+type heldExport struct {
+	items int
+	size  int64 // estimated memory, accounted to the memory limiter while held
+	send  func(context.Context) error
+}
+
+// circuitBreaker pauses the exports of one exporter after consecutive failures
+type circuitBreaker struct {
+	config domain.CircuitBreakerConfig
+	memory *MemoryLimiter // nil unless a memory limiter is configured
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	retryAt  time.Time
+	held     []heldExport
+	buffered int
+	dropped  int64
+}
+
+// newCircuitBreaker creates a breaker whose buffer counts against memory, or returns nil
+// if config disables it. memory may be nil.
+func newCircuitBreaker(config domain.CircuitBreakerConfig, memory *MemoryLimiter) *circuitBreaker {
+	if !config.Enabled() {
+		return nil
+	}
+	return &circuitBreaker{config: config, memory: memory}
+}
+
+// allow reports whether an export may be sent. Once the cooldown is over a single
+// export probes the collector; the others are held or dropped until it succeeds.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if now.Before(b.retryAt) {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	default:
+		return false
+	}
+}
+
+// closed reports whether exports are currently sent
+func (b *circuitBreaker) closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == breakerClosed
+}
+
+// result records the outcome of an allowed export. It reports whether the failure
+// opened the breaker, and after a success returns the held exports to send.
+func (b *circuitBreaker) result(ok bool, now time.Time) (opened bool, held []heldExport) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ok {
+		held = b.held
+		for _, export := range held {
+			b.memory.release(export.size)
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		b.held = nil
+		b.buffered = 0
+		return false, held
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		opened = b.state != breakerOpen
+		b.state = breakerOpen
+		b.openedAt = now
+		b.retryAt = now.Add(b.config.Cooldown)
+	}
+	return opened, nil
+}
+
+// hold keeps data exported while the breaker is open when the policy buffers and the
+// memory limiter has room, making room in the buffer by discarding the oldest data.
+// retain copies the data, or is nil if it cannot be buffered. It returns the number of
+// items discarded.
+func (b *circuitBreaker) hold(items int, retain func() heldExport) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.config.Policy != domain.BreakerBuffer || retain == nil || items > b.config.BufferSize {
+		b.dropped += int64(items)
+		return items
+	}
+	held := retain()
+	if !b.memory.admit(held.size) {
+		b.memory.countDropped(items)
+		b.dropped += int64(items)
+		return items
+	}
+	dropped := b.trim(b.config.BufferSize - items)
+	b.held = append(b.held, held)
+	b.buffered += items
+	return dropped
+}
+
+// requeue puts back held exports that could not be sent, ahead of newer data
+func (b *circuitBreaker) requeue(held []heldExport) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, export := range held {
+		b.buffered += export.items
+		b.memory.hold(export.size)
+	}
+	b.held = append(held[:len(held):len(held)], b.held...)
+	return b.trim(b.config.BufferSize)
+}
+
+// trim discards the oldest held exports until at most limit items are held. The caller
+// holds b.mu.
+func (b *circuitBreaker) trim(limit int) int {
+	dropped := 0
+	for b.buffered > limit && len(b.held) > 0 {
+		dropped += b.held[0].items
+		b.buffered -= b.held[0].items
+		b.memory.release(b.held[0].size)
+		b.held = b.held[1:]
+	}
+	b.dropped += int64(dropped)
+	return dropped
+}
+
+// health returns the breaker state for the health query
+func (b *circuitBreaker) health(signal domain.SignalType, exporter string) application.BreakerHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := application.BreakerHealth{
+		Signal:              string(signal),
+		Exporter:            exporter,
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+		OpenedAt:            b.openedAt,
+		Buffered:            b.buffered,
+		Dropped:             b.dropped,
+	}
+	if b.state == breakerOpen {
+		health.RetryAt = b.retryAt
+	}
+	return health
+}
@@ -0,0 +1,272 @@
+// Package infrastructure provides Go runtime metrics for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"math"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/internal/version"
+)
+
+// RuntimeScopeName is the instrumentation scope of the Go runtime metrics
+const RuntimeScopeName = "github.com/telemetryflow/telemetryflow-go-sdk/runtime"
+
+// runtime/metrics keys read on every sample
+const (
+	rmGoroutines   = "/sched/goroutines:goroutines"
+	rmMemoryTotal  = "/memory/classes/total:bytes"
+	rmHeapReleased = "/memory/classes/heap/released:bytes"
+	rmHeapStacks   = "/memory/classes/heap/stacks:bytes"
+	rmOSStacks     = "/memory/classes/os-stacks:bytes"
+	rmMemoryLimit  = "/gc/gomemlimit:bytes"
+	rmAllocBytes   = "/gc/heap/allocs:bytes"
+	rmAllocObjects = "/gc/heap/allocs:objects"
+	rmHeapGoal     = "/gc/heap/goal:bytes"
+	rmGOGC         = "/gc/gogc:percent"
+	rmGOMAXPROCS   = "/sched/gomaxprocs:threads"
+	rmSchedLatency = "/sched/latencies:seconds"
+	rmGCPauses     = "/sched/pauses/total/gc:seconds"
+)
+
+// runtimeLatencyBounds are the explicit bucket bounds (seconds) the runtime's fine-grained
+// latency histograms are folded into
+var runtimeLatencyBounds = []float64{
+	0.000001, 0.000005, 0.00001, 0.00005, 0.0001, 0.0005,
+	0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1,
+}
+
+// RuntimeMetrics reports Go runtime metrics under the OpenTelemetry semantic convention
+// names (go.goroutine.count, go.memory.used, ...). Scalar values are observable
+// instruments; the scheduler latency and GC pause histograms have no observable
+// instrument and are added to every reader as a metric producer.
+type RuntimeMetrics struct {
+	interval time.Duration
+	start    time.Time
+
+	mu       sync.Mutex
+	samples  []metrics.Sample
+	index    map[string]int
+	lastRead time.Time
+}
+
+// NewRuntimeMetrics creates runtime metrics that read runtime/metrics at most once per interval
+func NewRuntimeMetrics(interval time.Duration) *RuntimeMetrics {
+	names := []string{
+		rmGoroutines, rmMemoryTotal, rmHeapReleased, rmHeapStacks, rmOSStacks,
+		rmMemoryLimit, rmAllocBytes, rmAllocObjects, rmHeapGoal, rmGOGC, rmGOMAXPROCS,
+		rmSchedLatency, rmGCPauses,
+	}
+	r := &RuntimeMetrics{
+		interval: interval,
+		start:    time.Now(),
+		samples:  make([]metrics.Sample, len(names)),
+		index:    make(map[string]int, len(names)),
+	}
+	for i, name := range names {
+		r.samples[i].Name = name
+		r.index[name] = i
+	}
+	return r
+}
+
+// refresh re-reads runtime/metrics if the last sample is older than the interval.
+// Callers must hold r.mu.
+func (r *RuntimeMetrics) refresh() {
+	if now := time.Now(); r.lastRead.IsZero() || now.Sub(r.lastRead) >= r.interval {
+		metrics.Read(r.samples)
+		r.lastRead = now
+	}
+}
+
+// uint64Value returns a scalar sample, or false if the runtime does not support it
+func (r *RuntimeMetrics) uint64Value(name string) (uint64, bool) {
+	value := r.samples[r.index[name]].Value
+	if value.Kind() != metrics.KindUint64 {
+		return 0, false
+	}
+	return value.Uint64(), true
+}
+
+// Register creates the observable runtime instruments on meter
+func (r *RuntimeMetrics) Register(meter otelmetric.Meter) (otelmetric.Registration, error) {
+	goroutines, err := meter.Int64ObservableUpDownCounter("go.goroutine.count",
+		otelmetric.WithUnit("{goroutine}"),
+		otelmetric.WithDescription("Count of live goroutines."))
+	if err != nil {
+		return nil, err
+	}
+	memoryUsed, err := meter.Int64ObservableUpDownCounter("go.memory.used",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Memory used by the Go runtime."))
+	if err != nil {
+		return nil, err
+	}
+	memoryLimit, err := meter.Int64ObservableUpDownCounter("go.memory.limit",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Go runtime memory limit configured by the user, if a limit exists."))
+	if err != nil {
+		return nil, err
+	}
+	allocated, err := meter.Int64ObservableCounter("go.memory.allocated",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Memory allocated to the heap by the application."))
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := meter.Int64ObservableCounter("go.memory.allocations",
+		otelmetric.WithUnit("{allocation}"),
+		otelmetric.WithDescription("Count of allocations to the heap by the application."))
+	if err != nil {
+		return nil, err
+	}
+	gcGoal, err := meter.Int64ObservableUpDownCounter("go.memory.gc.goal",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Heap size target for the end of the GC cycle."))
+	if err != nil {
+		return nil, err
+	}
+	processors, err := meter.Int64ObservableUpDownCounter("go.processor.limit",
+		otelmetric.WithUnit("{thread}"),
+		otelmetric.WithDescription("The number of OS threads that can execute user-level Go code simultaneously."))
+	if err != nil {
+		return nil, err
+	}
+	gogc, err := meter.Int64ObservableUpDownCounter("go.config.gogc",
+		otelmetric.WithUnit("%"),
+		otelmetric.WithDescription("Heap size target percentage configured by the user, otherwise 100."))
+	if err != nil {
+		return nil, err
+	}
+
+	stackAttrs := otelmetric.WithAttributes(attribute.String("go.memory.type", "stack"))
+	otherAttrs := otelmetric.WithAttributes(attribute.String("go.memory.type", "other"))
+
+	return meter.RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.refresh()
+
+		observe := func(instrument otelmetric.Int64Observable, name string, opts ...otelmetric.ObserveOption) {
+			if value, ok := r.uint64Value(name); ok && value <= math.MaxInt64 {
+				o.ObserveInt64(instrument, int64(value), opts...)
+			}
+		}
+		observe(goroutines, rmGoroutines)
+		observe(allocated, rmAllocBytes)
+		observe(allocations, rmAllocObjects)
+		observe(gcGoal, rmHeapGoal)
+		observe(processors, rmGOMAXPROCS)
+		if value, ok := r.uint64Value(rmGOGC); ok && value > 0 {
+			o.ObserveInt64(gogc, int64(value))
+		}
+		// math.MaxInt64 means no limit is set
+		if value, ok := r.uint64Value(rmMemoryLimit); ok && value < math.MaxInt64 {
+			o.ObserveInt64(memoryLimit, int64(value))
+		}
+
+		total, okTotal := r.uint64Value(rmMemoryTotal)
+		released, okReleased := r.uint64Value(rmHeapReleased)
+		heapStacks, okHeapStacks := r.uint64Value(rmHeapStacks)
+		osStacks, okOSStacks := r.uint64Value(rmOSStacks)
+		if okTotal && okReleased && okHeapStacks && okOSStacks {
+			stack := heapStacks + osStacks
+			o.ObserveInt64(memoryUsed, int64(stack), stackAttrs)
+			o.ObserveInt64(memoryUsed, int64(total-released-stack), otherAttrs)
+		}
+		return nil
+	}, goroutines, memoryUsed, memoryLimit, allocated, allocations, gcGoal, processors, gogc)
+}
+
+// Produce returns the scheduler latency and GC pause histograms. It implements
+// sdkmetric.Producer.
+func (r *RuntimeMetrics) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refresh()
+
+	now := time.Now()
+	var result []metricdata.Metrics
+	for _, h := range []struct {
+		sample      string
+		name        string
+		description string
+	}{
+		{rmSchedLatency, "go.schedule.duration", "The time goroutines have spent in the scheduler in a runnable state before actually running."},
+		{rmGCPauses, "go.gc.pause.duration", "Distribution of individual GC-related stop-the-world pause latencies."},
+	} {
+		value := r.samples[r.index[h.sample]].Value
+		if value.Kind() != metrics.KindFloat64Histogram {
+			continue
+		}
+		result = append(result, metricdata.Metrics{
+			Name:        h.name,
+			Description: h.description,
+			Unit:        "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints:  []metricdata.HistogramDataPoint[float64]{foldHistogram(value.Float64Histogram(), r.start, now)},
+			},
+		})
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: RuntimeScopeName, Version: version.Version},
+		Metrics: result,
+	}}, nil
+}
+
+// foldHistogram folds a runtime histogram into runtimeLatencyBounds. Each runtime bucket
+// is counted in the bucket containing its upper edge, and contributes its midpoint to the sum.
+func foldHistogram(h *metrics.Float64Histogram, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	point := metricdata.HistogramDataPoint[float64]{
+		StartTime:    start,
+		Time:         now,
+		Bounds:       runtimeLatencyBounds,
+		BucketCounts: make([]uint64, len(runtimeLatencyBounds)+1),
+	}
+	for i, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		low, high := h.Buckets[i], h.Buckets[i+1]
+		if math.IsInf(low, -1) {
+			low = high
+		}
+		if math.IsInf(high, 1) {
+			high = low
+		}
+		point.BucketCounts[sort.SearchFloat64s(runtimeLatencyBounds, high)] += count
+		point.Count += count
+		point.Sum += float64(count) * (low + high) / 2
+	}
+	return point
+}
@@ -0,0 +1,221 @@
+// Package infrastructure provides the SDK's self-observability metrics for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/internal/version"
+)
+
+// SelfMetricsScopeName is the instrumentation scope of the SDK's own metrics
+const SelfMetricsScopeName = "github.com/telemetryflow/telemetryflow-go-sdk/sdk"
+
+// selfMetricsQueueSize bounds the spans or log records waiting in each batch processor.
+// It matches the OpenTelemetry SDK default.
+const selfMetricsQueueSize = 2048
+
+// exportDurationBounds are the bucket bounds (seconds) of telemetryflow.sdk.exporter.duration
+var exportDurationBounds = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// batchSizeBounds are the bucket bounds of telemetryflow.sdk.exporter.batch.size
+var batchSizeBounds = []float64{1, 10, 50, 100, 250, 512, 1000, 2048, 5000}
+
+// SelfMetrics reports what the SDK does with the telemetry it is given under the
+// telemetryflow.sdk.* namespace: items queued, exported, dropped and failed per
+// signal and exporter, export latency, batch sizes, retries and active spans.
+// Recording is a no-op until Register is called.
+type SelfMetrics struct {
+	instruments atomic.Pointer[selfInstruments]
+}
+
+// selfInstruments are the synchronous instruments SelfMetrics records into
+type selfInstruments struct {
+	queued    otelmetric.Int64Counter
+	exported  otelmetric.Int64Counter
+	dropped   otelmetric.Int64Counter
+	truncated otelmetric.Int64Counter
+	failed    otelmetric.Int64Counter
+	retries   otelmetric.Int64Counter
+	duration  otelmetric.Float64Histogram
+	batchSize otelmetric.Int64Histogram
+}
+
+// NewSelfMetrics creates self metrics that record nothing until registered on a meter
+func NewSelfMetrics() *SelfMetrics {
+	return &SelfMetrics{}
+}
+
+// Register creates the instruments on meter. activeSpans reports the spans started
+// through the client and not yet ended. memory is the memory limiter whose state is
+// reported, or nil.
+func (m *SelfMetrics) Register(meter otelmetric.Meter, activeSpans func() int64, memory *MemoryLimiter) error {
+	var err error
+	instruments := &selfInstruments{}
+	if instruments.queued, err = meter.Int64Counter("telemetryflow.sdk.items.queued",
+		otelmetric.WithUnit("{item}"),
+		otelmetric.WithDescription("Spans and log records handed to a batch processor for export.")); err != nil {
+		return err
+	}
+	if instruments.exported, err = meter.Int64Counter("telemetryflow.sdk.items.exported",
+		otelmetric.WithUnit("{item}"),
+		otelmetric.WithDescription("Spans, metric data points and log records accepted by an exporter.")); err != nil {
+		return err
+	}
+	if instruments.dropped, err = meter.Int64Counter("telemetryflow.sdk.items.dropped",
+		otelmetric.WithUnit("{item}"),
+		otelmetric.WithDescription("Items discarded because the export queue was full, the circuit breaker was open, the memory limit was reached or the item exceeded the request size limit.")); err != nil {
+		return err
+	}
+	if instruments.truncated, err = meter.Int64Counter("telemetryflow.sdk.items.truncated",
+		otelmetric.WithUnit("{item}"),
+		otelmetric.WithDescription("Items sent with shortened attribute and body values to fit the request size limit.")); err != nil {
+		return err
+	}
+	if instruments.failed, err = meter.Int64Counter("telemetryflow.sdk.items.failed",
+		otelmetric.WithUnit("{item}"),
+		otelmetric.WithDescription("Spans, metric data points and log records lost because the export failed after all retries.")); err != nil {
+		return err
+	}
+	if instruments.retries, err = meter.Int64Counter("telemetryflow.sdk.exporter.retries",
+		otelmetric.WithUnit("{retry}"),
+		otelmetric.WithDescription("Export requests repeated after a retryable failure or an endpoint failover.")); err != nil {
+		return err
+	}
+	if instruments.duration, err = meter.Float64Histogram("telemetryflow.sdk.exporter.duration",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Duration of export calls, including retries."),
+		otelmetric.WithExplicitBucketBoundaries(exportDurationBounds...)); err != nil {
+		return err
+	}
+	if instruments.batchSize, err = meter.Int64Histogram("telemetryflow.sdk.exporter.batch.size",
+		otelmetric.WithUnit("{item}"),
+		otelmetric.WithDescription("Number of spans, metric data points or log records per export call."),
+		otelmetric.WithExplicitBucketBoundaries(batchSizeBounds...)); err != nil {
+		return err
+	}
+	active, err := meter.Int64ObservableUpDownCounter("telemetryflow.sdk.spans.active",
+		otelmetric.WithUnit("{span}"),
+		otelmetric.WithDescription("Spans started through the client that have not ended yet."))
+	if err != nil {
+		return err
+	}
+	if _, err := meter.RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		o.ObserveInt64(active, activeSpans())
+		return nil
+	}, active); err != nil {
+		return err
+	}
+	if memory != nil {
+		if err := registerMemoryMetrics(meter, memory); err != nil {
+			return err
+		}
+	}
+
+	m.instruments.Store(instruments)
+	return nil
+}
+
+// registerMemoryMetrics reports the memory limiter's estimate of buffered data and its
+// level: 0 below the soft limit, 1 above it and 2 above the hard limit
+func registerMemoryMetrics(meter otelmetric.Meter, memory *MemoryLimiter) error {
+	buffered, err := meter.Int64ObservableUpDownCounter("telemetryflow.sdk.memory.buffered",
+		otelmetric.WithUnit("By"),
+		otelmetric.WithDescription("Estimated memory of the spans and log records waiting in the SDK's queues and buffers."))
+	if err != nil {
+		return err
+	}
+	level, err := meter.Int64ObservableGauge("telemetryflow.sdk.memory.limit_state",
+		otelmetric.WithUnit("1"),
+		otelmetric.WithDescription("Memory limiter state: 0 normal, 1 above the soft limit, 2 above the hard limit."))
+	if err != nil {
+		return err
+	}
+	_, err = meter.RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		o.ObserveInt64(buffered, memory.buffered.Load())
+		o.ObserveInt64(level, int64(memory.level()))
+		return nil
+	}, buffered, level)
+	return err
+}
+
+// recordQueued counts an item handed to a batch processor
+func (m *SelfMetrics) recordQueued(ctx context.Context, attrs otelmetric.MeasurementOption) {
+	if instruments := m.loaded(); instruments != nil {
+		instruments.queued.Add(ctx, 1, attrs)
+	}
+}
+
+// recordDropped counts items discarded because the export queue was full, the circuit
+// breaker was open or the memory limit was reached
+func (m *SelfMetrics) recordDropped(ctx context.Context, attrs otelmetric.MeasurementOption, items int) {
+	if instruments := m.loaded(); instruments != nil {
+		instruments.dropped.Add(ctx, int64(items), attrs)
+	}
+}
+
+// recordExport records the outcome of one export call
+func (m *SelfMetrics) recordExport(ctx context.Context, attrs otelmetric.MeasurementOption, items, retries int, elapsed time.Duration, err error) {
+	instruments := m.loaded()
+	if instruments == nil {
+		return
+	}
+	if err != nil {
+		instruments.failed.Add(ctx, int64(items), attrs)
+	} else {
+		instruments.exported.Add(ctx, int64(items), attrs)
+	}
+	if retries > 0 {
+		instruments.retries.Add(ctx, int64(retries), attrs)
+	}
+	instruments.duration.Record(ctx, elapsed.Seconds(), attrs)
+	instruments.batchSize.Record(ctx, int64(items), attrs)
+}
+
+// recordOversized counts items that exceeded the request size limit on their own
+func (m *SelfMetrics) recordOversized(ctx context.Context, attrs otelmetric.MeasurementOption, truncated, dropped int) {
+	instruments := m.loaded()
+	if instruments == nil {
+		return
+	}
+	if truncated > 0 {
+		instruments.truncated.Add(ctx, int64(truncated), attrs)
+	}
+	if dropped > 0 {
+		instruments.dropped.Add(ctx, int64(dropped), attrs)
+	}
+}
+
+// loaded returns the registered instruments, or nil if self metrics are disabled
+// (m is nil) or not registered yet
+func (m *SelfMetrics) loaded() *selfInstruments {
+	if m == nil {
+		return nil
+	}
+	return m.instruments.Load()
+}
+
+// selfMetricsMeter returns the meter the SDK records its own metrics with
+func selfMetricsMeter(provider otelmetric.MeterProvider) otelmetric.Meter {
+	return provider.Meter(SelfMetricsScopeName, otelmetric.WithInstrumentationVersion(version.Version))
+}
@@ -0,0 +1,158 @@
+// Package infrastructure provides metrics recorded with explicit timestamps for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// maxTimestampedPoints is the number of timestamped data points held per reader
+// between two collections
+const maxTimestampedPoints = 2048
+
+// timestampedPoint is a gauge data point recorded with an explicit timestamp
+type timestampedPoint struct {
+	name       string
+	unit       string
+	attributes attribute.Set
+	time       time.Time
+	value      float64
+}
+
+// timestampedMetrics holds gauge data points recorded with an explicit timestamp. The
+// OpenTelemetry metric API stamps data at collection, so these points bypass the meter:
+// every periodic reader gets its own producer, which hands each point to its exporter
+// once, with the point's own time.
+type timestampedMetrics struct {
+	scope instrumentation.Scope
+
+	mu      sync.Mutex
+	readers []*timestampedProducer
+}
+
+// newTimestampedMetrics returns the timestamped metrics of the meter scope
+func newTimestampedMetrics(scope string) *timestampedMetrics {
+	return &timestampedMetrics{scope: instrumentation.Scope{Name: scope}}
+}
+
+// producer returns a new producer for one reader. Points recorded from now on are held
+// until that reader collects them.
+func (t *timestampedMetrics) producer() sdkmetric.Producer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := &timestampedProducer{scope: t.scope}
+	t.readers = append(t.readers, p)
+	return p
+}
+
+// errTimestampedPointsFull is returned for points recorded while a reader holds
+// maxTimestampedPoints
+var errTimestampedPointsFull = fmt.Errorf("%d timestamped data points are waiting for export; flush before recording more", maxTimestampedPoints)
+
+// errNoTimestampedReaders is returned for points recorded without a periodic reader.
+// The Prometheus endpoint and custom metric readers cannot receive them.
+var errNoTimestampedReaders = errors.New("timestamped data points are only exported by the OTLP, console and file exporters and destinations; none is enabled")
+
+// record holds data points for every reader and returns how many were recorded. Only
+// the leading points that fit below maxTimestampedPoints for every reader are
+// recorded; flushing collects them. The error tells why the remaining points were not.
+func (t *timestampedMetrics) record(points ...timestampedPoint) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.readers) == 0 {
+		return 0, errNoTimestampedReaders
+	}
+	n := len(points)
+	for _, p := range t.readers {
+		n = min(n, maxTimestampedPoints-p.len())
+	}
+	n = max(n, 0)
+	for _, p := range t.readers {
+		p.add(points[:n]...)
+	}
+	if n < len(points) {
+		return n, errTimestampedPointsFull
+	}
+	return n, nil
+}
+
+// timestampedProducer hands the points held for one reader to its next collection
+type timestampedProducer struct {
+	scope instrumentation.Scope
+
+	mu     sync.Mutex
+	points []timestampedPoint
+}
+
+func (p *timestampedProducer) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.points)
+}
+
+func (p *timestampedProducer) add(points ...timestampedPoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.points = append(p.points, points...)
+}
+
+// Produce returns the held points as gauges, one per metric name, and forgets them.
+// It implements sdkmetric.Producer.
+func (p *timestampedProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	p.mu.Lock()
+	points := p.points
+	p.points = nil
+	p.mu.Unlock()
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	var metrics []metricdata.Metrics
+	index := make(map[string]int)
+	for _, point := range points {
+		i, ok := index[point.name]
+		if !ok {
+			i = len(metrics)
+			index[point.name] = i
+			metrics = append(metrics, metricdata.Metrics{
+				Name:        point.name,
+				Description: fmt.Sprintf("Metric: %s", point.name),
+				Unit:        point.unit,
+				Data:        metricdata.Gauge[float64]{},
+			})
+		}
+		gauge := metrics[i].Data.(metricdata.Gauge[float64])
+		gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[float64]{
+			Attributes: point.attributes,
+			Time:       point.time,
+			Value:      point.value,
+		})
+		metrics[i].Data = gauge
+	}
+	return []metricdata.ScopeMetrics{{Scope: p.scope, Metrics: metrics}}, nil
+}
@@ -0,0 +1,489 @@
+// Package telemetryflowtest provides a fake TelemetryFlow collector for integration tests.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip" // accept gzip-compressed gRPC exports
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+)
+
+// Collector is a fake TelemetryFlow collector with OTLP gRPC and HTTP receivers on random
+// local ports. It records every export request and can inject faults.
+type Collector struct {
+	grpcServer   *grpc.Server
+	grpcListener net.Listener
+	httpServer   *httptest.Server
+
+	mu       sync.Mutex
+	requests []Request
+	faults   []*Fault
+}
+
+// Request is an export request received by the Collector
+type Request struct {
+	Protocol   domain.Protocol
+	Signal     domain.SignalType
+	Path       string      // HTTP path, or the gRPC method
+	APIVersion string      // v1 or v2 for HTTP requests, empty for gRPC
+	Headers    http.Header // HTTP headers or gRPC metadata
+	Status     int         // HTTP status the collector answered with (200 unless a fault applied)
+
+	Traces  *coltracepb.ExportTraceServiceRequest
+	Metrics *colmetricpb.ExportMetricsServiceRequest
+	Logs    *collogspb.ExportLogsServiceRequest
+}
+
+// TelemetryFlowHeaders returns the X-TelemetryFlow-* headers of the request.
+func (r Request) TelemetryFlowHeaders() map[string]string {
+	headers := make(map[string]string)
+	for key, values := range r.Headers {
+		if strings.HasPrefix(strings.ToLower(key), "x-telemetryflow-") && len(values) > 0 {
+			headers[key] = values[0]
+		}
+	}
+	return headers
+}
+
+// Fault makes the Collector fail or slow down matching requests. Statuses are answered
+// as-is over HTTP and mapped to the equivalent gRPC code (401 Unauthenticated,
+// 429 ResourceExhausted, 503 Unavailable, ...).
+type Fault struct {
+	Status     int               // status to answer with (0: succeed after Delay)
+	Delay      time.Duration     // wait before answering
+	RetryAfter time.Duration     // Retry-After header, or RetryInfo for gRPC
+	Signal     domain.SignalType // only requests for this signal (empty: all signals)
+	APIVersion string            // only HTTP requests to this API version, e.g. "v2"
+	Times      int               // number of requests affected (0: until cleared)
+	After      int               // matching requests answered normally before the fault applies
+}
+
+// NewCollector starts a fake collector that is stopped when the test ends.
+func NewCollector(t testing.TB) *Collector {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("telemetryflowtest: failed to listen for gRPC: %v", err)
+	}
+
+	c := &Collector{grpcListener: listener}
+	c.grpcServer = grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(c.grpcServer, &traceService{collector: c})
+	colmetricpb.RegisterMetricsServiceServer(c.grpcServer, &metricsService{collector: c})
+	collogspb.RegisterLogsServiceServer(c.grpcServer, &logsService{collector: c})
+	go func() { _ = c.grpcServer.Serve(listener) }()
+
+	c.httpServer = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+
+	t.Cleanup(c.Close)
+	return c
+}
+
+// NewClient builds and initializes a Client exporting to the collector over protocol,
+// like the package level NewClient.
+func (c *Collector) NewClient(t testing.TB, protocol domain.Protocol, opts ...Option) *telemetryflow.Client {
+	t.Helper()
+
+	endpoint := c.GRPCEndpoint()
+	if protocol == domain.ProtocolHTTP {
+		endpoint = c.HTTPEndpoint()
+	}
+	withProtocol := func(b *telemetryflow.Builder) { b.WithProtocol(protocol) }
+	return NewClient(t, endpoint, append([]Option{withProtocol}, opts...)...)
+}
+
+// GRPCEndpoint returns the host:port of the OTLP gRPC receiver.
+func (c *Collector) GRPCEndpoint() string { return c.grpcListener.Addr().String() }
+
+// HTTPEndpoint returns the host:port of the OTLP HTTP receiver.
+func (c *Collector) HTTPEndpoint() string { return strings.TrimPrefix(c.httpServer.URL, "http://") }
+
+// HTTPURL returns the base URL of the OTLP HTTP receiver.
+func (c *Collector) HTTPURL() string { return c.httpServer.URL }
+
+// Close stops both receivers.
+func (c *Collector) Close() {
+	c.grpcServer.Stop()
+	c.httpServer.Close()
+}
+
+// InjectFault applies f to the next matching requests. Faults are matched in the order
+// they were injected.
+func (c *Collector) InjectFault(f Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults = append(c.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (c *Collector) ClearFaults() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults = nil
+}
+
+// Requests returns the received export requests, optionally only those for the given signals.
+func (c *Collector) Requests(signals ...domain.SignalType) []Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var requests []Request
+	for _, req := range c.requests {
+		if len(signals) == 0 || containsSignal(signals, req.Signal) {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// Reset forgets the received requests.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = nil
+}
+
+// Spans returns the spans of all successful trace exports.
+func (c *Collector) Spans() []*tracepb.Span {
+	var spans []*tracepb.Span
+	for _, req := range c.Requests(domain.SignalTraces) {
+		if req.Status != http.StatusOK {
+			continue
+		}
+		for _, rs := range req.Traces.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				spans = append(spans, ss.GetSpans()...)
+			}
+		}
+	}
+	return spans
+}
+
+// Metrics returns the metrics of all successful metric exports.
+func (c *Collector) Metrics() []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for _, req := range c.Requests(domain.SignalMetrics) {
+		if req.Status != http.StatusOK {
+			continue
+		}
+		for _, rm := range req.Metrics.GetResourceMetrics() {
+			for _, sm := range rm.GetScopeMetrics() {
+				metrics = append(metrics, sm.GetMetrics()...)
+			}
+		}
+	}
+	return metrics
+}
+
+// LogRecords returns the log records of all successful log exports.
+func (c *Collector) LogRecords() []*logspb.LogRecord {
+	var records []*logspb.LogRecord
+	for _, req := range c.Requests(domain.SignalLogs) {
+		if req.Status != http.StatusOK {
+			continue
+		}
+		for _, rl := range req.Logs.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				records = append(records, sl.GetLogRecords()...)
+			}
+		}
+	}
+	return records
+}
+
+// receive records req and returns the fault to apply, if any
+func (c *Collector) receive(req Request) *Fault {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var fault *Fault
+	for i, f := range c.faults {
+		if f.Signal != "" && f.Signal != req.Signal {
+			continue
+		}
+		if f.APIVersion != "" && f.APIVersion != req.APIVersion {
+			continue
+		}
+		if f.After > 0 {
+			f.After--
+			break
+		}
+		fault = f
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				c.faults = append(c.faults[:i], c.faults[i+1:]...)
+			}
+		}
+		break
+	}
+
+	req.Status = http.StatusOK
+	if fault != nil && fault.Status != 0 {
+		req.Status = fault.Status
+	}
+	c.requests = append(c.requests, req)
+
+	if fault == nil {
+		return nil
+	}
+	copied := *fault
+	return &copied
+}
+
+// wait applies the fault delay, returning early if ctx is done
+func (f *Fault) wait(ctx context.Context) {
+	if f.Delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(f.Delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// ===== HTTP RECEIVER =====
+
+var httpSignalPaths = map[string]domain.SignalType{
+	"traces":  domain.SignalTraces,
+	"metrics": domain.SignalMetrics,
+	"logs":    domain.SignalLogs,
+}
+
+func (c *Collector) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// Paths end in /v1/<signal> or /v2/<signal>, optionally below a base path
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodPost || len(parts) < 3 {
+		http.NotFound(w, r)
+		return
+	}
+	version := parts[len(parts)-2]
+	signal, ok := httpSignalPaths[parts[len(parts)-1]]
+	if !ok || (version != "v1" && version != "v2") {
+		http.NotFound(w, r)
+		return
+	}
+
+	req := Request{
+		Protocol:   domain.ProtocolHTTP,
+		Signal:     signal,
+		Path:       r.URL.Path,
+		APIVersion: version,
+		Headers:    r.Header.Clone(),
+	}
+	msg := newRequestMessage(&req)
+
+	jsonBody := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	if err := decodeHTTPBody(r, msg, jsonBody); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if fault := c.receive(req); fault != nil {
+		fault.wait(r.Context())
+		if fault.Status != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Round(time.Second)/time.Second)))
+			}
+			http.Error(w, http.StatusText(fault.Status), fault.Status)
+			return
+		}
+	}
+
+	if jsonBody {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+// decodeHTTPBody reads a protobuf or OTLP/JSON body, gunzipping it if needed
+func decodeHTTPBody(r *http.Request, msg proto.Message, jsonBody bool) error {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if jsonBody {
+		return infrastructure.UnmarshalOTLPJSON(data, msg)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// newRequestMessage allocates the export request for req.Signal and attaches it to req
+func newRequestMessage(req *Request) proto.Message {
+	switch req.Signal {
+	case domain.SignalTraces:
+		req.Traces = &coltracepb.ExportTraceServiceRequest{}
+		return req.Traces
+	case domain.SignalMetrics:
+		req.Metrics = &colmetricpb.ExportMetricsServiceRequest{}
+		return req.Metrics
+	default:
+		req.Logs = &collogspb.ExportLogsServiceRequest{}
+		return req.Logs
+	}
+}
+
+// ===== gRPC RECEIVER =====
+
+type traceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	collector *Collector
+}
+
+func (s *traceService) Export(ctx context.Context, msg *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	return &coltracepb.ExportTraceServiceResponse{}, s.collector.receiveGRPC(ctx, Request{
+		Signal: domain.SignalTraces,
+		Path:   "/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+		Traces: msg,
+	})
+}
+
+type metricsService struct {
+	colmetricpb.UnimplementedMetricsServiceServer
+	collector *Collector
+}
+
+func (s *metricsService) Export(ctx context.Context, msg *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	return &colmetricpb.ExportMetricsServiceResponse{}, s.collector.receiveGRPC(ctx, Request{
+		Signal:  domain.SignalMetrics,
+		Path:    "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export",
+		Metrics: msg,
+	})
+}
+
+type logsService struct {
+	collogspb.UnimplementedLogsServiceServer
+	collector *Collector
+}
+
+func (s *logsService) Export(ctx context.Context, msg *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	return &collogspb.ExportLogsServiceResponse{}, s.collector.receiveGRPC(ctx, Request{
+		Signal: domain.SignalLogs,
+		Path:   "/opentelemetry.proto.collector.logs.v1.LogsService/Export",
+		Logs:   msg,
+	})
+}
+
+// receiveGRPC records a gRPC export and returns the status error of an applied fault
+func (c *Collector) receiveGRPC(ctx context.Context, req Request) error {
+	req.Protocol = domain.ProtocolGRPC
+	req.Headers = http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			req.Headers.Add(key, value)
+		}
+	}
+
+	fault := c.receive(req)
+	if fault == nil {
+		return nil
+	}
+	fault.wait(ctx)
+	if fault.Status == 0 {
+		return nil
+	}
+
+	st := status.New(grpcCode(fault.Status), http.StatusText(fault.Status))
+	if fault.RetryAfter > 0 {
+		if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(fault.RetryAfter)}); err == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// grpcCode maps an HTTP status to the gRPC code a collector would answer with
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+func containsSignal(signals []domain.SignalType, signal domain.SignalType) bool {
+	for _, s := range signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the request for test failure messages.
+func (r Request) String() string {
+	return fmt.Sprintf("%s %s %s (%d)", r.Protocol, r.Signal, r.Path, r.Status)
+}
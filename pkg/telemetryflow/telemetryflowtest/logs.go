@@ -0,0 +1,133 @@
+// Package telemetryflowtest provides in-memory log recording for TelemetryFlow SDK tests.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Log is a log record recorded by the Kit
+type Log struct {
+	Body           string
+	Severity       string // severity text, e.g. "info"
+	SeverityNumber int
+	Attributes     map[string]interface{}
+	TraceID        string // empty if the record is not correlated with a span
+	SpanID         string
+	Timestamp      time.Time
+}
+
+// logRecorder is a log processor that keeps emitted records in memory
+type logRecorder struct {
+	mu   sync.Mutex
+	logs []Log
+}
+
+func (r *logRecorder) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+
+func (r *logRecorder) OnEmit(_ context.Context, record *sdklog.Record) error {
+	entry := Log{
+		Body:           logValueString(record.Body()),
+		Severity:       record.SeverityText(),
+		SeverityNumber: int(record.Severity()),
+		Attributes:     make(map[string]interface{}, record.AttributesLen()),
+		Timestamp:      record.Timestamp(),
+	}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		entry.Attributes[kv.Key] = logValue(kv.Value)
+		return true
+	})
+	if record.TraceID().IsValid() {
+		entry.TraceID = record.TraceID().String()
+	}
+	if record.SpanID().IsValid() {
+		entry.SpanID = record.SpanID().String()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, entry)
+	return nil
+}
+
+func (r *logRecorder) Shutdown(context.Context) error   { return nil }
+func (r *logRecorder) ForceFlush(context.Context) error { return nil }
+
+func (r *logRecorder) snapshot() []Log {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Log(nil), r.logs...)
+}
+
+func (r *logRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = nil
+}
+
+// Logs returns the emitted log records in order.
+func (k *Kit) Logs() []Log { return k.logs.snapshot() }
+
+// AssertLog fails the test unless a log record has the given body and at least the given
+// attributes, and returns the first such record.
+func (k *Kit) AssertLog(body string, attrs map[string]interface{}) Log {
+	k.t.Helper()
+
+	logs := k.logs.snapshot()
+	for _, entry := range logs {
+		if entry.Body == body && attributesMatch(entry.Attributes, attrs) {
+			return entry
+		}
+	}
+
+	bodies := make([]string, 0, len(logs))
+	for _, entry := range logs {
+		bodies = append(bodies, entry.Body)
+	}
+	k.t.Errorf("telemetryflowtest: no log %q with attributes %v; recorded logs: %q", body, attrs, bodies)
+	return Log{}
+}
+
+// logValue converts a log attribute value to a plain Go value
+func logValue(v otellog.Value) interface{} {
+	switch v.Kind() {
+	case otellog.KindString:
+		return v.AsString()
+	case otellog.KindInt64:
+		return v.AsInt64()
+	case otellog.KindFloat64:
+		return v.AsFloat64()
+	case otellog.KindBool:
+		return v.AsBool()
+	default:
+		return v.String()
+	}
+}
+
+func logValueString(v otellog.Value) string {
+	if v.Kind() == otellog.KindString {
+		return v.AsString()
+	}
+	return v.String()
+}
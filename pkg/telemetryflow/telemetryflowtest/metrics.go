@@ -0,0 +1,185 @@
+// Package telemetryflowtest provides in-memory metric recording for TelemetryFlow SDK tests.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Metric is a metric recorded by the Kit, with one point per attribute set.
+// Counter and histogram points accumulate everything recorded since the last Reset.
+type Metric struct {
+	Name        string
+	Description string
+	Unit        string
+	Type        string // counter, updowncounter, gauge or histogram
+	Points      []Point
+}
+
+// Point is the value of a metric for one attribute set
+type Point struct {
+	Attributes map[string]interface{}
+	Value      float64 // sum for counters and histograms, last value for gauges
+	Count      uint64  // number of recorded values (histograms only)
+}
+
+// Point returns the first point that has at least the given attributes.
+func (m Metric) Point(attrs map[string]interface{}) (Point, bool) {
+	for _, point := range m.Points {
+		if attributesMatch(point.Attributes, attrs) {
+			return point, true
+		}
+	}
+	return Point{}, false
+}
+
+// Total returns the sum of the values of all points.
+func (m Metric) Total() float64 {
+	var total float64
+	for _, point := range m.Points {
+		total += point.Value
+	}
+	return total
+}
+
+// Metrics collects pending measurements and returns all recorded metrics in the order
+// they were first seen.
+func (k *Kit) Metrics() []Metric {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// Collection fails once the client is shut down; the accumulated metrics stay readable
+	var rm metricdata.ResourceMetrics
+	if err := k.reader.Collect(context.Background(), &rm); err == nil {
+		k.metrics.merge(rm)
+	}
+	return k.metrics.snapshot()
+}
+
+// FindMetric returns the recorded metric with the given name.
+func (k *Kit) FindMetric(name string) (Metric, bool) {
+	for _, metric := range k.Metrics() {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+	return Metric{}, false
+}
+
+// metricStore accumulates delta collections into per-attribute-set points
+type metricStore struct {
+	order   []string
+	metrics map[string]*Metric
+	points  map[string]map[attribute.Distinct]int
+}
+
+func newMetricStore() *metricStore {
+	return &metricStore{
+		metrics: make(map[string]*Metric),
+		points:  make(map[string]map[attribute.Distinct]int),
+	}
+}
+
+func (s *metricStore) merge(rm metricdata.ResourceMetrics) {
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				mergeSum(s, m, data)
+			case metricdata.Sum[float64]:
+				mergeSum(s, m, data)
+			case metricdata.Gauge[int64]:
+				mergeGauge(s, m, data)
+			case metricdata.Gauge[float64]:
+				mergeGauge(s, m, data)
+			case metricdata.Histogram[int64]:
+				mergeHistogram(s, m, data)
+			case metricdata.Histogram[float64]:
+				mergeHistogram(s, m, data)
+			}
+		}
+	}
+}
+
+func mergeSum[N int64 | float64](s *metricStore, m metricdata.Metrics, data metricdata.Sum[N]) {
+	kind := "updowncounter"
+	if data.IsMonotonic {
+		kind = "counter"
+	}
+	for _, dp := range data.DataPoints {
+		point := s.point(m, kind, dp.Attributes)
+		if data.Temporality == metricdata.DeltaTemporality {
+			point.Value += float64(dp.Value)
+		} else {
+			point.Value = float64(dp.Value)
+		}
+	}
+}
+
+func mergeGauge[N int64 | float64](s *metricStore, m metricdata.Metrics, data metricdata.Gauge[N]) {
+	for _, dp := range data.DataPoints {
+		s.point(m, "gauge", dp.Attributes).Value = float64(dp.Value)
+	}
+}
+
+func mergeHistogram[N int64 | float64](s *metricStore, m metricdata.Metrics, data metricdata.Histogram[N]) {
+	for _, dp := range data.DataPoints {
+		point := s.point(m, "histogram", dp.Attributes)
+		if data.Temporality == metricdata.DeltaTemporality {
+			point.Value += float64(dp.Sum)
+			point.Count += dp.Count
+		} else {
+			point.Value = float64(dp.Sum)
+			point.Count = dp.Count
+		}
+	}
+}
+
+// point returns the point of metric m for the attribute set, creating both if needed
+func (s *metricStore) point(m metricdata.Metrics, kind string, attrs attribute.Set) *Point {
+	metric, ok := s.metrics[m.Name]
+	if !ok {
+		metric = &Metric{Name: m.Name, Description: m.Description, Unit: m.Unit, Type: kind}
+		s.metrics[m.Name] = metric
+		s.points[m.Name] = make(map[attribute.Distinct]int)
+		s.order = append(s.order, m.Name)
+	}
+
+	key := attrs.Equivalent()
+	index, ok := s.points[m.Name][key]
+	if !ok {
+		index = len(metric.Points)
+		metric.Points = append(metric.Points, Point{Attributes: attributeMap(attrs.ToSlice())})
+		s.points[m.Name][key] = index
+	}
+	return &metric.Points[index]
+}
+
+func (s *metricStore) snapshot() []Metric {
+	metrics := make([]Metric, 0, len(s.order))
+	for _, name := range s.order {
+		metric := *s.metrics[name]
+		metric.Points = append([]Point(nil), metric.Points...)
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
@@ -0,0 +1,205 @@
+// Package telemetryflowtest provides in-memory span recording for TelemetryFlow SDK tests.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Span is a finished span recorded by the Kit
+type Span struct {
+	Name          string
+	Kind          string // internal, server, client, producer or consumer
+	TraceID       string
+	SpanID        string
+	ParentSpanID  string // empty for root spans
+	Attributes    map[string]interface{}
+	Events        []SpanEvent
+	Links         []SpanLink
+	StatusCode    string // Unset, Error or Ok
+	StatusMessage string
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// SpanEvent is an event added to a recorded span
+type SpanEvent struct {
+	Name       string
+	Attributes map[string]interface{}
+	Time       time.Time
+}
+
+// SpanLink is a link from a recorded span to another span
+type SpanLink struct {
+	TraceID    string
+	SpanID     string
+	Attributes map[string]interface{}
+}
+
+// Duration returns how long the span ran.
+func (s Span) Duration() time.Duration { return s.EndTime.Sub(s.StartTime) }
+
+// IsRoot returns true if the span has no parent.
+func (s Span) IsRoot() bool { return s.ParentSpanID == "" }
+
+// spanRecorder is a span processor that keeps finished spans in memory
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+func (r *spanRecorder) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (r *spanRecorder) OnEnd(s sdktrace.ReadOnlySpan) {
+	span := Span{
+		Name:          s.Name(),
+		Kind:          s.SpanKind().String(),
+		TraceID:       s.SpanContext().TraceID().String(),
+		SpanID:        s.SpanContext().SpanID().String(),
+		Attributes:    attributeMap(s.Attributes()),
+		StatusCode:    s.Status().Code.String(),
+		StatusMessage: s.Status().Description,
+		StartTime:     s.StartTime(),
+		EndTime:       s.EndTime(),
+	}
+	if s.Parent().IsValid() {
+		span.ParentSpanID = s.Parent().SpanID().String()
+	}
+	for _, event := range s.Events() {
+		span.Events = append(span.Events, SpanEvent{
+			Name:       event.Name,
+			Attributes: attributeMap(event.Attributes),
+			Time:       event.Time,
+		})
+	}
+	for _, link := range s.Links() {
+		span.Links = append(span.Links, SpanLink{
+			TraceID:    link.SpanContext.TraceID().String(),
+			SpanID:     link.SpanContext.SpanID().String(),
+			Attributes: attributeMap(link.Attributes),
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func (r *spanRecorder) Shutdown(context.Context) error   { return nil }
+func (r *spanRecorder) ForceFlush(context.Context) error { return nil }
+
+func (r *spanRecorder) snapshot() []Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Span(nil), r.spans...)
+}
+
+func (r *spanRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = nil
+}
+
+// Spans returns the finished spans in the order they ended.
+func (k *Kit) Spans() []Span { return k.spans.snapshot() }
+
+// FindSpan returns the first finished span with the given name.
+func (k *Kit) FindSpan(name string) (Span, bool) {
+	for _, span := range k.spans.snapshot() {
+		if span.Name == name {
+			return span, true
+		}
+	}
+	return Span{}, false
+}
+
+// AssertSpan fails the test unless a finished span has the given name and at least the
+// given attributes, and returns the first such span.
+func (k *Kit) AssertSpan(name string, attrs map[string]interface{}) Span {
+	k.t.Helper()
+
+	spans := k.spans.snapshot()
+	for _, span := range spans {
+		if span.Name == name && attributesMatch(span.Attributes, attrs) {
+			return span
+		}
+	}
+
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	k.t.Errorf("telemetryflowtest: no span %q with attributes %v; recorded spans: %v", name, attrs, names)
+	return Span{}
+}
+
+// Roots returns the finished spans without a recorded parent.
+func (k *Kit) Roots() []Span {
+	spans := k.spans.snapshot()
+	recorded := make(map[string]bool, len(spans))
+	for _, span := range spans {
+		recorded[span.SpanID] = true
+	}
+
+	var roots []Span
+	for _, span := range spans {
+		if span.IsRoot() || !recorded[span.ParentSpanID] {
+			roots = append(roots, span)
+		}
+	}
+	return roots
+}
+
+// Children returns the finished direct children of parent.
+func (k *Kit) Children(parent Span) []Span {
+	var children []Span
+	for _, span := range k.spans.snapshot() {
+		if span.ParentSpanID == parent.SpanID && span.TraceID == parent.TraceID {
+			children = append(children, span)
+		}
+	}
+	return children
+}
+
+// Parent returns the recorded parent of span.
+func (k *Kit) Parent(span Span) (Span, bool) {
+	if span.IsRoot() {
+		return Span{}, false
+	}
+	for _, candidate := range k.spans.snapshot() {
+		if candidate.SpanID == span.ParentSpanID && candidate.TraceID == span.TraceID {
+			return candidate, true
+		}
+	}
+	return Span{}, false
+}
+
+// attributeMap converts OpenTelemetry attributes to plain Go values
+func attributeMap(attrs []attribute.KeyValue) map[string]interface{} {
+	result := make(map[string]interface{}, len(attrs))
+	for _, attr := range attrs {
+		result[string(attr.Key)] = attr.Value.AsInterface()
+	}
+	return result
+}
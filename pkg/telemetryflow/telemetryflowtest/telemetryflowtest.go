@@ -0,0 +1,169 @@
+// Package telemetryflowtest provides an in-memory TelemetryFlow client for application tests.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// Option customizes the builder used by New and NewClient, e.g. to set resource
+// attributes
+type Option func(b *telemetryflow.Builder)
+
+// Kit is an initialized Client whose spans, metrics and logs are recorded in memory.
+// Nothing is sent over the network unless an Option enables an exporter.
+type Kit struct {
+	t       testing.TB
+	client  *telemetryflow.Client
+	spans   *spanRecorder
+	reader  *sdkmetric.ManualReader
+	logs    *logRecorder
+	mu      sync.Mutex
+	metrics *metricStore
+}
+
+// New builds and initializes an in-memory Client for t. It is shut down when the test ends.
+func New(t testing.TB, opts ...Option) *Kit {
+	t.Helper()
+
+	kit := &Kit{
+		t:     t,
+		spans: &spanRecorder{},
+		reader: sdkmetric.NewManualReader(sdkmetric.WithTemporalitySelector(
+			func(sdkmetric.InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality },
+		)),
+		logs:    &logRecorder{},
+		metrics: newMetricStore(),
+	}
+
+	builder := telemetryflow.NewBuilder().
+		WithService("test-service", "0.0.0").
+		WithExporters().
+		WithSpanProcessor(kit.spans).
+		WithMetricReader(kit.reader).
+		WithLogProcessor(kit.logs)
+	for _, opt := range opts {
+		opt(builder)
+	}
+
+	client, err := builder.Build()
+	if err != nil {
+		t.Fatalf("telemetryflowtest: failed to build client: %v", err)
+	}
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatalf("telemetryflowtest: failed to initialize client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Shutdown(context.Background()) })
+
+	kit.client = client
+	return kit
+}
+
+// NewClient builds and initializes a Client exporting to endpoint, e.g. a test server
+// standing in for a collector. Retries are disabled so failures surface at once; opts are
+// applied last and can enable them again. The client is shut down when the test ends.
+func NewClient(t testing.TB, endpoint string, opts ...Option) *telemetryflow.Client {
+	t.Helper()
+
+	builder := telemetryflow.NewBuilder().
+		WithAPIKey("tfk_test", "tfs_secret").
+		WithEndpoint(endpoint).
+		WithInsecure(true).
+		WithService("test-service", "0.0.0").
+		WithRetry(false, 0, 0)
+	for _, opt := range opts {
+		opt(builder)
+	}
+
+	client, err := builder.Build()
+	if err != nil {
+		t.Fatalf("telemetryflowtest: failed to build client: %v", err)
+	}
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatalf("telemetryflowtest: failed to initialize client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Shutdown(context.Background()) })
+	return client
+}
+
+// WithService sets the service name and version reported by the test client
+func WithService(name, version string) Option {
+	return func(b *telemetryflow.Builder) { b.WithService(name, version) }
+}
+
+// WithExporters also sends telemetry to the given exporters, e.g. domain.ExporterConsole
+// to see what a failing test recorded
+func WithExporters(exporters ...domain.ExporterType) Option {
+	return func(b *telemetryflow.Builder) { b.WithExporters(exporters...) }
+}
+
+// Client returns the client under test.
+func (k *Kit) Client() *telemetryflow.Client { return k.client }
+
+// Reset discards everything recorded so far.
+func (k *Kit) Reset() {
+	k.spans.reset()
+	k.logs.reset()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var rm metricdata.ResourceMetrics
+	_ = k.reader.Collect(context.Background(), &rm)
+	k.metrics = newMetricStore()
+}
+
+// attributesMatch returns true if actual contains every expected attribute.
+// Integer and float values compare by value, so 200 matches a recorded int64(200).
+func attributesMatch(actual, expected map[string]interface{}) bool {
+	for key, want := range expected {
+		got, ok := actual[key]
+		if !ok || !reflect.DeepEqual(normalize(got), normalize(want)) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize maps numeric values to int64 or float64 and other unsupported types to their
+// string form, mirroring how the SDK converts attributes
+func normalize(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String, reflect.Bool, reflect.Slice:
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,231 @@
+// Package tfslog provides a log/slog handler that exports records through a TelemetryFlow Client.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Usage:
+//
+//	logger := slog.New(tfslog.NewHandler(client, &tfslog.Options{
+//	    Level: slog.LevelDebug,
+//	    Tee:   slog.NewTextHandler(os.Stdout, nil),
+//	}))
+//	logger.InfoContext(ctx, "order placed", "order.id", id)
+package tfslog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"runtime"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+)
+
+// Options configures a Handler
+type Options struct {
+	// Level is the minimum level of exported records (default: slog.LevelInfo)
+	Level slog.Leveler
+	// Tee also passes every record to this handler, e.g. a slog.TextHandler writing to
+	// stdout. It filters records with its own level.
+	Tee slog.Handler
+	// AddSource adds the code.filepath, code.lineno and code.function attributes of
+	// the call site to exported records
+	AddSource bool
+}
+
+// Handler is a slog.Handler exporting records as TelemetryFlow log records. Levels map
+// to OTLP severities, attributes of groups are prefixed with the group names, e.g.
+// "http.method", and records logged with a context carrying a span are correlated
+// with its trace and span IDs.
+type Handler struct {
+	client *telemetryflow.Client
+	opts   Options
+	prefix string       // group prefix of new attributes, e.g. "http."
+	attrs  []keyValue   // attributes added with WithAttrs, already prefixed
+	tee    slog.Handler // opts.Tee with the same attributes and groups
+}
+
+// keyValue is a flattened attribute
+type keyValue struct {
+	key   string
+	value interface{}
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler returns a handler exporting records through client. opts may be nil.
+func NewHandler(client *telemetryflow.Client, opts *Options) *Handler {
+	h := &Handler{client: client}
+	if opts != nil {
+		h.opts = *opts
+	}
+	h.tee = h.opts.Tee
+	return h
+}
+
+// Enabled reports whether records of level are exported or passed to the tee handler
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.exports(level) || (h.tee != nil && h.tee.Enabled(ctx, level))
+}
+
+func (h *Handler) exports(level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle exports r and passes it to the tee handler. Records passed to the tee handler
+// get trace_id and span_id attributes when ctx carries a span, so local output can be
+// matched with the exported records.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	if h.exports(r.Level) {
+		if err := h.client.LogAt(ctx, Severity(r.Level), r.Message, h.attributes(r), r.Time); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if h.tee != nil && h.tee.Enabled(ctx, r.Level) {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			r = r.Clone()
+			r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+		}
+		if err := h.tee.Handle(ctx, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = append([]keyValue(nil), h.attrs...)
+	for _, attr := range attrs {
+		h2.attrs = appendAttr(h2.attrs, h.prefix, attr)
+	}
+	if h.tee != nil {
+		h2.tee = h.tee.WithAttrs(attrs)
+	}
+	return &h2
+}
+
+// WithGroup returns a handler prefixing the keys of later attributes with name
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	if h.tee != nil {
+		h2.tee = h.tee.WithGroup(name)
+	}
+	return &h2
+}
+
+// attributes returns the attributes of r with those of the handler
+func (h *Handler) attributes(r slog.Record) map[string]interface{} {
+	// A fresh slice: appending to h.attrs would write into its spare capacity, shared by
+	// every record logged through the handler
+	attrs := append(make([]keyValue, 0, len(h.attrs)+r.NumAttrs()+3), h.attrs...)
+	r.Attrs(func(attr slog.Attr) bool {
+		attrs = appendAttr(attrs, h.prefix, attr)
+		return true
+	})
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		attrs = append(attrs,
+			keyValue{"code.filepath", frame.File},
+			keyValue{"code.lineno", frame.Line},
+			keyValue{"code.function", frame.Function},
+		)
+	}
+
+	result := make(map[string]interface{}, len(attrs))
+	for _, kv := range attrs {
+		result[kv.key] = kv.value
+	}
+	return result
+}
+
+// appendAttr appends attr with its key prefixed. Groups are flattened, so the
+// attributes of group "http" are prefixed with "http."; groups without a key are
+// inlined and empty attributes are dropped, as slog.Handler requires.
+func appendAttr(attrs []keyValue, prefix string, attr slog.Attr) []keyValue {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			attrs = appendAttr(attrs, prefix, groupAttr)
+		}
+		return attrs
+	}
+	return append(attrs, keyValue{prefix + attr.Key, value(attr.Value)})
+}
+
+// value converts a slog value to a value supported by the log attributes of Client
+func value(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		if n := v.Uint64(); n <= math.MaxInt64 {
+			return int64(n)
+		}
+		return v.String()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		return v.String()
+	default:
+		return v.String()
+	}
+}
+
+// Severity returns the OTLP severity name of a slog level. slog.LevelDebug, LevelInfo,
+// LevelWarn and LevelError map to "debug", "info", "warn" and "error", the levels in
+// between to numbered severities, e.g. slog.LevelInfo+1 to "info2", levels below debug
+// to the trace severities and levels above error+3 to the fatal severities.
+func Severity(level slog.Level) string {
+	// The slog levels are 4 apart like the OTLP severities, with LevelInfo at 0 and
+	// SeverityInfo at 9
+	n := min(max(int(level)+int(otellog.SeverityInfo), int(otellog.SeverityTrace1)), int(otellog.SeverityFatal4))
+	return strings.ToLower(otellog.Severity(n).String())
+}
@@ -0,0 +1,273 @@
+// Package tfzap provides a zap core that exports entries through a TelemetryFlow Client.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Usage:
+//
+//	logger = logger.WithOptions(tfzap.WrapCore(client, nil))
+//	logger.Info("order placed", append(tfzap.TraceFields(ctx), zap.String("order.id", id))...)
+package tfzap
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+// Field keys correlating an entry with a span, as added by TraceFields
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// Options configures a core
+type Options struct {
+	// Level enables the exported levels (default: zapcore.InfoLevel)
+	Level zapcore.LevelEnabler
+	// Sampling limits the exported entries with the same level and message. Nil
+	// exports every entry.
+	Sampling *Sampling
+}
+
+// Sampling exports the first First entries with the same level and message within each
+// Tick, then every Thereafter-th of them
+type Sampling struct {
+	Tick       time.Duration
+	First      int
+	Thereafter int
+}
+
+// core is a zapcore.Core exporting entries as TelemetryFlow log records
+type core struct {
+	client *telemetryflow.Client
+	level  zapcore.LevelEnabler
+	fields []zapcore.Field // fields added with With
+}
+
+// NewCore returns a core exporting entries through client. Levels map to OTLP
+// severities and fields become log attributes, with the keys of objects and namespaces
+// prefixed with their names, e.g. "http.method". Entries with valid trace_id and span_id
+// fields are correlated with that span. opts may be nil.
+func NewCore(client *telemetryflow.Client, opts *Options) zapcore.Core {
+	if opts == nil {
+		opts = &Options{}
+	}
+	level := opts.Level
+	if level == nil {
+		level = zapcore.InfoLevel
+	}
+	var c zapcore.Core = &core{client: client, level: level}
+	if s := opts.Sampling; s != nil {
+		c = zapcore.NewSamplerWithOptions(c, s.Tick, s.First, s.Thereafter)
+	}
+	return c
+}
+
+// WrapCore returns a zap option that keeps the logger's core and also exports its
+// entries through client, so existing loggers only need
+// logger.WithOptions(tfzap.WrapCore(client, nil)).
+func WrapCore(client *telemetryflow.Client, opts *Options) zap.Option {
+	return zap.WrapCore(func(existing zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(existing, NewCore(client, opts))
+	})
+}
+
+// TraceFields returns the trace_id and span_id fields of the span in ctx, or nil when
+// ctx carries no span. The core uses them to correlate the entry; other cores, e.g. a
+// console encoder, log them as plain fields.
+func TraceFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String(TraceIDKey, sc.TraceID().String()),
+		zap.String(SpanIDKey, sc.SpanID().String()),
+	}
+}
+
+// Severity returns the OTLP severity name of a zap level. DPanic, Panic and Fatal map
+// to "fatal", "fatal2" and "fatal3".
+func Severity(level zapcore.Level) string {
+	switch {
+	case level < zapcore.DebugLevel:
+		return "trace"
+	case level == zapcore.DebugLevel:
+		return "debug"
+	case level == zapcore.InfoLevel:
+		return "info"
+	case level == zapcore.WarnLevel:
+		return "warn"
+	case level == zapcore.ErrorLevel:
+		return "error"
+	case level == zapcore.DPanicLevel:
+		return "fatal"
+	case level == zapcore.PanicLevel:
+		return "fatal2"
+	default:
+		return "fatal3"
+	}
+}
+
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field(nil), c.fields...), fields...)
+	return &clone
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	ctx := context.Background()
+	if sc, ok := spanContext(enc.Fields); ok {
+		ctx = trace.ContextWithSpanContext(ctx, sc)
+		delete(enc.Fields, TraceIDKey)
+		delete(enc.Fields, SpanIDKey)
+	}
+
+	attrs := make(map[string]interface{}, len(enc.Fields)+5)
+	flatten(attrs, "", enc.Fields)
+	if entry.LoggerName != "" {
+		attrs[domain.LoggerNameAttribute] = entry.LoggerName
+	}
+	if entry.Caller.Defined {
+		attrs["code.filepath"] = entry.Caller.File
+		attrs["code.lineno"] = entry.Caller.Line
+		if entry.Caller.Function != "" {
+			attrs["code.function"] = entry.Caller.Function
+		}
+	}
+	if entry.Stack != "" {
+		attrs["code.stacktrace"] = entry.Stack
+	}
+
+	return c.client.LogAt(ctx, Severity(entry.Level), entry.Message, attrs, entry.Time)
+}
+
+// Sync flushes the telemetry buffered by the client
+func (c *core) Sync() error {
+	return c.client.Flush(context.Background())
+}
+
+// spanContext returns the span context of the trace_id and span_id fields
+func spanContext(fields map[string]interface{}) (trace.SpanContext, bool) {
+	traceHex, _ := fields[TraceIDKey].(string)
+	spanHex, _ := fields[SpanIDKey].(string)
+	traceID, err := trace.TraceIDFromHex(traceHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := trace.SpanIDFromHex(spanHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}), true
+}
+
+// flatten adds the encoded fields to attrs, prefixing the keys of objects and
+// namespaces with their names
+func flatten(attrs map[string]interface{}, prefix string, fields map[string]interface{}) {
+	for key, v := range fields {
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(attrs, prefix+key+".", nested)
+			continue
+		}
+		attrs[prefix+key] = value(v)
+	}
+}
+
+// value converts an encoded field value to a value supported by the log attributes of
+// Client
+func value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string, bool, int64, float64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v)
+		}
+		return fmt.Sprint(v)
+	case uint:
+		return value(uint64(v))
+	case uint32:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uintptr:
+		return fmt.Sprintf("0x%x", v)
+	case float32:
+		return float64(v)
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		// Arrays and reflected values
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,77 @@
+// Package integration provides integration tests for the TelemetryFlow SDK.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// TestClientWithFakeCollector runs the full export path against an in-process collector,
+// so it needs neither docker-compose nor OTLP_COLLECTOR_AVAILABLE
+func TestClientWithFakeCollector(t *testing.T) {
+	skipInShortMode(t)
+
+	for _, protocol := range []domain.Protocol{domain.ProtocolGRPC, domain.ProtocolHTTP} {
+		t.Run("should deliver every signal over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			endpoint := collector.GRPCEndpoint()
+			if protocol == domain.ProtocolHTTP {
+				endpoint = collector.HTTPEndpoint()
+			}
+
+			client, err := telemetryflow.NewBuilder().
+				WithAPIKey("tfk_integration", "tfs_secret").
+				WithEndpoint(endpoint).
+				WithProtocol(protocol).
+				WithInsecure(true).
+				WithService("integration-test", "1.0.0").
+				WithEnvironment("test").
+				Build()
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			require.NoError(t, client.Initialize(ctx))
+
+			spanID, err := client.StartSpan(ctx, "integration-span", "server", map[string]interface{}{"test": true})
+			require.NoError(t, err)
+			require.NoError(t, client.IncrementCounter(ctx, "integration.requests", 1, nil))
+			require.NoError(t, client.LogInfo(ctx, "integration log", nil))
+			require.NoError(t, client.EndSpan(ctx, spanID, nil))
+			require.NoError(t, client.Shutdown(ctx))
+
+			require.Len(t, collector.Spans(), 1)
+			assert.Equal(t, "integration-span", collector.Spans()[0].Name)
+			assert.NotEmpty(t, collector.Metrics())
+			require.Len(t, collector.LogRecords(), 1)
+			assert.Equal(t, "integration log", collector.LogRecords()[0].GetBody().GetStringValue())
+
+			for _, req := range collector.Requests() {
+				assert.Equal(t, "tfk_integration", req.Headers.Get("X-TelemetryFlow-Key-ID"), req.String())
+			}
+		})
+	}
+}
@@ -229,6 +229,11 @@ func TestGeneratorInit(t *testing.T) {
 		assert.FileExists(t, filepath.Join(logsDir, "logs.go"))
 		assert.FileExists(t, filepath.Join(tracesDir, "traces.go"))
 		assert.FileExists(t, filepath.Join(tmpDir, ".env.telemetryflow"))
+
+		// Runtime metrics are on by default in generated code
+		initContent, err := os.ReadFile(filepath.Join(telemetryDir, "init.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(initContent), "WithRuntimeMetrics()")
 	})
 }
 
@@ -32,7 +32,8 @@ type ExportedData struct {
 	Attributes map[string]interface{}
 }
 
-// MockExporter is a mock implementation of an OTLP exporter
+// MockExporter is a mock implementation of an OTLP exporter. The SDK never calls it; to
+// assert on the telemetry a client actually emits, use pkg/telemetryflow/telemetryflowtest.
 type MockExporter struct {
 	mock.Mock
 	mu sync.RWMutex
@@ -19,6 +19,7 @@
 package application_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -189,6 +190,47 @@ func TestEmitBatchLogsCommand(t *testing.T) {
 	})
 }
 
+func TestRecordMetricsCommand(t *testing.T) {
+	t.Run("should create batch metrics command", func(t *testing.T) {
+		cmd := &application.RecordMetricsCommand{
+			Measurements: []application.Measurement{
+				{Name: "queue.depth", Value: 12},
+				{Kind: "counter", Name: "rows.total", Value: 3},
+				{Kind: "histogram", Name: "row.size", Value: 512, Unit: "By"},
+			},
+		}
+
+		assert.Len(t, cmd.Measurements, 3)
+		assert.Empty(t, cmd.Measurements[0].Kind)
+		assert.Equal(t, "counter", cmd.Measurements[1].Kind)
+		assert.Equal(t, "By", cmd.Measurements[2].Unit)
+	})
+}
+
+func TestBatchResult(t *testing.T) {
+	t.Run("should have no error when every item was accepted", func(t *testing.T) {
+		result := &application.BatchResult{Accepted: 3}
+
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("should join the errors of rejected items", func(t *testing.T) {
+		errTooOld := errors.New("too old")
+		result := &application.BatchResult{
+			Accepted: 1,
+			Errors: []application.BatchError{
+				{Index: 1, Err: errTooOld},
+				{Index: 2, Err: errors.New("invalid")},
+			},
+		}
+
+		err := result.Err()
+		assert.ErrorIs(t, err, errTooOld)
+		assert.ErrorContains(t, err, "item 1: too old")
+		assert.ErrorContains(t, err, "item 2: invalid")
+	})
+}
+
 func TestStartSpanCommand(t *testing.T) {
 	t.Run("should create span command with all fields", func(t *testing.T) {
 		cmd := &application.StartSpanCommand{
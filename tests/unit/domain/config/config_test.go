@@ -19,6 +19,8 @@
 package domain_test
 
 import (
+	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -604,6 +606,412 @@ func TestTelemetryConfig_TFOv2FullChaining(t *testing.T) {
 	})
 }
 
+func TestTelemetryConfig_WithFailoverEndpoints(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should have no failover endpoints by default", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		assert.False(t, config.IsFailoverEnabled())
+		assert.Equal(t, []string{"localhost:4317"}, config.Endpoints())
+		assert.Equal(t, 30*time.Second, config.FailoverProbeInterval())
+	})
+
+	t.Run("should list endpoints in priority order", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithFailoverEndpoints("tfo-agent:4317", "collector.region.telemetryflow.id:4317")
+
+		assert.True(t, config.IsFailoverEnabled())
+		assert.Equal(t, []string{
+			"localhost:4317",
+			"tfo-agent:4317",
+			"collector.region.telemetryflow.id:4317",
+		}, config.Endpoints())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should reject empty failover endpoint", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithFailoverEndpoints("tfo-agent:4317", "")
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failover endpoint")
+	})
+
+	t.Run("should reject failover endpoint equal to primary", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithFailoverEndpoints("localhost:4317")
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicates")
+	})
+
+	t.Run("should reject non-positive probe interval", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithFailoverEndpoints("tfo-agent:4317").WithFailoverProbeInterval(0)
+
+		require.Error(t, config.Validate())
+	})
+}
+
+func TestTelemetryConfig_WithSignalSettings(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should fall back to shared settings", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		for _, signal := range []domain.SignalType{domain.SignalTraces, domain.SignalMetrics, domain.SignalLogs} {
+			assert.Equal(t, "localhost:4317", config.SignalEndpoint(signal))
+			assert.Equal(t, domain.ProtocolGRPC, config.SignalProtocol(signal))
+			assert.True(t, config.IsSignalCompressionEnabled(signal))
+			assert.Equal(t, 30*time.Second, config.SignalTimeout(signal))
+			assert.Empty(t, config.SignalHeaders(signal))
+		}
+	})
+
+	t.Run("should override settings for one signal only", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.
+			WithSignalEndpoint(domain.SignalMetrics, "metrics-collector:4318").
+			WithSignalProtocol(domain.SignalMetrics, domain.ProtocolHTTP).
+			WithSignalHeader(domain.SignalMetrics, "x-team", "platform").
+			WithSignalCompression(domain.SignalMetrics, false).
+			WithSignalTimeout(domain.SignalMetrics, 5*time.Second)
+
+		assert.Equal(t, "metrics-collector:4318", config.SignalEndpoint(domain.SignalMetrics))
+		assert.Equal(t, domain.ProtocolHTTP, config.SignalProtocol(domain.SignalMetrics))
+		assert.Equal(t, map[string]string{"x-team": "platform"}, config.SignalHeaders(domain.SignalMetrics))
+		assert.False(t, config.IsSignalCompressionEnabled(domain.SignalMetrics))
+		assert.Equal(t, 5*time.Second, config.SignalTimeout(domain.SignalMetrics))
+
+		assert.Equal(t, "localhost:4317", config.SignalEndpoint(domain.SignalTraces))
+		assert.Equal(t, domain.ProtocolGRPC, config.SignalProtocol(domain.SignalTraces))
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should not apply failover endpoints to a signal with its own endpoint", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.
+			WithFailoverEndpoints("tfo-agent:4317").
+			WithSignalEndpoint(domain.SignalLogs, "logs-collector:4317")
+
+		assert.Equal(t, []string{"localhost:4317", "tfo-agent:4317"}, config.SignalEndpoints(domain.SignalTraces))
+		assert.Equal(t, []string{"logs-collector:4317"}, config.SignalEndpoints(domain.SignalLogs))
+	})
+
+	t.Run("should reject unsupported signal protocol", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithSignalProtocol(domain.SignalTraces, domain.Protocol("thrift"))
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported protocol")
+	})
+
+	t.Run("should reject negative signal timeout", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithSignalTimeout(domain.SignalLogs, -time.Second)
+
+		require.Error(t, config.Validate())
+	})
+
+	t.Run("should not carry signal settings to destinations", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithSignalEndpoint(domain.SignalTraces, "traces-collector:4317")
+		dest, _ := domain.NewDestination("otel", "otel-collector:4317")
+
+		derived := config.ForDestination(dest)
+
+		assert.Equal(t, "otel-collector:4317", derived.SignalEndpoint(domain.SignalTraces))
+		assert.Equal(t, "traces-collector:4317", config.SignalEndpoint(domain.SignalTraces))
+	})
+}
+
+func TestTelemetryConfig_WithHTTPTransport(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should store proxy and header settings", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "https://gateway.example.com", "my-service")
+		config.
+			WithProxy("http://proxy.corp:3128").
+			WithProxyAuth("svc", "secret").
+			WithNoProxy("localhost", ".internal").
+			WithHeader("X-Tenant-ID", "tenant-a")
+
+		username, password := config.ProxyCredentials()
+		assert.Equal(t, "http://proxy.corp:3128", config.ProxyURL())
+		assert.Equal(t, "svc", username)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, []string{"localhost", ".internal"}, config.NoProxy())
+		assert.Equal(t, map[string]string{"X-Tenant-ID": "tenant-a"}, config.Headers())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should default to protobuf encoding", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4318", "my-service")
+
+		assert.Equal(t, domain.HTTPEncodingProtobuf, config.HTTPEncoding())
+		assert.Equal(t, "application/x-protobuf", config.HTTPEncoding().ContentType())
+
+		config.WithHTTPEncoding(domain.HTTPEncodingJSON)
+		assert.Equal(t, domain.HTTPEncodingJSON, config.HTTPEncoding())
+		assert.Equal(t, "application/json", config.HTTPEncoding().ContentType())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should reject invalid combinations", func(t *testing.T) {
+		tests := map[string]func(*domain.TelemetryConfig){
+			"unsupported proxy scheme":        func(c *domain.TelemetryConfig) { c.WithProxy("ftp://proxy.corp") },
+			"proxy without host":              func(c *domain.TelemetryConfig) { c.WithProxy("http://") },
+			"proxy credentials without proxy": func(c *domain.TelemetryConfig) { c.WithProxyAuth("svc", "secret") },
+			"proxy with custom transport": func(c *domain.TelemetryConfig) {
+				c.WithProxy("http://proxy.corp:3128").WithHTTPTransport(http.DefaultTransport)
+			},
+			"client and transport": func(c *domain.TelemetryConfig) {
+				c.WithHTTPClient(&http.Client{}).WithHTTPTransport(http.DefaultTransport)
+			},
+			"unsupported encoding": func(c *domain.TelemetryConfig) { c.WithHTTPEncoding("xml") },
+		}
+
+		for name, apply := range tests {
+			config, _ := domain.NewTelemetryConfig(creds, "localhost:4318", "my-service")
+			apply(config)
+			assert.Error(t, config.Validate(), name)
+		}
+	})
+
+	t.Run("should send the destination's own headers to destinations", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4318", "my-service")
+		config.
+			WithProxy("http://proxy.corp:3128").
+			WithHeader("X-Tenant-ID", "tenant-a").
+			WithHeaderProvider(func(context.Context) (map[string]string, error) { return nil, nil })
+		plain, _ := domain.NewDestination("plain", "otel-collector:4318")
+		withHeaders, _ := domain.NewDestination("with-headers", "otel-collector:4318")
+		withHeaders.
+			WithHeader("X-Scope-OrgID", "team-b").
+			WithHeaderProvider(func(context.Context) (map[string]string, error) { return nil, nil })
+
+		derived := config.ForDestination(plain)
+		assert.Equal(t, "http://proxy.corp:3128", derived.ProxyURL())
+		assert.Empty(t, derived.Headers())
+		assert.Nil(t, derived.HeaderProvider())
+
+		derived = config.ForDestination(withHeaders)
+		assert.Equal(t, map[string]string{"X-Scope-OrgID": "team-b"}, derived.Headers())
+		assert.NotNil(t, derived.HeaderProvider())
+		assert.Equal(t, map[string]string{"X-Tenant-ID": "tenant-a"}, config.Headers())
+	})
+}
+
+func TestTelemetryConfig_WithCircuitBreaker(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should default to a disabled breaker and twice the retry backoff", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		assert.False(t, config.CircuitBreaker().Enabled())
+		assert.Equal(t, 10*time.Second, config.RetryMaxBackoff())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should store the retry cap and breaker settings", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		breaker := domain.DefaultCircuitBreakerConfig()
+		breaker.Policy = domain.BreakerBuffer
+		config.WithRetryMaxBackoff(time.Minute).WithCircuitBreaker(breaker)
+
+		assert.Equal(t, time.Minute, config.RetryMaxBackoff())
+		assert.Equal(t, breaker, config.CircuitBreaker())
+		assert.True(t, config.CircuitBreaker().Enabled())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should never cap below the initial backoff", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithRetry(true, 3, time.Minute).WithRetryMaxBackoff(time.Second)
+
+		assert.Equal(t, time.Minute, config.RetryMaxBackoff())
+	})
+
+	t.Run("should reject invalid settings", func(t *testing.T) {
+		tests := map[string]func(*domain.TelemetryConfig){
+			"negative max backoff": func(c *domain.TelemetryConfig) { c.WithRetryMaxBackoff(-time.Second) },
+			"negative threshold": func(c *domain.TelemetryConfig) {
+				c.WithCircuitBreaker(domain.CircuitBreakerConfig{FailureThreshold: -1})
+			},
+			"no cooldown": func(c *domain.TelemetryConfig) {
+				c.WithCircuitBreaker(domain.CircuitBreakerConfig{FailureThreshold: 3, Policy: domain.BreakerDrop})
+			},
+			"unknown policy": func(c *domain.TelemetryConfig) {
+				c.WithCircuitBreaker(domain.CircuitBreakerConfig{FailureThreshold: 3, Cooldown: time.Second, Policy: "retry"})
+			},
+			"empty buffer": func(c *domain.TelemetryConfig) {
+				c.WithCircuitBreaker(domain.CircuitBreakerConfig{FailureThreshold: 3, Cooldown: time.Second, Policy: domain.BreakerBuffer})
+			},
+		}
+
+		for name, apply := range tests {
+			config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+			apply(config)
+			assert.Error(t, config.Validate(), name)
+		}
+	})
+}
+
+func TestTelemetryConfig_WithMaxRequestSize(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should limit gRPC requests to the max send message size", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		assert.Zero(t, config.MaxRequestSize())
+		assert.Equal(t, 4<<20, config.RequestSizeLimit(domain.ProtocolGRPC))
+		assert.Zero(t, config.RequestSizeLimit(domain.ProtocolHTTP))
+	})
+
+	t.Run("should use the smaller of both limits", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithMaxRequestSize(1 << 20)
+
+		assert.Equal(t, 1<<20, config.RequestSizeLimit(domain.ProtocolGRPC))
+		assert.Equal(t, 1<<20, config.RequestSizeLimit(domain.ProtocolHTTP))
+
+		config.WithMaxRequestSize(64 << 20)
+		assert.Equal(t, 4<<20, config.RequestSizeLimit(domain.ProtocolGRPC))
+		assert.Equal(t, 64<<20, config.RequestSizeLimit(domain.ProtocolHTTP))
+	})
+
+	t.Run("should reject a negative size", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithMaxRequestSize(-1)
+
+		assert.Error(t, config.Validate())
+	})
+}
+
+func TestTelemetryConfig_WithMemoryLimiter(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should default to no limits", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		assert.False(t, config.MemoryLimiter().Enabled())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should store the limiter settings", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		limiter := domain.DefaultMemoryLimiterConfig()
+		limiter.HeapHardLimit = 512 << 20
+		config.WithMemoryLimiter(limiter)
+
+		assert.Equal(t, limiter, config.MemoryLimiter())
+		assert.True(t, config.MemoryLimiter().Enabled())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should reject invalid settings", func(t *testing.T) {
+		tests := map[string]domain.MemoryLimiterConfig{
+			"negative limit":       {HardLimit: -1},
+			"soft above hard":      {SoftLimit: 2 << 20, HardLimit: 1 << 20},
+			"heap soft above hard": {HeapSoftLimit: 2 << 20, HeapHardLimit: 1 << 20, CheckInterval: time.Second},
+			"sampling ratio":       {HardLimit: 1 << 20, SamplingRatio: 1.5},
+			"unknown severity":     {HardLimit: 1 << 20, MinSeverity: "loud"},
+			"no check interval":    {HeapHardLimit: 1 << 20},
+		}
+
+		for name, limiter := range tests {
+			config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+			config.WithMemoryLimiter(limiter)
+			assert.Error(t, config.Validate(), name)
+		}
+	})
+}
+
+func TestTelemetryConfig_WithTimestampSkew(t *testing.T) {
+	creds := createValidCredentials(t)
+	now := time.Now()
+
+	t.Run("should default to 24h in the past and 5m in the future", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		skew := config.TimestampSkew()
+		assert.Equal(t, domain.DefaultTimestampSkewConfig(), skew)
+		assert.NoError(t, skew.Check(now.Add(-23*time.Hour), now))
+		assert.NoError(t, skew.Check(now.Add(4*time.Minute), now))
+		assert.ErrorIs(t, skew.Check(now.Add(-25*time.Hour), now), domain.ErrTimestampSkew)
+		assert.ErrorIs(t, skew.Check(now.Add(6*time.Minute), now), domain.ErrTimestampSkew)
+	})
+
+	t.Run("should not check a limit set to zero", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithTimestampSkew(domain.TimestampSkewConfig{MaxFuture: time.Minute})
+
+		skew := config.TimestampSkew()
+		assert.NoError(t, skew.Check(now.AddDate(-1, 0, 0), now))
+		assert.Error(t, skew.Check(now.Add(time.Hour), now))
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should reject negative limits", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithTimestampSkew(domain.TimestampSkewConfig{MaxPast: -time.Hour})
+
+		assert.Error(t, config.Validate())
+	})
+}
+
+func TestTelemetryConfig_WithLogFilter(t *testing.T) {
+	creds := createValidCredentials(t)
+
+	t.Run("should emit every log by default", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+
+		assert.Equal(t, domain.DefaultLogFilterConfig(), config.LogFilter())
+		assert.False(t, config.LogFilter().Enabled())
+	})
+
+	t.Run("should not share the logger severities", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		severities := map[string]string{"db": "debug"}
+		config.WithLogFilter(domain.LogFilterConfig{MinSeverity: "info", LoggerSeverities: severities})
+
+		severities["db"] = "error"
+		config.LogFilter().LoggerSeverities["db"] = "warn"
+
+		assert.Equal(t, "debug", config.LogFilter().LoggerSeverities["db"])
+		assert.True(t, config.LogFilter().Enabled())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should accept numbered severities", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		config.WithLogFilter(domain.LogFilterConfig{MinSeverity: "INFO2", LoggerSeverities: map[string]string{"http": "warning"}})
+
+		assert.NoError(t, config.Validate())
+	})
+
+	invalid := map[string]domain.LogFilterConfig{
+		"an unknown severity":            {MinSeverity: "loud"},
+		"an unknown logger severity":     {LoggerSeverities: map[string]string{"db": "info5"}},
+		"an empty logger name":           {LoggerSeverities: map[string]string{"": "info"}},
+		"a negative window":              {DedupWindow: -time.Second},
+		"a negative count":               {SamplingInterval: time.Second, SamplingInitial: -1},
+		"sampling that keeps no records": {SamplingInterval: time.Second},
+	}
+	for name, cfg := range invalid {
+		t.Run("should reject "+name, func(t *testing.T) {
+			config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+			config.WithLogFilter(cfg)
+
+			assert.Error(t, config.Validate())
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkNewTelemetryConfig(b *testing.B) {
 	creds, _ := domain.NewCredentials("tfk_bench", "tfs_bench")
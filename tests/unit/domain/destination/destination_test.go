@@ -0,0 +1,125 @@
+// Package domain_test provides unit tests for export destinations.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+func TestNewDestination(t *testing.T) {
+	t.Run("should create destination with defaults", func(t *testing.T) {
+		dest, err := domain.NewDestination("otel", "otel-collector:4317")
+
+		require.NoError(t, err)
+		assert.Equal(t, "otel", dest.Name())
+		assert.Equal(t, "otel-collector:4317", dest.Endpoint())
+		assert.Equal(t, domain.ProtocolGRPC, dest.Protocol())
+		assert.Nil(t, dest.Credentials())
+		assert.True(t, dest.IsSignalEnabled(domain.SignalTraces))
+		assert.True(t, dest.IsSignalEnabled(domain.SignalMetrics))
+		assert.True(t, dest.IsSignalEnabled(domain.SignalLogs))
+		assert.True(t, dest.IsRetryEnabled())
+	})
+
+	t.Run("should reject empty name", func(t *testing.T) {
+		_, err := domain.NewDestination("", "otel-collector:4317")
+		require.Error(t, err)
+	})
+
+	t.Run("should reject empty endpoint", func(t *testing.T) {
+		_, err := domain.NewDestination("otel", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "endpoint")
+	})
+}
+
+func TestTelemetryConfig_ForDestination(t *testing.T) {
+	creds, _ := domain.NewCredentials("tfk_primary", "tfs_primary")
+	destCreds, _ := domain.NewCredentials("tfk_dest", "tfs_dest")
+
+	config, _ := domain.NewTelemetryConfig(creds, "api.telemetryflow.id:4317", "my-service")
+	config.
+		WithFailoverEndpoints("tfo-agent:4317").
+		WithTracesEndpoint("/custom/traces").
+		WithSignals(true, false, true)
+
+	dest, _ := domain.NewDestination("otel", "otel-collector:4318")
+	dest.
+		WithCredentials(destCreds).
+		WithProtocol(domain.ProtocolHTTP).
+		WithInsecure(true).
+		WithV2API(false).
+		WithSignals(true, true, false).
+		WithRetry(true, 5, time.Second)
+
+	derived := config.ForDestination(dest)
+
+	t.Run("should take connection settings from destination", func(t *testing.T) {
+		assert.Equal(t, "otel-collector:4318", derived.Endpoint())
+		assert.Equal(t, domain.ProtocolHTTP, derived.Protocol())
+		assert.True(t, derived.IsInsecure())
+		assert.Equal(t, "tfk_dest", derived.Credentials().KeyID())
+		assert.Equal(t, "/v1/traces", derived.TracesEndpoint())
+		assert.Equal(t, 5, derived.MaxRetries())
+		assert.False(t, derived.IsFailoverEnabled())
+	})
+
+	t.Run("should only enable signals enabled on both", func(t *testing.T) {
+		assert.True(t, derived.IsSignalEnabled(domain.SignalMetrics))
+		assert.False(t, derived.IsSignalEnabled(domain.SignalLogs))
+		assert.False(t, derived.IsSignalEnabled(domain.SignalTraces))
+	})
+
+	t.Run("should keep service settings and leave original untouched", func(t *testing.T) {
+		assert.Equal(t, "my-service", derived.ServiceName())
+		assert.Equal(t, "api.telemetryflow.id:4317", config.Endpoint())
+		assert.True(t, config.IsSignalEnabled(domain.SignalTraces))
+		assert.Equal(t, "/custom/traces", config.TracesEndpoint())
+	})
+}
+
+func TestTelemetryConfig_WithDestination(t *testing.T) {
+	creds, _ := domain.NewCredentials("tfk_test", "tfs_secret")
+
+	t.Run("should validate destinations", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		dest, _ := domain.NewDestination("otel", "otel-collector:4317")
+		config.WithDestination(dest)
+
+		require.NoError(t, config.Validate())
+		assert.Len(t, config.Destinations(), 1)
+	})
+
+	t.Run("should reject duplicate destination names", func(t *testing.T) {
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		first, _ := domain.NewDestination("otel", "otel-a:4317")
+		second, _ := domain.NewDestination("otel", "otel-b:4317")
+		config.WithDestination(first).WithDestination(second)
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate destination")
+	})
+}
@@ -0,0 +1,178 @@
+// Package domain_test provides unit tests for endpoint parsing.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected domain.EndpointURL
+	}{
+		{"bare host and port", "localhost:4317", domain.EndpointURL{Host: "localhost:4317"}},
+		{"http URL", "http://collector:4318", domain.EndpointURL{Scheme: "http", Host: "collector:4318"}},
+		{"https URL with base path", "https://gateway.example.com/otlp/", domain.EndpointURL{Scheme: "https", Host: "gateway.example.com", BasePath: "/otlp"}},
+		{"upper-case scheme", "HTTPS://gateway.example.com", domain.EndpointURL{Scheme: "https", Host: "gateway.example.com"}},
+		{"unix socket", "unix:///var/run/tfo-agent.sock", domain.EndpointURL{Scheme: "unix", SocketPath: "/var/run/tfo-agent.sock"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("should parse "+tt.name, func(t *testing.T) {
+			parsed, err := domain.ParseEndpoint(tt.raw)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *parsed)
+		})
+	}
+
+	invalid := map[string]string{
+		"empty endpoint":        "",
+		"unsupported scheme":    "ftp://collector:21",
+		"bare host with a path": "collector:4318/otlp",
+		"URL without host":      "https:///otlp",
+		"unix without path":     "unix://",
+		"unix with host":        "unix://localhost/var/run/tfo.sock",
+		"URL with query":        "https://gateway.example.com/otlp?tenant=a",
+	}
+	for name, raw := range invalid {
+		t.Run("should reject "+name, func(t *testing.T) {
+			_, err := domain.ParseEndpoint(raw)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEndpointURL_Inference(t *testing.T) {
+	t.Run("should infer defaults from the scheme", func(t *testing.T) {
+		tests := []struct {
+			raw              string
+			insecure         bool
+			insecureKnown    bool
+			protocol         domain.Protocol
+			protocolInferred bool
+		}{
+			{"localhost:4317", false, false, "", false},
+			{"http://collector:4318", true, true, domain.ProtocolHTTP, true},
+			{"https://gateway.example.com", false, true, domain.ProtocolHTTP, true},
+			{"unix:///var/run/tfo-agent.sock", true, true, "", false},
+		}
+
+		for _, tt := range tests {
+			parsed, err := domain.ParseEndpoint(tt.raw)
+			require.NoError(t, err)
+
+			insecure, ok := parsed.InferredInsecure()
+			assert.Equal(t, tt.insecure, insecure, tt.raw)
+			assert.Equal(t, tt.insecureKnown, ok, tt.raw)
+
+			protocol, ok := parsed.InferredProtocol()
+			assert.Equal(t, tt.protocol, protocol, tt.raw)
+			assert.Equal(t, tt.protocolInferred, ok, tt.raw)
+		}
+	})
+
+	t.Run("should reject a URL path with grpc", func(t *testing.T) {
+		parsed, err := domain.ParseEndpoint("http://collector:4318/v2/traces")
+		require.NoError(t, err)
+
+		err = parsed.Validate(domain.ProtocolGRPC)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grpc endpoints cannot have a URL path")
+	})
+
+	t.Run("should reject a signal path used as base path", func(t *testing.T) {
+		parsed, err := domain.ParseEndpoint("http://collector:4318/v2/traces")
+		require.NoError(t, err)
+
+		assert.Error(t, parsed.Validate(domain.ProtocolHTTP))
+	})
+
+	t.Run("should accept a base path with http", func(t *testing.T) {
+		parsed, err := domain.ParseEndpoint("https://gateway.example.com/otlp")
+		require.NoError(t, err)
+
+		assert.NoError(t, parsed.Validate(domain.ProtocolHTTP))
+		assert.Equal(t, "https://gateway.example.com/otlp", parsed.String())
+	})
+}
+
+func TestTelemetryConfig_EndpointURL(t *testing.T) {
+	creds, err := domain.NewCredentials("tfk_test", "tfs_secret")
+	require.NoError(t, err)
+
+	t.Run("should infer protocol and TLS from a URL endpoint", func(t *testing.T) {
+		config, err := domain.NewTelemetryConfig(creds, "https://gateway.example.com/otlp", "my-service")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProtocolHTTP, config.Protocol())
+		assert.False(t, config.IsInsecure())
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should treat a unix socket as plaintext", func(t *testing.T) {
+		config, err := domain.NewTelemetryConfig(creds, "unix:///var/run/tfo-agent.sock", "my-service")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProtocolGRPC, config.Protocol())
+		assert.True(t, config.IsInsecure())
+	})
+
+	t.Run("should reject an invalid endpoint", func(t *testing.T) {
+		_, err := domain.NewTelemetryConfig(creds, "ftp://collector", "my-service")
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject grpc with a URL path", func(t *testing.T) {
+		config, err := domain.NewTelemetryConfig(creds, "http://collector:4318/otlp", "my-service")
+		require.NoError(t, err)
+		config.WithProtocol(domain.ProtocolGRPC)
+
+		err = config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grpc endpoints cannot have a URL path")
+	})
+
+	t.Run("should infer the protocol of a signal URL endpoint", func(t *testing.T) {
+		config, err := domain.NewTelemetryConfig(creds, "localhost:4317", "my-service")
+		require.NoError(t, err)
+		config.WithSignalEndpoint(domain.SignalMetrics, "https://metrics.example.com/otlp")
+
+		assert.Equal(t, domain.ProtocolHTTP, config.SignalProtocol(domain.SignalMetrics))
+		assert.Equal(t, domain.ProtocolGRPC, config.SignalProtocol(domain.SignalTraces))
+		assert.False(t, config.EndpointInsecure("https://metrics.example.com/otlp"))
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("should infer defaults for destinations", func(t *testing.T) {
+		dest, err := domain.NewDestination("gateway", "http://otel-gateway:4318/otlp")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProtocolHTTP, dest.Protocol())
+		assert.True(t, dest.IsInsecure())
+		require.NoError(t, dest.Validate())
+	})
+}
@@ -0,0 +1,331 @@
+// Package infrastructure_test provides unit tests for batch log and metric commands.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// metricsAndLogs exports metrics and logs only
+func metricsAndLogs(b *telemetryflow.Builder) {
+	b.WithSignals(true, true, false)
+}
+
+// findMetric returns the last exported metric named name
+func findMetric(collector *telemetryflowtest.Collector, name string) *metricspb.Metric {
+	var found *metricspb.Metric
+	for _, metric := range collector.Metrics() {
+		if metric.Name == name {
+			found = metric
+		}
+	}
+	return found
+}
+
+func TestLogBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should emit every log of the batch", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+		timestamp := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+
+		logs := make([]application.EmitLogCommand, 100)
+		for i := range logs {
+			logs[i] = application.EmitLogCommand{
+				Severity:   "info",
+				Message:    fmt.Sprintf("imported record %d", i),
+				Attributes: map[string]interface{}{"row": i},
+			}
+		}
+		logs[0].Timestamp = timestamp
+
+		result, err := client.LogBatch(ctx, logs)
+		require.NoError(t, err)
+		assert.Equal(t, 100, result.Accepted)
+		assert.Empty(t, result.Errors)
+		assert.NoError(t, result.Err())
+		require.NoError(t, client.Flush(ctx))
+
+		records := collector.LogRecords()
+		require.Len(t, records, 100)
+		assert.Equal(t, uint64(timestamp.UnixNano()), records[0].TimeUnixNano)
+		// The batch shares a single observed time
+		assert.Equal(t, records[0].ObservedTimeUnixNano, records[99].ObservedTimeUnixNano)
+	})
+
+	t.Run("should report rejected logs by index", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+
+		result, err := client.LogBatch(ctx, []application.EmitLogCommand{
+			{Severity: "info", Message: "first"},
+			{Severity: "info", Message: "too old", Timestamp: time.Now().Add(-48 * time.Hour)},
+			{Severity: "warn", Message: "third"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Accepted)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, 1, result.Errors[0].Index)
+		assert.ErrorIs(t, result.Errors[0], domain.ErrTimestampSkew)
+		assert.ErrorIs(t, result.Err(), domain.ErrTimestampSkew)
+		require.NoError(t, client.Flush(ctx))
+
+		records := collector.LogRecords()
+		require.Len(t, records, 2)
+		assert.Equal(t, "first", records[0].Body.GetStringValue())
+		assert.Equal(t, "third", records[1].Body.GetStringValue())
+	})
+
+	t.Run("should fail the whole batch before initialization", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("batch-service", "1.0.0").
+			Build()
+		require.NoError(t, err)
+
+		result, err := client.LogBatch(ctx, []application.EmitLogCommand{{Message: "lost"}})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRecordMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should record every kind of measurement", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+
+		result, err := client.RecordMetrics(ctx, []application.Measurement{
+			{Name: "import.queue", Value: 12, Unit: "{row}"},
+			{Kind: "counter", Name: "import.rows", Value: 3},
+			{Kind: "counter", Name: "import.rows", Value: 4},
+			{Kind: "histogram", Name: "import.row.size", Value: 512, Unit: "By"},
+			{Kind: "histogram", Name: "import.row.size", Value: 1024, Unit: "By"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Accepted)
+		require.NoError(t, client.Flush(ctx))
+
+		gauge := findMetric(collector, "import.queue")
+		require.NotNil(t, gauge)
+		assert.Equal(t, 12.0, gauge.GetGauge().DataPoints[0].GetAsDouble())
+		counter := findMetric(collector, "import.rows")
+		require.NotNil(t, counter)
+		assert.Equal(t, int64(7), counter.GetSum().DataPoints[0].GetAsInt())
+		histogram := findMetric(collector, "import.row.size")
+		require.NotNil(t, histogram)
+		assert.Equal(t, uint64(2), histogram.GetHistogram().DataPoints[0].Count)
+	})
+
+	t.Run("should report rejected measurements by index", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+
+		result, err := client.RecordMetrics(ctx, []application.Measurement{
+			{Kind: "counter", Name: "import.rows", Value: 1.5},
+			{Kind: "counter", Name: "import.rows", Value: -1},
+			{Kind: "summary", Name: "import.rows", Value: 1},
+			{Kind: "counter", Name: "import.rows", Value: 1, Timestamp: time.Now()},
+			{Name: "sensor.temperature", Value: 20, Timestamp: time.Now().Add(time.Hour)},
+			{Name: "sensor.temperature", Value: 21, Timestamp: time.Now().Add(-time.Minute)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Accepted)
+		require.Len(t, result.Errors, 5)
+		for i, itemErr := range result.Errors {
+			assert.Equal(t, i, itemErr.Index)
+		}
+		assert.ErrorContains(t, result.Errors[0], "whole, non-negative")
+		assert.ErrorContains(t, result.Errors[1], "whole, non-negative")
+		assert.ErrorContains(t, result.Errors[2], "unsupported metric kind")
+		assert.ErrorContains(t, result.Errors[3], "only supported for gauges")
+		assert.ErrorIs(t, result.Errors[4], domain.ErrTimestampSkew)
+	})
+
+	t.Run("should reject timestamped values beyond the pending limit", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+		start := time.Now().Add(-time.Hour)
+
+		measurements := make([]application.Measurement, 2050)
+		for i := range measurements {
+			measurements[i] = application.Measurement{Name: "sensor.temperature", Value: float64(i), Timestamp: start.Add(time.Duration(i) * time.Millisecond)}
+		}
+
+		result, err := client.RecordMetrics(ctx, measurements)
+		require.NoError(t, err)
+		assert.Equal(t, 2048, result.Accepted)
+		require.Len(t, result.Errors, 2)
+		assert.Equal(t, 2048, result.Errors[0].Index)
+		assert.ErrorContains(t, result.Errors[0], "flush before recording more")
+	})
+
+	t.Run("should report the last value of a gauge once per attribute set", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+
+		for i := range 10 {
+			require.NoError(t, client.RecordGauge(ctx, "import.queue", float64(i), map[string]interface{}{"source": "a"}))
+		}
+		require.NoError(t, client.RecordGauge(ctx, "import.queue", 100, map[string]interface{}{"source": "b"}))
+		require.NoError(t, client.Flush(ctx))
+
+		gauge := findMetric(collector, "import.queue")
+		require.NotNil(t, gauge)
+		values := make(map[string]float64)
+		for _, point := range gauge.GetGauge().DataPoints {
+			values[point.Attributes[0].Value.GetStringValue()] = point.GetAsDouble()
+		}
+		assert.Equal(t, map[string]float64{"a": 9, "b": 100}, values)
+	})
+
+	t.Run("should report the gauge values of a batch together", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		batch := func(value float64) []application.Measurement {
+			measurements := make([]application.Measurement, 200)
+			for i := range measurements {
+				measurements[i] = application.Measurement{
+					Name:       "import.shard.lag",
+					Value:      value,
+					Attributes: map[string]interface{}{"shard": i},
+				}
+			}
+			return measurements
+		}
+		_, err := client.RecordMetrics(ctx, batch(0))
+		require.NoError(t, err)
+
+		recorded := make(chan struct{})
+		go func() {
+			defer close(recorded)
+			for i := range 500 {
+				_, _ = client.RecordMetrics(ctx, batch(float64(i)))
+			}
+		}()
+
+		for done := false; !done; {
+			select {
+			case <-recorded:
+				done = true
+			default:
+			}
+			gauge, ok := kit.FindMetric("import.shard.lag")
+			require.True(t, ok)
+			require.Len(t, gauge.Points, 200)
+			for _, point := range gauge.Points {
+				require.Equal(t, gauge.Points[0].Value, point.Value, "a collection reported part of a batch")
+			}
+		}
+	})
+
+	t.Run("should record new metric names while collecting", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		require.NoError(t, client.RecordGauge(ctx, "collect.gauge.0", 1, nil))
+
+		stop := make(chan struct{})
+		collected := make(chan struct{})
+		go func() {
+			defer close(collected)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					kit.Metrics()
+				}
+			}
+		}()
+
+		recorded := make(chan struct{})
+		go func() {
+			defer close(recorded)
+			for i := range 2000 {
+				_ = client.RecordGauge(ctx, fmt.Sprintf("collect.gauge.%d", i), float64(i), nil)
+				_ = client.IncrementCounter(ctx, fmt.Sprintf("collect.counter.%d", i), 1, nil)
+			}
+		}()
+
+		select {
+		case <-recorded:
+		case <-time.After(10 * time.Second):
+			t.Fatal("recording new metrics deadlocked with a concurrent collection")
+		}
+		close(stop)
+		<-collected
+
+		_, found := kit.FindMetric("collect.gauge.1999")
+		assert.True(t, found)
+	})
+}
+
+func TestBatchCommands(t *testing.T) {
+	ctx := context.Background()
+	collector := telemetryflowtest.NewCollector(t)
+
+	creds, err := domain.NewCredentials("tfk_test", "tfs_secret")
+	require.NoError(t, err)
+	config, err := domain.NewTelemetryConfig(creds, collector.GRPCEndpoint(), "batch-service")
+	require.NoError(t, err)
+	config.WithProtocol(domain.ProtocolGRPC).WithInsecure(true).WithSignals(true, true, false).WithRetry(false, 0, 0)
+
+	handler := infrastructure.NewTelemetryCommandHandler(config)
+	require.NoError(t, handler.Handle(ctx, &application.InitializeSDKCommand{Config: config}))
+	t.Cleanup(func() { _ = handler.Handle(ctx, &application.ShutdownSDKCommand{Timeout: time.Second}) })
+
+	t.Run("should handle EmitBatchLogsCommand", func(t *testing.T) {
+		err := handler.Handle(ctx, &application.EmitBatchLogsCommand{Logs: []application.EmitLogCommand{
+			{Severity: "info", Message: "one"},
+			{Severity: "info", Message: "two"},
+		}})
+		require.NoError(t, err)
+		require.NoError(t, handler.Handle(ctx, &application.FlushTelemetryCommand{Timeout: time.Second}))
+
+		assert.Len(t, collector.LogRecords(), 2)
+	})
+
+	t.Run("should return the item errors of a RecordMetricsCommand", func(t *testing.T) {
+		err := handler.Handle(ctx, &application.RecordMetricsCommand{Measurements: []application.Measurement{
+			{Name: "import.queue", Value: 1},
+			{Kind: "counter", Name: "import.rows", Value: -1},
+		}})
+
+		var itemErr application.BatchError
+		require.True(t, errors.As(err, &itemErr))
+		assert.Equal(t, 1, itemErr.Index)
+	})
+}
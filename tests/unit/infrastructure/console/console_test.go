@@ -0,0 +1,150 @@
+// Package infrastructure_test provides unit tests for the console exporters.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestConsoleSpanExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should print spans as a tree with durations", func(t *testing.T) {
+		var out bytes.Buffer
+		tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(infrastructure.NewConsoleSpanExporter(&out)))
+		tracer := tp.Tracer("test")
+
+		rootCtx, root := tracer.Start(ctx, "GET /users")
+		_, query := tracer.Start(rootCtx, "db.query")
+		query.SetAttributes(attribute.String("db.system", "postgres"))
+		query.End()
+		_, cache := tracer.Start(rootCtx, "cache.get")
+		cache.SetStatus(codes.Error, "timeout")
+		cache.End()
+		root.End()
+		require.NoError(t, tp.ForceFlush(ctx))
+		require.NoError(t, tp.Shutdown(ctx))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, "trace "+root.SpanContext().TraceID().String(), lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "└─ GET /users [internal] "), lines[1])
+		assert.True(t, strings.HasPrefix(lines[2], "   ├─ db.query [internal] "), lines[2])
+		assert.Contains(t, lines[2], "db.system=postgres")
+		assert.True(t, strings.HasPrefix(lines[3], "   └─ cache.get [internal] "), lines[3])
+		assert.Contains(t, lines[3], "ERROR timeout")
+		assert.NotContains(t, out.String(), "\033[", "no colors when not writing to a terminal")
+	})
+}
+
+func TestConsoleMetricExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should print a table on each collection", func(t *testing.T) {
+		var out bytes.Buffer
+		reader := sdkmetric.NewPeriodicReader(infrastructure.NewConsoleMetricExporter(&out))
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		meter := mp.Meter("test")
+
+		counter, err := meter.Int64Counter("requests")
+		require.NoError(t, err)
+		histogram, err := meter.Float64Histogram("latency", otelmetric.WithUnit("s"))
+		require.NoError(t, err)
+		counter.Add(ctx, 3, otelmetric.WithAttributes(attribute.String("method", "GET")))
+		histogram.Record(ctx, 0.5)
+		histogram.Record(ctx, 1.5)
+
+		require.NoError(t, mp.ForceFlush(ctx))
+		require.NoError(t, mp.Shutdown(ctx))
+
+		output := out.String()
+		assert.Contains(t, output, "NAME")
+		assert.Regexp(t, `requests\s+sum\s+3\s+method=GET`, output)
+		assert.Regexp(t, `latency \(s\)\s+histogram\s+count=2 sum=2`, output)
+	})
+}
+
+func TestConsoleLogExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should print one line per record", func(t *testing.T) {
+		var out bytes.Buffer
+		lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(infrastructure.NewConsoleLogExporter(&out))))
+
+		var record otellog.Record
+		record.SetSeverity(otellog.SeverityWarn)
+		record.SetSeverityText("warn")
+		record.SetBody(otellog.StringValue("disk almost full"))
+		record.AddAttributes(otellog.String("mount", "/data"))
+		lp.Logger("test").Emit(ctx, record)
+		require.NoError(t, lp.Shutdown(ctx))
+
+		assert.Regexp(t, `^\d{2}:\d{2}:\d{2}\.\d{3} WARN  disk almost full  mount=/data\n$`, out.String())
+	})
+}
+
+func TestClient_ConsoleExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should run console-only without credentials or collector", func(t *testing.T) {
+		var out bytes.Buffer
+		client, err := telemetryflow.NewBuilder().
+			WithService("console-service", "1.0.0").
+			WithExporters(domain.ExporterConsole).
+			WithConsoleWriter(&out).
+			Build()
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+
+		spanID, err := client.StartSpan(ctx, "checkout", "server", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.IncrementCounter(ctx, "orders", 1, nil))
+		require.NoError(t, client.LogInfo(ctx, "order placed", nil))
+		require.NoError(t, client.Flush(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		output := out.String()
+		assert.Contains(t, output, "checkout [server]")
+		assert.Regexp(t, `orders\s+sum\s+1`, output)
+		assert.Contains(t, output, "INFO  order placed")
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"console"}, status.Config["exporters"])
+	})
+}
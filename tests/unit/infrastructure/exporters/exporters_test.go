@@ -0,0 +1,553 @@
+// Package infrastructure_test provides unit tests for the OTLP exporter factory.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// receivedRequest captures a request received by the test OTLP receiver
+type receivedRequest struct {
+	Path    string
+	Headers http.Header
+}
+
+// otlpReceiver is a minimal OTLP/HTTP receiver that records requests
+type otlpReceiver struct {
+	server   *httptest.Server
+	v1Only   bool // reply 404 to v2 paths, like collectors that predate the v2 API
+	mu       sync.Mutex
+	requests []receivedRequest
+}
+
+func newOTLPReceiver(t *testing.T) *otlpReceiver {
+	r := &otlpReceiver{}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{Path: req.URL.Path, Headers: req.Header.Clone()})
+		r.mu.Unlock()
+		if r.v1Only && strings.HasPrefix(req.URL.Path, "/v2/") {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func newV1OnlyReceiver(t *testing.T) *otlpReceiver {
+	r := newOTLPReceiver(t)
+	r.v1Only = true
+	return r
+}
+
+func (r *otlpReceiver) endpoint() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func (r *otlpReceiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func (r *otlpReceiver) paths() []string {
+	var paths []string
+	for _, req := range r.received() {
+		paths = append(paths, req.Path)
+	}
+	return paths
+}
+
+func createConfig(t *testing.T, endpoint string) *domain.TelemetryConfig {
+	creds, err := domain.NewCredentials("tfk_test", "tfs_secret")
+	require.NoError(t, err)
+	config, err := domain.NewTelemetryConfig(creds, endpoint, "exporter-test")
+	require.NoError(t, err)
+	config.WithProtocol(domain.ProtocolHTTP).WithInsecure(true).WithCompression(false)
+	return config
+}
+
+func TestOTLPExporterFactory_Destinations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create exporters only for destinations receiving the signal", func(t *testing.T) {
+		config := createConfig(t, "localhost:4318")
+		tracesDest, _ := domain.NewDestination("traces-only", "otel-a:4318")
+		tracesDest.WithProtocol(domain.ProtocolHTTP).WithSignals(false, false, true)
+		metricsDest, _ := domain.NewDestination("metrics-only", "otel-b:4318")
+		metricsDest.WithProtocol(domain.ProtocolHTTP).WithSignals(true, false, false)
+		config.WithDestination(tracesDest).WithDestination(metricsDest)
+
+		factory := infrastructure.NewOTLPExporterFactory(config)
+
+		traceExporters, err := factory.CreateDestinationTraceExporters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, traceExporters, 1)
+
+		metricExporters, err := factory.CreateDestinationMetricExporters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, metricExporters, 1)
+	})
+
+	t.Run("should fan out spans to every destination with its own credentials", func(t *testing.T) {
+		primary := newOTLPReceiver(t)
+		secondary := newOTLPReceiver(t)
+
+		otelCreds, _ := domain.NewCredentials("tfk_otel", "tfs_otel")
+		dest, _ := domain.NewDestination("otel", secondary.endpoint())
+		dest.
+			WithCredentials(otelCreds).
+			WithProtocol(domain.ProtocolHTTP).
+			WithInsecure(true).
+			WithV2API(false).
+			WithSignals(false, false, true)
+
+		client, err := telemetryflow.NewClient(createConfig(t, primary.endpoint()).
+			WithSignals(false, false, true).
+			WithDestination(dest))
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		spanID, err := client.StartSpan(ctx, "fan-out", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.Flush(ctx))
+
+		assert.Equal(t, []string{"/v2/traces"}, primary.paths())
+		assert.Equal(t, []string{"/v1/traces"}, secondary.paths())
+		assert.Equal(t, "tfk_test", primary.received()[0].Headers.Get("X-TelemetryFlow-Key-ID"))
+		assert.Equal(t, "tfk_otel", secondary.received()[0].Headers.Get("X-TelemetryFlow-Key-ID"))
+	})
+
+	t.Run("should keep exporting when another destination fails", func(t *testing.T) {
+		primary := newOTLPReceiver(t)
+
+		dead, _ := domain.NewDestination("dead", "127.0.0.1:1")
+		dead.
+			WithProtocol(domain.ProtocolHTTP).
+			WithInsecure(true).
+			WithRetry(false, 0, time.Second)
+
+		client, err := telemetryflow.NewClient(createConfig(t, primary.endpoint()).
+			WithSignals(false, false, true).
+			WithDestination(dead))
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		spanID, err := client.StartSpan(ctx, "fan-out", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		_ = client.Flush(ctx) // the dead destination reports an error
+
+		assert.Equal(t, []string{"/v2/traces"}, primary.paths())
+	})
+
+	t.Run("should send each destination its own headers", func(t *testing.T) {
+		primary := telemetryflowtest.NewCollector(t)
+		secondary := telemetryflowtest.NewCollector(t)
+
+		dest, _ := domain.NewDestination("otel", secondary.HTTPEndpoint())
+		dest.
+			WithProtocol(domain.ProtocolHTTP).
+			WithInsecure(true).
+			WithRetry(false, 0, time.Second).
+			WithHeader("X-Scope-OrgID", "team-b").
+			WithHeaderProvider(func(context.Context) (map[string]string, error) {
+				return map[string]string{"X-Token": "token-b"}, nil
+			})
+
+		client := primary.NewClient(t, domain.ProtocolHTTP, func(b *telemetryflow.Builder) {
+			b.WithSignals(false, false, true).
+				WithHeader("X-Tenant-ID", "tenant-a").
+				WithDestination(dest)
+		})
+
+		spanID, err := client.StartSpan(ctx, "fan-out", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.Flush(ctx))
+
+		primaryRequests := primary.Requests(domain.SignalTraces)
+		require.Len(t, primaryRequests, 1)
+		assert.Equal(t, "tenant-a", primaryRequests[0].Headers.Get("X-Tenant-ID"))
+		assert.Empty(t, primaryRequests[0].Headers.Get("X-Scope-OrgID"))
+
+		secondaryRequests := secondary.Requests(domain.SignalTraces)
+		require.Len(t, secondaryRequests, 1)
+		assert.Equal(t, "team-b", secondaryRequests[0].Headers.Get("X-Scope-OrgID"))
+		assert.Equal(t, "token-b", secondaryRequests[0].Headers.Get("X-Token"))
+		assert.Empty(t, secondaryRequests[0].Headers.Get("X-Tenant-ID"))
+	})
+}
+
+func TestOTLPExporterFactory_SignalSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send each signal to its own endpoint", func(t *testing.T) {
+		shared := newOTLPReceiver(t)
+		metrics := newOTLPReceiver(t)
+
+		config := createConfig(t, shared.endpoint()).
+			WithSignalEndpoint(domain.SignalMetrics, metrics.endpoint()).
+			WithSignalHeader(domain.SignalMetrics, "X-Team", "platform")
+
+		client, err := telemetryflow.NewClient(config)
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		spanID, err := client.StartSpan(ctx, "per-signal", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.IncrementCounter(ctx, "requests", 1, nil))
+		require.NoError(t, client.LogInfo(ctx, "hello", map[string]interface{}{"user": "alice"}))
+		require.NoError(t, client.Flush(ctx))
+
+		assert.ElementsMatch(t, []string{"/v2/traces", "/v2/logs"}, shared.paths())
+		assert.Equal(t, []string{"/v2/metrics"}, metrics.paths())
+		assert.Equal(t, "platform", metrics.received()[0].Headers.Get("X-Team"))
+		for _, req := range shared.received() {
+			assert.Empty(t, req.Headers.Get("X-Team"))
+		}
+	})
+
+	t.Run("should fail for an unsupported signal protocol", func(t *testing.T) {
+		config := createConfig(t, "localhost:4318")
+		config.WithSignalProtocol(domain.SignalLogs, domain.Protocol("thrift"))
+
+		_, err := infrastructure.NewOTLPExporterFactory(config).CreateLogExporter(ctx)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported protocol")
+	})
+}
+
+func TestOTLPExporterFactory_APIVersionNegotiation(t *testing.T) {
+	ctx := context.Background()
+
+	exportSpan := func(t *testing.T, client *telemetryflow.Client) error {
+		spanID, err := client.StartSpan(ctx, "negotiation", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		return client.Flush(ctx)
+	}
+
+	t.Run("should fall back to v1 once when the collector lacks v2", func(t *testing.T) {
+		receiver := newV1OnlyReceiver(t)
+		config := createConfig(t, receiver.endpoint()).WithSignals(false, false, true)
+
+		client, err := telemetryflow.NewClient(config)
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		require.NoError(t, exportSpan(t, client))
+		require.NoError(t, exportSpan(t, client))
+
+		assert.Equal(t, []string{"/v2/traces", "/v1/traces", "/v1/traces"}, receiver.paths())
+		requests := receiver.received()
+		assert.Equal(t, "v2", requests[0].Headers.Get("X-TelemetryFlow-API-Version"))
+		assert.Equal(t, "v1", requests[1].Headers.Get("X-TelemetryFlow-API-Version"))
+		assert.Equal(t, "v1", requests[2].Headers.Get("X-TelemetryFlow-API-Version"))
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", status.Config["api_version"])
+	})
+
+	t.Run("should keep v2 when the collector accepts it", func(t *testing.T) {
+		receiver := newOTLPReceiver(t)
+		config := createConfig(t, receiver.endpoint()).WithSignals(false, false, true)
+
+		client, err := telemetryflow.NewClient(config)
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		require.NoError(t, exportSpan(t, client))
+
+		assert.Equal(t, []string{"/v2/traces"}, receiver.paths())
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", status.Config["api_version"])
+	})
+
+	t.Run("should not fall back in v2-only mode", func(t *testing.T) {
+		receiver := newV1OnlyReceiver(t)
+		config := createConfig(t, receiver.endpoint()).
+			WithSignals(false, false, true).
+			WithV2Only(true).
+			WithRetry(false, 0, time.Second)
+
+		client, err := telemetryflow.NewClient(config)
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		_ = exportSpan(t, client) // the 404 is reported as an export error
+
+		assert.Equal(t, []string{"/v2/traces"}, receiver.paths())
+	})
+
+	t.Run("should remember the version per unix socket", func(t *testing.T) {
+		receiver := newV1OnlyReceiver(t)
+		socket := filepath.Join(t.TempDir(), "tfo.sock")
+		listener, err := net.Listen("unix", socket)
+		require.NoError(t, err)
+		server := &http.Server{Handler: receiver.server.Config.Handler}
+		go func() { _ = server.Serve(listener) }()
+		t.Cleanup(func() { _ = server.Close() })
+
+		client, err := telemetryflow.NewClient(createConfig(t, "unix://"+socket).WithSignals(false, false, true))
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		require.NoError(t, exportSpan(t, client))
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", status.Config["api_version"])
+		assert.Equal(t, map[string]string{"unix://" + socket: "v1"}, status.Config["api_versions"])
+	})
+}
+
+func TestAPIVersionNegotiator(t *testing.T) {
+	t.Run("should start from the configured version", func(t *testing.T) {
+		assert.Equal(t, "v2", infrastructure.NewAPIVersionNegotiator(true, false).Version("collector:4318"))
+		assert.Equal(t, "v1", infrastructure.NewAPIVersionNegotiator(false, false).Version("collector:4318"))
+		assert.Equal(t, "v2", infrastructure.NewAPIVersionNegotiator(false, true).Version("collector:4318"))
+	})
+
+	t.Run("should remember the version per endpoint", func(t *testing.T) {
+		legacy := newV1OnlyReceiver(t)
+		current := newOTLPReceiver(t)
+		negotiator := infrastructure.NewAPIVersionNegotiator(true, false)
+		client := &http.Client{Transport: negotiator.Transport(http.DefaultTransport)}
+
+		for _, receiver := range []*otlpReceiver{legacy, current} {
+			resp, err := client.Post(receiver.server.URL+"/v2/metrics", "application/x-protobuf", strings.NewReader("payload"))
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+
+		assert.Equal(t, map[string]string{
+			legacy.endpoint():  "v1",
+			current.endpoint(): "v2",
+		}, negotiator.Versions())
+	})
+
+	t.Run("should remember the version per base path", func(t *testing.T) {
+		receiver := newOTLPReceiver(t)
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if strings.HasPrefix(req.URL.Path, "/legacy/v2/") {
+				http.NotFound(w, req)
+				return
+			}
+			receiver.server.Config.Handler.ServeHTTP(w, req)
+		}))
+		t.Cleanup(gateway.Close)
+		negotiator := infrastructure.NewAPIVersionNegotiator(true, false)
+		client := &http.Client{Transport: negotiator.Transport(http.DefaultTransport)}
+
+		for _, base := range []string{"/legacy", "/current"} {
+			resp, err := client.Post(gateway.URL+base+"/v2/metrics", "application/x-protobuf", strings.NewReader("payload"))
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+
+		host := strings.TrimPrefix(gateway.URL, "http://")
+		assert.Equal(t, map[string]string{host + "/legacy": "v1", host + "/current": "v2"}, negotiator.Versions())
+		assert.Equal(t, "v1", negotiator.Version(gateway.URL+"/legacy"))
+		assert.Equal(t, "v2", negotiator.Version(gateway.URL+"/current"))
+		assert.Equal(t, []string{"/legacy/v1/metrics", "/current/v2/metrics"}, receiver.paths())
+	})
+}
+
+func TestOTLPExporterFactory_EndpointURL(t *testing.T) {
+	ctx := context.Background()
+
+	exportSpan := func(t *testing.T, config *domain.TelemetryConfig) {
+		client, err := telemetryflow.NewClient(config.WithSignals(false, false, true))
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		spanID, err := client.StartSpan(ctx, "endpoint-url", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.Flush(ctx))
+	}
+
+	t.Run("should prefix signal paths with the URL base path", func(t *testing.T) {
+		receiver := newOTLPReceiver(t)
+		creds, _ := domain.NewCredentials("tfk_test", "tfs_secret")
+		config, err := domain.NewTelemetryConfig(creds, receiver.server.URL+"/otlp", "exporter-test")
+		require.NoError(t, err)
+
+		exportSpan(t, config.WithCompression(false))
+
+		assert.Equal(t, []string{"/otlp/v2/traces"}, receiver.paths())
+	})
+
+	t.Run("should export over a unix domain socket", func(t *testing.T) {
+		socket := filepath.Join(t.TempDir(), "tfo.sock")
+		listener, err := net.Listen("unix", socket)
+		require.NoError(t, err)
+
+		receiver := &otlpReceiver{}
+		receiver.server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			receiver.mu.Lock()
+			receiver.requests = append(receiver.requests, receivedRequest{Path: req.URL.Path, Headers: req.Header.Clone()})
+			receiver.mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		receiver.server.Listener = listener
+		receiver.server.Start()
+		t.Cleanup(receiver.server.Close)
+
+		exportSpan(t, createConfig(t, "unix://"+socket))
+
+		assert.Equal(t, []string{"/v2/traces"}, receiver.paths())
+	})
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestOTLPExporterFactory_HTTPTransport(t *testing.T) {
+	ctx := context.Background()
+
+	exportSpan := func(t *testing.T, config *domain.TelemetryConfig) error {
+		client, err := telemetryflow.NewClient(config.WithSignals(false, false, true).WithRetry(false, 0, time.Second))
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		spanID, err := client.StartSpan(ctx, "http-transport", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		return client.Flush(ctx)
+	}
+
+	t.Run("should send exports through an authenticated proxy", func(t *testing.T) {
+		proxy := newOTLPReceiver(t) // records the forwarded requests instead of proxying them
+		config := createConfig(t, "collector.example.invalid:4318").
+			WithProxy(proxy.server.URL).
+			WithProxyAuth("svc", "secret")
+
+		require.NoError(t, exportSpan(t, config))
+
+		requests := proxy.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "/v2/traces", requests[0].Path)
+		assert.Equal(t, "Basic c3ZjOnNlY3JldA==", requests[0].Headers.Get("Proxy-Authorization"))
+	})
+
+	t.Run("should bypass the proxy for NO_PROXY hosts", func(t *testing.T) {
+		proxy := newOTLPReceiver(t)
+		config := createConfig(t, "collector.example.invalid:4318").
+			WithTimeout(time.Second).
+			WithProxy(proxy.server.URL).
+			WithNoProxy(".example.invalid")
+
+		_ = exportSpan(t, config) // the collector host does not resolve
+
+		assert.Empty(t, proxy.received())
+	})
+
+	t.Run("should use a custom transport with static and dynamic headers", func(t *testing.T) {
+		receiver := newOTLPReceiver(t)
+		signer := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Signature", "signed")
+			return http.DefaultTransport.RoundTrip(req)
+		})
+		var calls int
+		var mu sync.Mutex
+		config := createConfig(t, receiver.endpoint()).
+			WithHTTPTransport(signer).
+			WithHeader("X-Tenant-ID", "tenant-a").
+			WithHeaderProvider(func(context.Context) (map[string]string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				return map[string]string{"X-Token": "token-1"}, nil
+			})
+
+		require.NoError(t, exportSpan(t, config))
+
+		requests := receiver.received()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "signed", requests[0].Headers.Get("X-Signature"))
+		assert.Equal(t, "tenant-a", requests[0].Headers.Get("X-Tenant-ID"))
+		assert.Equal(t, "token-1", requests[0].Headers.Get("X-Token"))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should use a custom HTTP client", func(t *testing.T) {
+		receiver := newOTLPReceiver(t)
+		var used bool
+		httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(req)
+		})}
+
+		require.NoError(t, exportSpan(t, createConfig(t, receiver.endpoint()).WithHTTPClient(httpClient)))
+
+		assert.True(t, used)
+		assert.Len(t, receiver.received(), 1)
+	})
+
+	t.Run("should fail the export when the header provider fails", func(t *testing.T) {
+		receiver := newOTLPReceiver(t)
+		config := createConfig(t, receiver.endpoint()).
+			WithHeaderProvider(func(context.Context) (map[string]string, error) {
+				return nil, assert.AnError
+			})
+
+		assert.Error(t, exportSpan(t, config))
+		assert.Empty(t, receiver.received())
+	})
+}
@@ -0,0 +1,312 @@
+// Package infrastructure_test provides unit tests for export status tracking and callbacks.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// events collects export events from the callbacks
+type events struct {
+	mu      sync.Mutex
+	success []domain.ExportEvent
+	failure []domain.ExportEvent
+}
+
+func (e *events) onSuccess(event domain.ExportEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.success = append(e.success, event)
+}
+
+func (e *events) onFailure(event domain.ExportEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failure = append(e.failure, event)
+}
+
+// bySignal returns the events of one signal
+func bySignal(list []domain.ExportEvent, signal domain.SignalType) []domain.ExportEvent {
+	var result []domain.ExportEvent
+	for _, event := range list {
+		if event.Signal == signal {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// recording sends the export callbacks of a client into recorded
+func recording(recorded *events) telemetryflowtest.Option {
+	return func(b *telemetryflow.Builder) {
+		b.OnExportSuccess(recorded.onSuccess).OnExportFailure(recorded.onFailure)
+	}
+}
+
+func TestExportStatus_Callbacks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report successful exports to callbacks, health and status", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolGRPC, recording(recorded))
+
+		for range 2 {
+			spanID, err := client.StartSpan(ctx, "operation", "internal", nil)
+			require.NoError(t, err)
+			require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		}
+		require.NoError(t, client.Flush(ctx))
+
+		traces := bySignal(recorded.success, domain.SignalTraces)
+		require.Len(t, traces, 1)
+		assert.Equal(t, "otlp", traces[0].Exporter)
+		assert.Equal(t, 2, traces[0].Items)
+		assert.Positive(t, traces[0].Duration)
+		assert.NoError(t, traces[0].Err)
+		assert.Empty(t, recorded.failure)
+
+		health, err := client.Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "healthy", health.Status)
+		assert.False(t, health.LastSuccess.IsZero())
+		assert.NoError(t, health.LastError)
+		assert.Positive(t, health.Metrics.TotalRequests)
+		assert.Zero(t, health.Metrics.FailedRequests)
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), status.Statistics.TracesSent)
+	})
+
+	t.Run("should report failed exports and fill LastError", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadRequest, Signal: domain.SignalLogs})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolHTTP, recording(recorded))
+
+		require.NoError(t, client.LogInfo(ctx, "lost", nil))
+		_ = client.Flush(ctx)
+
+		logs := bySignal(recorded.failure, domain.SignalLogs)
+		require.Len(t, logs, 1)
+		assert.Equal(t, 1, logs[0].Items)
+		assert.Error(t, logs[0].Err)
+		assert.NotErrorIs(t, logs[0].Err, domain.ErrCredentialsRejected)
+
+		health, err := client.Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "degraded", health.Status)
+		assert.Error(t, health.LastError)
+		assert.Equal(t, int64(1), health.Metrics.FailedRequests)
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), status.Statistics.ErrorsCount)
+	})
+
+	t.Run("should keep a newer endpoint error over an older export error", func(t *testing.T) {
+		primary := telemetryflowtest.NewCollector(t)
+		primary.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadRequest, Signal: domain.SignalTraces})
+		secondary := telemetryflowtest.NewCollector(t)
+		secondary.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadRequest, Signal: domain.SignalTraces, Times: 1})
+		client := primary.NewClient(t, domain.ProtocolGRPC, func(b *telemetryflow.Builder) {
+			b.WithSignals(false, false, true).
+				WithFailoverEndpoints(secondary.GRPCEndpoint()).
+				WithFailoverProbeInterval(time.Nanosecond)
+		})
+
+		// Both endpoints fail the first export; the second moves past the primary again
+		for range 2 {
+			spanID, err := client.StartSpan(ctx, "checkout", "internal", nil)
+			require.NoError(t, err)
+			require.NoError(t, client.EndSpan(ctx, spanID, nil))
+			_ = client.Flush(ctx)
+		}
+
+		health, err := client.Health(ctx)
+		require.NoError(t, err)
+		require.Error(t, health.LastError)
+		assert.NotContains(t, health.LastError.Error(), "all traces endpoints failed")
+		assert.Equal(t, int64(1), health.Metrics.FailedRequests)
+	})
+}
+
+func TestExportStatus_CredentialRejection(t *testing.T) {
+	ctx := context.Background()
+
+	for _, protocol := range []domain.Protocol{domain.ProtocolGRPC, domain.ProtocolHTTP} {
+		t.Run("should stop exporting after repeated credential rejections over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusUnauthorized, Signal: domain.SignalLogs})
+			recorded := &events{}
+			client := collector.NewClient(t, protocol, recording(recorded))
+
+			for range 5 {
+				require.NoError(t, client.LogInfo(ctx, "rejected", nil))
+				_ = client.Flush(ctx)
+			}
+
+			logs := bySignal(recorded.failure, domain.SignalLogs)
+			require.Len(t, logs, 5)
+			for _, event := range logs {
+				assert.ErrorIs(t, event.Err, domain.ErrCredentialsRejected)
+			}
+			assert.Contains(t, logs[2].Err.Error(), "exporting stopped")
+			assert.Len(t, collector.Requests(domain.SignalLogs), 3)
+
+			health, err := client.Health(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "unhealthy", health.Status)
+			assert.Equal(t, "unauthorized", health.Metrics.ConnectionState)
+			assert.ErrorIs(t, health.LastError, domain.ErrCredentialsRejected)
+		})
+	}
+
+	t.Run("should keep exporting when a rejection is followed by a success", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusForbidden, Signal: domain.SignalLogs, Times: 2})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolHTTP, recording(recorded))
+
+		for range 5 {
+			require.NoError(t, client.LogInfo(ctx, "flaky", nil))
+			_ = client.Flush(ctx)
+		}
+
+		assert.Len(t, bySignal(recorded.failure, domain.SignalLogs), 2)
+		assert.Len(t, bySignal(recorded.success, domain.SignalLogs), 3)
+		assert.Len(t, collector.LogRecords(), 3)
+	})
+}
+
+// errorHandler collects the errors it is given
+type errorHandler struct {
+	mu      sync.Mutex
+	handled []error
+}
+
+func (h *errorHandler) Handle(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, err)
+}
+
+func (h *errorHandler) errors() []error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.handled)
+}
+
+// keepGlobalHandler restores the global error handler when the test ends
+func keepGlobalHandler(t *testing.T) {
+	previous := otel.GetErrorHandler()
+	t.Cleanup(func() {
+		if otel.GetErrorHandler() != previous {
+			otel.SetErrorHandler(previous)
+		}
+	})
+}
+
+func TestExportStatus_ErrorHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should route background export errors to the error handler", func(t *testing.T) {
+		keepGlobalHandler(t)
+
+		configured := &errorHandler{}
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadRequest, Signal: domain.SignalTraces})
+		client := collector.NewClient(t, domain.ProtocolGRPC, func(b *telemetryflow.Builder) {
+			b.WithErrorHandler(configured.Handle).WithBatchSettings(50*time.Millisecond, 512)
+		})
+
+		spanID, err := client.StartSpan(ctx, "operation", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+
+		assert.Eventually(t, func() bool {
+			return len(configured.errors()) > 0
+		}, 5*time.Second, 20*time.Millisecond)
+		assert.ErrorContains(t, configured.errors()[0], "InvalidArgument")
+	})
+
+	t.Run("should report background errors as LastError", func(t *testing.T) {
+		keepGlobalHandler(t)
+
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolHTTP)
+
+		background := errors.New("background failure")
+		otel.Handle(background)
+
+		health, err := client.Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, background, health.LastError)
+		assert.Zero(t, health.Metrics.FailedRequests)
+	})
+
+	t.Run("should pass background errors to the previous handler without an error handler", func(t *testing.T) {
+		keepGlobalHandler(t)
+
+		previous := &errorHandler{}
+		otel.SetErrorHandler(previous)
+
+		collector := telemetryflowtest.NewCollector(t)
+		collector.NewClient(t, domain.ProtocolHTTP)
+
+		otel.Handle(errors.New("background failure"))
+		assert.Len(t, previous.errors(), 1)
+	})
+
+	t.Run("should restore the previous handler on shutdown", func(t *testing.T) {
+		keepGlobalHandler(t)
+
+		previous := &errorHandler{}
+		otel.SetErrorHandler(previous)
+
+		configured := &errorHandler{}
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolHTTP, func(b *telemetryflow.Builder) {
+			b.WithErrorHandler(configured.Handle)
+		})
+		assert.NotSame(t, previous, otel.GetErrorHandler())
+
+		require.NoError(t, client.Shutdown(ctx))
+		assert.Same(t, previous, otel.GetErrorHandler())
+
+		otel.Handle(errors.New("after shutdown"))
+		assert.Len(t, previous.errors(), 1)
+		assert.Empty(t, configured.errors())
+	})
+}
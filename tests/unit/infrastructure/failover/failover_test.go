@@ -0,0 +1,203 @@
+// Package infrastructure_test provides unit tests for exporter failover.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// stubSpanExporter records exported spans and fails while err is set
+type stubSpanExporter struct {
+	mu       sync.Mutex
+	err      error
+	exported int
+}
+
+func (e *stubSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.exported += len(spans)
+	return nil
+}
+
+func (e *stubSpanExporter) Shutdown(ctx context.Context) error { return nil }
+
+func (e *stubSpanExporter) setError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *stubSpanExporter) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.exported
+}
+
+func testSpans() []sdktrace.ReadOnlySpan {
+	return tracetest.SpanStubs{{Name: "span-1"}, {Name: "span-2"}}.Snapshots()
+}
+
+func newFailoverExporter(probeInterval time.Duration) (*infrastructure.FailoverSpanExporter, *stubSpanExporter, *stubSpanExporter) {
+	primary := &stubSpanExporter{}
+	secondary := &stubSpanExporter{}
+	pool := infrastructure.NewEndpointPool(domain.SignalTraces, []string{"primary:4317", "secondary:4317"}, probeInterval)
+	exporter := infrastructure.NewFailoverSpanExporter(pool, []sdktrace.SpanExporter{primary, secondary})
+	return exporter, primary, secondary
+}
+
+func TestFailoverSpanExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should export to primary when healthy", func(t *testing.T) {
+		exporter, primary, secondary := newFailoverExporter(time.Minute)
+
+		require.NoError(t, exporter.ExportSpans(ctx, testSpans()))
+
+		assert.Equal(t, 2, primary.count())
+		assert.Equal(t, 0, secondary.count())
+		assert.Equal(t, "primary:4317", exporter.Pool().ActiveEndpoint())
+		assert.Equal(t, int64(0), exporter.Pool().Failovers())
+	})
+
+	t.Run("should fail over to secondary and report the event", func(t *testing.T) {
+		exporter, primary, secondary := newFailoverExporter(time.Minute)
+		primary.setError(errors.New("connection refused"))
+
+		var events []infrastructure.FailoverEvent
+		exporter.Pool().OnFailover(func(event infrastructure.FailoverEvent) {
+			events = append(events, event)
+		})
+
+		require.NoError(t, exporter.ExportSpans(ctx, testSpans()))
+
+		assert.Equal(t, 2, secondary.count())
+		assert.Equal(t, "secondary:4317", exporter.Pool().ActiveEndpoint())
+		require.Len(t, events, 1)
+		assert.Equal(t, "primary:4317", events[0].From)
+		assert.Equal(t, "secondary:4317", events[0].To)
+		assert.Equal(t, domain.SignalTraces, events[0].Signal)
+
+		health := exporter.Pool().Health()
+		require.Len(t, health, 2)
+		assert.False(t, health[0].Healthy)
+		assert.Equal(t, 1, health[0].ConsecutiveFailures)
+		assert.EqualError(t, health[0].LastError, "connection refused")
+		assert.True(t, health[1].Healthy)
+		assert.True(t, health[1].Active)
+	})
+
+	t.Run("should skip failed primary until the probe interval passes", func(t *testing.T) {
+		exporter, primary, secondary := newFailoverExporter(time.Minute)
+		primary.setError(errors.New("connection refused"))
+		require.NoError(t, exporter.ExportSpans(ctx, testSpans()))
+
+		primary.setError(nil)
+		require.NoError(t, exporter.ExportSpans(ctx, testSpans()))
+
+		assert.Equal(t, 0, primary.count())
+		assert.Equal(t, 4, secondary.count())
+	})
+
+	t.Run("should fall back to primary once it recovers", func(t *testing.T) {
+		exporter, primary, secondary := newFailoverExporter(10 * time.Millisecond)
+		primary.setError(errors.New("connection refused"))
+		require.NoError(t, exporter.ExportSpans(ctx, testSpans()))
+
+		primary.setError(nil)
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, exporter.ExportSpans(ctx, testSpans()))
+
+		assert.Equal(t, 2, primary.count())
+		assert.Equal(t, 2, secondary.count())
+		assert.Equal(t, "primary:4317", exporter.Pool().ActiveEndpoint())
+		assert.Equal(t, int64(2), exporter.Pool().Failovers())
+	})
+
+	t.Run("should return error when all endpoints fail", func(t *testing.T) {
+		exporter, primary, secondary := newFailoverExporter(time.Minute)
+		primary.setError(errors.New("primary down"))
+		secondary.setError(errors.New("secondary down"))
+
+		err := exporter.ExportSpans(ctx, testSpans())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all traces endpoints failed")
+		assert.Contains(t, err.Error(), "primary down")
+		assert.Contains(t, err.Error(), "secondary down")
+	})
+}
+
+func TestClient_FailoverMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should count failovers per transition", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		// Nothing listens on the primary endpoint
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		primary := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) {
+			b.WithAPIKey("tfk_test", "tfs_secret").
+				WithEndpoint(primary).
+				WithFailoverEndpoints(collector.HTTPEndpoint()).
+				WithProtocol(domain.ProtocolHTTP).
+				WithInsecure(true).
+				WithExporters(domain.ExporterOTLP).
+				WithRetry(false, 0, 0)
+		})
+		client := kit.Client()
+
+		spanID, err := client.StartSpan(ctx, "checkout", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.Flush(ctx))
+
+		failovers, ok := kit.FindMetric("telemetryflow.sdk.exporter.failovers")
+		require.True(t, ok)
+		point, ok := failovers.Point(map[string]interface{}{
+			"signal": "traces",
+			"from":   primary,
+			"to":     collector.HTTPEndpoint(),
+		})
+		require.True(t, ok)
+		assert.Equal(t, float64(1), point.Value)
+	})
+}
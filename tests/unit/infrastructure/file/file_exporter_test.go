@@ -0,0 +1,295 @@
+// Package infrastructure_test provides unit tests for the file exporter and replay.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// writeTelemetry records a span, a counter and a log line through the file exporter
+func writeTelemetry(t *testing.T, dir string) string {
+	ctx := context.Background()
+	client, err := telemetryflow.NewBuilder().
+		WithService("file-service", "1.0.0").
+		WithExporters(domain.ExporterFile).
+		WithFileExporter(dir).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, client.Initialize(ctx))
+
+	spanID, err := client.StartSpan(ctx, "upload", "client", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.EndSpan(ctx, spanID, nil))
+	require.NoError(t, client.IncrementCounter(ctx, "uploads", 1, nil))
+	require.NoError(t, client.LogInfo(ctx, "uploaded", nil))
+	require.NoError(t, client.Shutdown(ctx))
+	return spanID
+}
+
+func TestFileExporter(t *testing.T) {
+	t.Run("should write each signal as OTLP/JSON lines", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTelemetry(t, dir)
+
+		traces, err := os.ReadFile(filepath.Join(dir, "traces.jsonl"))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(traces)), "\n")
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], `"name":"upload"`)
+		assert.Contains(t, lines[0], `"kind":3`, "enums are integers")
+		assert.Regexp(t, `"traceId":"[0-9a-f]{32}"`, lines[0], "IDs are hex")
+
+		for _, name := range []string{"metrics.jsonl", "logs.jsonl"} {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			assert.NotEmpty(t, data, name)
+		}
+		logs, _ := os.ReadFile(filepath.Join(dir, "logs.jsonl"))
+		assert.Contains(t, string(logs), `"severityNumber":9`)
+	})
+}
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("should rotate by size and gzip rotated files", func(t *testing.T) {
+		dir := t.TempDir()
+		file, err := infrastructure.NewRotatingFile("traces", domain.FileExporterConfig{
+			Directory: dir,
+			MaxSize:   10,
+			Compress:  true,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, file.WriteLine([]byte(`{"a":1}`)))
+		require.NoError(t, file.WriteLine([]byte(`{"b":2}`)))
+		require.NoError(t, file.WriteLine([]byte(`{"c":3}`)))
+		require.NoError(t, file.Close())
+
+		rotated, err := filepath.Glob(filepath.Join(dir, "traces-*.jsonl.gz"))
+		require.NoError(t, err)
+		assert.Len(t, rotated, 2)
+		active, err := os.ReadFile(filepath.Join(dir, "traces.jsonl"))
+		require.NoError(t, err)
+		assert.Equal(t, "{\"c\":3}\n", string(active))
+	})
+
+	t.Run("should rotate by age", func(t *testing.T) {
+		dir := t.TempDir()
+		file, err := infrastructure.NewRotatingFile("logs", domain.FileExporterConfig{
+			Directory: dir,
+			MaxAge:    time.Millisecond,
+		})
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+
+		require.NoError(t, file.WriteLine([]byte(`{}`)))
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, file.WriteLine([]byte(`{}`)))
+
+		rotated, err := filepath.Glob(filepath.Join(dir, "logs-*.jsonl"))
+		require.NoError(t, err)
+		assert.Len(t, rotated, 1)
+	})
+
+	t.Run("should continue an existing active file", func(t *testing.T) {
+		dir := t.TempDir()
+		config := domain.FileExporterConfig{Directory: dir}
+		for _, line := range []string{`{"run":1}`, `{"run":2}`} {
+			file, err := infrastructure.NewRotatingFile("metrics", config)
+			require.NoError(t, err)
+			require.NoError(t, file.WriteLine([]byte(line)))
+			require.NoError(t, file.Close())
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "metrics.jsonl"))
+		require.NoError(t, err)
+		assert.Equal(t, "{\"run\":1}\n{\"run\":2}\n", string(data))
+	})
+
+	t.Run("should keep writing when a rotation fails", func(t *testing.T) {
+		dir := t.TempDir()
+		file, err := infrastructure.NewRotatingFile("traces", domain.FileExporterConfig{Directory: dir})
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+
+		// Nothing left to rename
+		require.NoError(t, os.Remove(file.Path()))
+		require.Error(t, file.Rotate())
+
+		require.NoError(t, file.WriteLine([]byte(`{"after":1}`)))
+		data, err := os.ReadFile(file.Path())
+		require.NoError(t, err)
+		assert.Equal(t, "{\"after\":1}\n", string(data))
+	})
+}
+
+func TestReplayFiles(t *testing.T) {
+	t.Run("should list rotated files oldest first and the active file last", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{
+			"traces.jsonl",
+			"traces-20261018T100000.000000000.jsonl.gz",
+			"traces-20261018T090000.000000000.jsonl",
+			"notes.txt",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+		}
+
+		files, err := infrastructure.ReplayFiles(dir)
+		require.NoError(t, err)
+
+		var names []string
+		for _, file := range files {
+			names = append(names, filepath.Base(file))
+		}
+		assert.Equal(t, []string{
+			"traces-20261018T090000.000000000.jsonl",
+			"traces-20261018T100000.000000000.jsonl.gz",
+			"traces.jsonl",
+		}, names)
+	})
+}
+
+// traceCollector is a gRPC trace service that records export requests
+type traceCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+	mu       sync.Mutex
+	requests []*coltracepb.ExportTraceServiceRequest
+	keyIDs   []string
+}
+
+func (c *traceCollector) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	c.keyIDs = append(c.keyIDs, strings.Join(md.Get("x-telemetryflow-key-id"), ","))
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func TestReplayer(t *testing.T) {
+	ctx := context.Background()
+	creds, _ := domain.NewCredentials("tfk_replay", "tfs_secret")
+
+	t.Run("should replay files over HTTP with credentials", func(t *testing.T) {
+		dir := t.TempDir()
+		spanID := writeTelemetry(t, dir)
+
+		var mu sync.Mutex
+		received := map[string]http.Header{}
+		var traceBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			mu.Lock()
+			received[req.URL.Path] = req.Header.Clone()
+			if req.URL.Path == "/v2/traces" {
+				traceBody = body
+			}
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		config, err := domain.NewTelemetryConfig(creds, server.URL, "replay")
+		require.NoError(t, err)
+		config.WithCompression(false)
+		replayer := infrastructure.NewReplayer(config)
+		defer func() { _ = replayer.Close() }()
+
+		results, err := replayer.ReplayPaths(ctx, dir)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.ElementsMatch(t, []string{"/v2/traces", "/v2/metrics", "/v2/logs"}, keys(received))
+		assert.Equal(t, "tfk_replay", received["/v2/traces"].Get("X-TelemetryFlow-Key-ID"))
+
+		var request coltracepb.ExportTraceServiceRequest
+		require.NoError(t, proto.Unmarshal(traceBody, &request))
+		span := request.ResourceSpans[0].ScopeSpans[0].Spans[0]
+		assert.Equal(t, "upload", span.Name)
+		assert.Equal(t, spanID, hex.EncodeToString(span.SpanId))
+	})
+
+	t.Run("should replay files over gRPC", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTelemetry(t, dir)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		collector := &traceCollector{}
+		server := grpc.NewServer()
+		coltracepb.RegisterTraceServiceServer(server, collector)
+		go func() { _ = server.Serve(listener) }()
+		defer server.Stop()
+
+		config, err := domain.NewTelemetryConfig(creds, listener.Addr().String(), "replay")
+		require.NoError(t, err)
+		config.WithInsecure(true)
+		replayer := infrastructure.NewReplayer(config)
+		defer func() { _ = replayer.Close() }()
+
+		result, err := replayer.ReplayFile(ctx, filepath.Join(dir, "traces.jsonl"))
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Requests)
+		assert.Equal(t, domain.SignalTraces, result.Signal)
+		require.Len(t, collector.requests, 1)
+		assert.Equal(t, "tfk_replay", collector.keyIDs[0])
+	})
+
+	t.Run("should reject files without a signal name", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "capture.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "replay")
+
+		_, err := infrastructure.NewReplayer(config).ReplayFile(ctx, path)
+
+		require.Error(t, err)
+	})
+}
+
+func keys(m map[string]http.Header) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,237 @@
+// Package infrastructure_test provides unit tests for the process and host metrics.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// procFiles is a minimal procfs snapshot
+var procFiles = map[string]string{
+	"self/stat":  "4242 (my (odd) app) S 1 4242 4242 0 -1 4194560 900 0 0 0 250 75 0 0 20 0 7 0 1000 104857600 2560 18446744073709551615\n",
+	"self/statm": "25600 2560 512 10 0 4096 0\n",
+	"stat": "cpu  1000 10 500 8000 200 5 15 20 0 0\n" +
+		"cpu0 500 5 250 4000 100 3 8 10 0 0\n" +
+		"cpu1 500 5 250 4000 100 2 7 10 0 0\n" +
+		"intr 12345\n",
+	"meminfo": "MemTotal:        8000 kB\n" +
+		"MemFree:         2000 kB\n" +
+		"MemAvailable:    5000 kB\n" +
+		"Buffers:          500 kB\n" +
+		"Cached:          1000 kB\n" +
+		"SReclaimable:     500 kB\n",
+	"loadavg": "0.50 0.25 0.10 1/100 4242\n",
+	"net/dev": "Inter-|   Receive                                                |  Transmit\n" +
+		" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n" +
+		"  eth0:  1000    10    1    2    0     0          0         0     2000      20    3    4    0     0       0          0\n",
+	"diskstats": "   7       0 loop0 5 0 40 0 5 0 40 0 0 0 0\n" +
+		" 259       0 nvme0n1 100 0 800 0 50 0 400 0 0 0 0\n" +
+		" 259       1 nvme0n1p1 60 0 480 0 30 0 240 0 0 0 0\n" +
+		"   8       0 sda 10 0 80 0 5 0 40 0 0 0 0\n" +
+		"   8       1 sda1 10 0 80 0 5 0 40 0 0 0 0\n",
+}
+
+// writeProc writes procFiles below a temporary procfs root
+func writeProc(t *testing.T) string {
+	root := t.TempDir()
+	for name, content := range procFiles {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	fdDir := filepath.Join(root, "self", "fd")
+	require.NoError(t, os.MkdirAll(fdDir, 0o755))
+	// 3 is the descriptor opened to list the directory
+	for _, fd := range []string{"0", "1", "2", "3"} {
+		require.NoError(t, os.WriteFile(filepath.Join(fdDir, fd), nil, 0o644))
+	}
+	return root
+}
+
+// collect registers host metrics on a manual reader and returns one collection by name
+func collect(t *testing.T, hostMetrics *infrastructure.HostMetrics) map[string]metricdata.Metrics {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	_, err := hostMetrics.Register(provider.Meter(infrastructure.HostScopeName))
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	result := make(map[string]metricdata.Metrics)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			result[m.Name] = m
+		}
+	}
+	return result
+}
+
+// int64Value returns the data point of an int64 metric whose attributes include attrs
+func int64Value(t *testing.T, m metricdata.Metrics, attrs ...attribute.KeyValue) int64 {
+	var points []metricdata.DataPoint[int64]
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		points = data.DataPoints
+	case metricdata.Gauge[int64]:
+		points = data.DataPoints
+	}
+	for _, point := range points {
+		if hasAttributes(point.Attributes, attrs) {
+			return point.Value
+		}
+	}
+	t.Fatalf("%s: no point with %v", m.Name, attrs)
+	return 0
+}
+
+// float64Value returns the data point of a float64 metric whose attributes include attrs
+func float64Value(t *testing.T, m metricdata.Metrics, attrs ...attribute.KeyValue) float64 {
+	var points []metricdata.DataPoint[float64]
+	switch data := m.Data.(type) {
+	case metricdata.Sum[float64]:
+		points = data.DataPoints
+	case metricdata.Gauge[float64]:
+		points = data.DataPoints
+	}
+	for _, point := range points {
+		if hasAttributes(point.Attributes, attrs) {
+			return point.Value
+		}
+	}
+	t.Fatalf("%s: no point with %v", m.Name, attrs)
+	return 0
+}
+
+func hasAttributes(set attribute.Set, attrs []attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if value, ok := set.Value(attr.Key); !ok || value != attr.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func TestHostMetrics_Process(t *testing.T) {
+	t.Run("should report process metrics from /proc/self", func(t *testing.T) {
+		metrics := collect(t, infrastructure.NewHostMetrics(writeProc(t), true, false))
+		pageSize := int64(os.Getpagesize())
+
+		assert.InDelta(t, 2.5, float64Value(t, metrics["process.cpu.time"], attribute.String("cpu.mode", "user")), 1e-9)
+		assert.InDelta(t, 0.75, float64Value(t, metrics["process.cpu.time"], attribute.String("cpu.mode", "system")), 1e-9)
+		assert.Equal(t, int64(7), int64Value(t, metrics["process.thread.count"]))
+		assert.Equal(t, 2560*pageSize, int64Value(t, metrics["process.memory.usage"]))
+		assert.Equal(t, 25600*pageSize, int64Value(t, metrics["process.memory.virtual"]))
+		assert.Equal(t, int64(3), int64Value(t, metrics["process.unix.file_descriptor.count"]))
+		assert.NotContains(t, metrics, "system.cpu.time")
+	})
+}
+
+func TestHostMetrics_Host(t *testing.T) {
+	t.Run("should report host metrics from /proc", func(t *testing.T) {
+		metrics := collect(t, infrastructure.NewHostMetrics(writeProc(t), false, true))
+
+		assert.InDelta(t, 10.0, float64Value(t, metrics["system.cpu.time"], attribute.String("cpu.mode", "user")), 1e-9)
+		assert.InDelta(t, 80.0, float64Value(t, metrics["system.cpu.time"], attribute.String("cpu.mode", "idle")), 1e-9)
+		assert.InDelta(t, 0.2, float64Value(t, metrics["system.cpu.time"], attribute.String("cpu.mode", "steal")), 1e-9)
+		assert.Equal(t, int64(2), int64Value(t, metrics["system.cpu.logical.count"]))
+
+		assert.Equal(t, int64(8000<<10), int64Value(t, metrics["system.memory.limit"]))
+		assert.Equal(t, int64(4000<<10), int64Value(t, metrics["system.memory.usage"], attribute.String("system.memory.state", "used")))
+		assert.Equal(t, int64(1500<<10), int64Value(t, metrics["system.memory.usage"], attribute.String("system.memory.state", "cached")))
+
+		assert.InDelta(t, 0.25, float64Value(t, metrics["system.cpu.load_average.5m"]), 1e-9)
+
+		eth0 := attribute.String("network.interface.name", "eth0")
+		assert.Equal(t, int64(1000), int64Value(t, metrics["system.network.io"], eth0, attribute.String("network.io.direction", "receive")))
+		assert.Equal(t, int64(20), int64Value(t, metrics["system.network.packets"], eth0, attribute.String("network.io.direction", "transmit")))
+		assert.Equal(t, int64(4), int64Value(t, metrics["system.network.dropped"], eth0, attribute.String("network.io.direction", "transmit")))
+
+		nvme := attribute.String("system.device", "nvme0n1")
+		assert.Equal(t, int64(800*512), int64Value(t, metrics["system.disk.io"], nvme, attribute.String("disk.io.direction", "read")))
+		assert.Equal(t, int64(50), int64Value(t, metrics["system.disk.operations"], nvme, attribute.String("disk.io.direction", "write")))
+
+		assert.Equal(t, int64(80*512), int64Value(t, metrics["system.disk.io"], attribute.String("system.device", "sda"), attribute.String("disk.io.direction", "read")))
+
+		// Loop devices and partitions repeat I/O counted elsewhere
+		disks := metrics["system.disk.io"].Data.(metricdata.Sum[int64]).DataPoints
+		for _, point := range disks {
+			device, _ := point.Attributes.Value("system.device")
+			assert.NotContains(t, []string{"loop0", "nvme0n1p1", "sda1"}, device.AsString())
+		}
+	})
+
+	t.Run("should keep the other metrics when a file is unreadable", func(t *testing.T) {
+		root := writeProc(t)
+		require.NoError(t, os.Remove(filepath.Join(root, "diskstats")))
+
+		metrics := collect(t, infrastructure.NewHostMetrics(root, false, true))
+
+		assert.Contains(t, metrics, "system.cpu.time")
+		assert.Contains(t, metrics, "system.network.io")
+		assert.NotContains(t, metrics, "system.disk.io")
+	})
+}
+
+func TestHostMetrics_Check(t *testing.T) {
+	t.Run("should accept a complete procfs", func(t *testing.T) {
+		assert.NoError(t, infrastructure.NewHostMetrics(writeProc(t), true, true).Check())
+	})
+
+	t.Run("should reject a missing procfs", func(t *testing.T) {
+		err := infrastructure.NewHostMetrics(filepath.Join(t.TempDir(), "missing"), true, false).Check()
+		assert.ErrorContains(t, err, "procfs")
+	})
+}
+
+func TestHostMetrics_Client(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process and host metrics read Linux procfs")
+	}
+
+	t.Run("should collect process and host metrics through the client", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) {
+			b.WithProcessMetrics().WithHostMetrics()
+		})
+
+		threads, ok := kit.FindMetric("process.thread.count")
+		require.True(t, ok)
+		assert.Greater(t, threads.Total(), 0.0)
+
+		limit, ok := kit.FindMetric("system.memory.limit")
+		require.True(t, ok)
+		assert.Greater(t, limit.Total(), 0.0)
+	})
+}
@@ -0,0 +1,226 @@
+// Package infrastructure_test provides unit tests for OTLP/HTTP JSON encoding.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// receiver is an OTLP/HTTP endpoint that only accepts OTLP/JSON bodies
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	bodies   map[string][]string // raw JSON bodies by signal
+	requests map[string][]proto.Message
+	response string // JSON body of successful responses
+}
+
+func newReceiver(t *testing.T) *receiver {
+	t.Helper()
+	r := &receiver{bodies: make(map[string][]string), requests: make(map[string][]proto.Message), response: "{}"}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) serve(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, "only JSON is accepted", http.StatusUnsupportedMediaType)
+		return
+	}
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil || !json.Valid(data) {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	signal := path.Base(req.URL.Path)
+	var msg proto.Message
+	switch signal {
+	case "traces":
+		msg = &coltracepb.ExportTraceServiceRequest{}
+	case "metrics":
+		msg = &colmetricspb.ExportMetricsServiceRequest{}
+	default:
+		msg = &collogspb.ExportLogsServiceRequest{}
+	}
+	if err := infrastructure.UnmarshalOTLPJSON(data, msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	r.bodies[signal] = append(r.bodies[signal], string(data))
+	r.requests[signal] = append(r.requests[signal], msg)
+	response := r.response
+	r.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(response))
+}
+
+func (r *receiver) received(signal string) ([]string, []proto.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bodies[signal], r.requests[signal]
+}
+
+// jsonEncoding exports as OTLP/JSON
+func jsonEncoding(b *telemetryflow.Builder) {
+	b.WithHTTPEncoding(domain.HTTPEncodingJSON)
+}
+
+func TestHTTPJSONEncoding(t *testing.T) {
+	ctx := context.Background()
+
+	for _, compression := range []bool{false, true} {
+		t.Run(fmt.Sprintf("should export all signals as OTLP/JSON (gzip: %t)", compression), func(t *testing.T) {
+			r := newReceiver(t)
+			client := telemetryflowtest.NewClient(t, r.URL, jsonEncoding, func(b *telemetryflow.Builder) { b.WithCompression(compression) })
+
+			spanID, err := client.StartSpan(ctx, "checkout", "server", map[string]interface{}{"cart.items": 3})
+			require.NoError(t, err)
+			require.NoError(t, client.EndSpan(ctx, spanID, nil))
+			require.NoError(t, client.IncrementCounter(ctx, "orders.total", 1, nil))
+			require.NoError(t, client.LogInfo(ctx, "order placed", nil))
+			require.NoError(t, client.Flush(ctx))
+
+			bodies, requests := r.received("traces")
+			require.Len(t, requests, 1)
+			spans := requests[0].(*coltracepb.ExportTraceServiceRequest).ResourceSpans[0].ScopeSpans[0].Spans
+			require.Len(t, spans, 1)
+			assert.Equal(t, "checkout", spans[0].Name)
+			// OTLP/JSON uses hex IDs and integer enums
+			assert.Regexp(t, regexp.MustCompile(`"traceId":"[0-9a-f]{32}"`), bodies[0])
+			assert.Contains(t, bodies[0], `"kind":2`)
+
+			_, requests = r.received("metrics")
+			require.NotEmpty(t, requests)
+			metric := requests[0].(*colmetricspb.ExportMetricsServiceRequest).ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+			assert.Equal(t, "orders.total", metric.Name)
+
+			_, requests = r.received("logs")
+			require.Len(t, requests, 1)
+			record := requests[0].(*collogspb.ExportLogsServiceRequest).ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+			assert.Equal(t, "order placed", record.Body.GetStringValue())
+		})
+	}
+
+	t.Run("should report partial success from JSON responses", func(t *testing.T) {
+		r := newReceiver(t)
+		r.response = `{"partialSuccess":{"rejectedSpans":"1","errorMessage":"span too old"}}`
+		var mu sync.Mutex
+		var failures []domain.ExportEvent
+		client := telemetryflowtest.NewClient(t, r.URL, jsonEncoding, func(b *telemetryflow.Builder) {
+			b.WithSignals(false, false, true).OnExportFailure(func(event domain.ExportEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				failures = append(failures, event)
+			})
+		})
+
+		spanID, err := client.StartSpan(ctx, "checkout", "server", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		_ = client.Flush(ctx)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, failures, 1)
+		assert.ErrorContains(t, failures[0].Err, "span too old")
+	})
+
+	t.Run("should apply the request size limit to JSON bodies", func(t *testing.T) {
+		r := newReceiver(t)
+		client := telemetryflowtest.NewClient(t, r.URL, jsonEncoding, func(b *telemetryflow.Builder) {
+			b.WithSignals(false, false, true).WithCompression(false).WithMaxRequestSize(8 << 10)
+		})
+
+		for range 10 {
+			spanID, err := client.StartSpan(ctx, "operation", "internal", map[string]interface{}{
+				"payload": strings.Repeat("x", 2<<10),
+			})
+			require.NoError(t, err)
+			require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		}
+		require.NoError(t, client.Flush(ctx))
+
+		bodies, requests := r.received("traces")
+		assert.Greater(t, len(bodies), 1)
+		spans := 0
+		for i, body := range bodies {
+			assert.LessOrEqual(t, len(body), 8<<10)
+			spans += len(requests[i].(*coltracepb.ExportTraceServiceRequest).ResourceSpans[0].ScopeSpans[0].Spans)
+		}
+		assert.Equal(t, 10, spans)
+	})
+
+	t.Run("should keep protobuf as the default", func(t *testing.T) {
+		var contentType string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			contentType = req.Header.Get("Content-Type")
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+
+		client := telemetryflowtest.NewClient(t, server.URL, func(b *telemetryflow.Builder) {
+			b.WithSignals(false, false, true)
+		})
+
+		spanID, err := client.StartSpan(ctx, "checkout", "server", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.Flush(ctx))
+
+		assert.Equal(t, "application/x-protobuf", contentType)
+	})
+}
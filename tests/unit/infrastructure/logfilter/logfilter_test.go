@@ -0,0 +1,248 @@
+// Package infrastructure_test provides unit tests for the log filter.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// summaries records the summaries of a filter
+type summaries struct {
+	mu   sync.Mutex
+	list []infrastructure.LogSummary
+}
+
+func (s *summaries) add(summary infrastructure.LogSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list = append(s.list, summary)
+}
+
+func (s *summaries) get() []infrastructure.LogSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]infrastructure.LogSummary(nil), s.list...)
+}
+
+func TestLogFilter(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should allow every record when disabled", func(t *testing.T) {
+		filter := infrastructure.NewLogFilter(domain.DefaultLogFilterConfig(), func(infrastructure.LogSummary) {})
+
+		for range 100 {
+			assert.True(t, filter.Allow(otellog.SeverityDebug, "debug", "", "same message", now))
+		}
+	})
+
+	t.Run("should apply the threshold of the longest logger prefix", func(t *testing.T) {
+		filter := infrastructure.NewLogFilter(domain.LogFilterConfig{
+			MinSeverity:      "warn",
+			LoggerSeverities: map[string]string{"db": "debug", "db.pool": "error"},
+		}, func(infrastructure.LogSummary) {})
+
+		assert.False(t, filter.Allow(otellog.SeverityInfo, "info", "", "m", now))
+		assert.True(t, filter.Allow(otellog.SeverityWarn, "warn", "", "m", now))
+		assert.True(t, filter.Allow(otellog.SeverityDebug, "debug", "db", "m", now))
+		assert.True(t, filter.Allow(otellog.SeverityDebug, "debug", "db.query", "m", now))
+		assert.False(t, filter.Allow(otellog.SeverityWarn, "warn", "db.pool", "m", now))
+		assert.False(t, filter.Allow(otellog.SeverityWarn, "warn", "db.pool.conn", "m", now))
+		assert.False(t, filter.Allow(otellog.SeverityInfo, "info", "dbx", "m", now))
+	})
+
+	t.Run("should sample records by message template", func(t *testing.T) {
+		filter := infrastructure.NewLogFilter(domain.LogFilterConfig{
+			SamplingInterval:   time.Minute,
+			SamplingInitial:    2,
+			SamplingThereafter: 5,
+		}, func(infrastructure.LogSummary) {})
+
+		allowed := 0
+		for i := range 22 {
+			if filter.Allow(otellog.SeverityError, "error", "", fmt.Sprintf("request %d failed", i), now) {
+				allowed++
+			}
+		}
+		// 2 first, then the 5th, 10th, 15th and 20th of the remaining 20
+		assert.Equal(t, 6, allowed)
+		assert.True(t, filter.Allow(otellog.SeverityError, "error", "", "other message", now))
+		assert.True(t, filter.Allow(otellog.SeverityError, "error", "", "request 0 failed", now.Add(time.Minute)))
+	})
+
+	t.Run("should summarize the repeats suppressed in a window", func(t *testing.T) {
+		var got summaries
+		filter := infrastructure.NewLogFilter(domain.LogFilterConfig{DedupWindow: 20 * time.Millisecond}, got.add)
+		start := time.Now()
+
+		assert.True(t, filter.Allow(otellog.SeverityWarn, "warn", "db", "retry 1 of 3", start))
+		assert.False(t, filter.Allow(otellog.SeverityWarn, "warn", "db", "retry 2 of 3", start))
+		assert.False(t, filter.Allow(otellog.SeverityWarn, "warn", "db", "retry 3 of 3", start))
+
+		require.Eventually(t, func() bool { return len(got.get()) == 1 }, time.Second, 5*time.Millisecond)
+		assert.Equal(t, infrastructure.LogSummary{
+			Severity:     otellog.SeverityWarn,
+			SeverityText: "warn",
+			Logger:       "db",
+			Template:     "retry # of #",
+			Suppressed:   2,
+		}, got.get()[0])
+		assert.True(t, filter.Allow(otellog.SeverityWarn, "warn", "db", "retry 1 of 3", start.Add(time.Second)))
+	})
+
+	t.Run("should not summarize a window without repeats", func(t *testing.T) {
+		var got summaries
+		filter := infrastructure.NewLogFilter(domain.LogFilterConfig{DedupWindow: time.Millisecond}, got.add)
+
+		assert.True(t, filter.Allow(otellog.SeverityInfo, "info", "", "once", time.Now()))
+		time.Sleep(10 * time.Millisecond)
+		filter.Close()
+
+		assert.Empty(t, got.get())
+	})
+
+	t.Run("should summarize the windows in progress when closed", func(t *testing.T) {
+		var got summaries
+		filter := infrastructure.NewLogFilter(domain.LogFilterConfig{DedupWindow: time.Hour}, got.add)
+
+		filter.Allow(otellog.SeverityInfo, "info", "", "tick", now)
+		filter.Allow(otellog.SeverityInfo, "info", "", "tick", now)
+		filter.Close()
+
+		require.Len(t, got.get(), 1)
+		assert.Equal(t, 1, got.get()[0].Suppressed)
+		assert.True(t, filter.Allow(otellog.SeverityInfo, "info", "", "tick", now))
+	})
+
+	t.Run("should apply updated settings", func(t *testing.T) {
+		filter := infrastructure.NewLogFilter(domain.LogFilterConfig{MinSeverity: "error"}, func(infrastructure.LogSummary) {})
+		require.False(t, filter.Allow(otellog.SeverityInfo, "info", "", "m", now))
+
+		filter.Update(domain.LogFilterConfig{MinSeverity: "info"})
+
+		assert.True(t, filter.Allow(otellog.SeverityInfo, "info", "", "m", now))
+		assert.Equal(t, "info", filter.Config().MinSeverity)
+	})
+}
+
+func TestClient_LogFilter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should drop logs below the configured severities", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) {
+			b.WithMinLogSeverity("warn").WithLoggerSeverity("payments", "debug")
+		})
+		client := kit.Client()
+
+		require.NoError(t, client.LogInfo(ctx, "dropped", nil))
+		require.NoError(t, client.LogWarn(ctx, "kept", nil))
+		require.NoError(t, client.Log(ctx, "debug", "payment authorized", map[string]interface{}{
+			domain.LoggerNameAttribute: "payments.card",
+		}))
+
+		logs := kit.Logs()
+		require.Len(t, logs, 2)
+		assert.Equal(t, "kept", logs[0].Body)
+		assert.Equal(t, "payment authorized", logs[1].Body)
+	})
+
+	t.Run("should change the filter at runtime", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		require.NoError(t, client.LogInfo(ctx, "before", nil))
+		require.NoError(t, client.SetLogFilter(domain.LogFilterConfig{MinSeverity: "error"}))
+		require.NoError(t, client.LogInfo(ctx, "after", nil))
+
+		require.Len(t, kit.Logs(), 1)
+		assert.Equal(t, "error", client.LogFilter().MinSeverity)
+		assert.Equal(t, "error", client.Config().LogFilter().MinSeverity)
+	})
+
+	t.Run("should reject invalid settings", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+
+		err := kit.Client().SetLogFilter(domain.LogFilterConfig{MinSeverity: "loud"})
+
+		assert.Error(t, err)
+		assert.Empty(t, kit.Client().LogFilter().MinSeverity)
+	})
+
+	t.Run("should filter batches and count filtered logs as accepted", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) { b.WithMinLogSeverity("info") })
+
+		result, err := kit.Client().LogBatch(ctx, []application.EmitLogCommand{
+			{Severity: "debug", Message: "dropped"},
+			{Severity: "info", Message: "kept"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Accepted)
+		require.Len(t, kit.Logs(), 1)
+		assert.Equal(t, "kept", kit.Logs()[0].Body)
+	})
+
+	t.Run("should emit a summary of suppressed logs", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) { b.WithLogDeduplication(20 * time.Millisecond) })
+		client := kit.Client()
+
+		for i := range 10 {
+			require.NoError(t, client.Log(ctx, "error", fmt.Sprintf("connection %d refused", i), map[string]interface{}{
+				domain.LoggerNameAttribute: "db",
+			}))
+		}
+
+		require.Eventually(t, func() bool { return len(kit.Logs()) == 2 }, time.Second, 5*time.Millisecond)
+		summary := kit.Logs()[1]
+		assert.Equal(t, "suppressed 9 similar messages: connection # refused", summary.Body)
+		assert.Equal(t, "error", summary.Severity)
+		assert.Equal(t, map[string]interface{}{
+			domain.LoggerNameAttribute: "db",
+			"log.template":             "connection # refused",
+			"log.suppressed_count":     int64(9),
+		}, summary.Attributes)
+	})
+
+	t.Run("should emit the pending summaries on shutdown", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) { b.WithLogDeduplication(time.Hour) })
+		client := kit.Client()
+
+		for range 3 {
+			require.NoError(t, client.LogWarn(ctx, "disk almost full", nil))
+		}
+		require.NoError(t, client.Shutdown(ctx))
+
+		logs := kit.Logs()
+		require.Len(t, logs, 2)
+		assert.Equal(t, "suppressed 2 similar messages: disk almost full", logs[1].Body)
+	})
+}
@@ -0,0 +1,211 @@
+// Package infrastructure_test provides unit tests for the SDK memory limiter.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// flushOnly exports spans and log records only on flush, so ended spans and emitted
+// log records stay buffered until then
+func flushOnly(b *telemetryflow.Builder) {
+	b.WithSignals(false, true, true).WithBatchSettings(time.Hour, 512)
+}
+
+// endSpans ends n spans, each with a 1 KB attribute
+func endSpans(t *testing.T, client *telemetryflow.Client, n int) {
+	t.Helper()
+	ctx := context.Background()
+	for range n {
+		spanID, err := client.StartSpan(ctx, "operation", "internal", map[string]interface{}{
+			"payload": strings.Repeat("x", 1024),
+		})
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+	}
+}
+
+// memoryStatus returns the memory limiter state from the status query
+func memoryStatus(t *testing.T, client *telemetryflow.Client) *application.MemoryStatus {
+	t.Helper()
+	status, err := client.Status(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, status.Memory)
+	return status.Memory
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should not report memory unless limited", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly)
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, status.Memory)
+	})
+
+	t.Run("should account for buffered spans until they are exported", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly, func(b *telemetryflow.Builder) {
+			b.WithMemoryLimit(1<<20, 2<<20)
+		})
+
+		endSpans(t, client, 10)
+		memory := memoryStatus(t, client)
+		assert.Equal(t, "normal", memory.State)
+		assert.Greater(t, memory.BufferedBytes, int64(10*1024))
+		assert.Equal(t, int64(1<<20), memory.SoftLimit)
+		assert.Equal(t, int64(2<<20), memory.HardLimit)
+
+		require.NoError(t, client.Flush(ctx))
+		assert.Zero(t, memoryStatus(t, client).BufferedBytes)
+		assert.Len(t, collector.Spans(), 10)
+	})
+
+	t.Run("should drop new data above the hard limit", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly, func(b *telemetryflow.Builder) {
+			b.WithMemoryLimit(0, 16<<10)
+		})
+
+		endSpans(t, client, 30)
+		memory := memoryStatus(t, client)
+		assert.Equal(t, "normal", memory.State)
+		assert.LessOrEqual(t, memory.BufferedBytes, int64(16<<10))
+		assert.Positive(t, memory.Dropped)
+
+		require.NoError(t, client.LogError(ctx, "lost while full", nil))
+		require.NoError(t, client.Flush(ctx))
+		spans := len(collector.Spans())
+		assert.Less(t, spans, 30)
+		assert.Equal(t, int64(30-spans), memory.Dropped)
+
+		// Once the buffers are exported, data is accepted again
+		endSpans(t, client, 1)
+		require.NoError(t, client.Flush(ctx))
+		assert.Len(t, collector.Spans(), spans+1)
+	})
+
+	t.Run("should sample less and drop low severity logs above the soft limit", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly, func(b *telemetryflow.Builder) {
+			b.WithMemoryLimit(8<<10, 1<<20).WithMemoryLimiterDegradation(0, "warn")
+		})
+
+		// Each span takes about 1.5 KB, so the sixth one passes the soft limit
+		endSpans(t, client, 6)
+		require.Equal(t, "soft", memoryStatus(t, client).State)
+
+		endSpans(t, client, 5)
+		require.NoError(t, client.LogInfo(ctx, "dropped", nil))
+		require.NoError(t, client.LogWarn(ctx, "kept", nil))
+		require.NoError(t, client.Flush(ctx))
+
+		assert.Len(t, collector.Spans(), 6)
+		records := collector.LogRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, "kept", records[0].Body.GetStringValue())
+		assert.Equal(t, "normal", memoryStatus(t, client).State)
+		assert.Equal(t, int64(6), memoryStatus(t, client).Dropped)
+	})
+
+	t.Run("should apply the heap limits", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly, func(b *telemetryflow.Builder) {
+			b.WithHeapLimit(1, 0)
+		})
+
+		memory := memoryStatus(t, client)
+		assert.Equal(t, "soft", memory.State)
+		assert.Positive(t, memory.HeapBytes)
+	})
+
+	t.Run("should count breaker buffers against the limit", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusServiceUnavailable})
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly, func(b *telemetryflow.Builder) {
+			b.WithMemoryLimit(0, 1<<20).
+				WithCircuitBreaker(1, time.Hour).
+				WithCircuitBreakerBuffer(100)
+		})
+
+		endSpans(t, client, 1)
+		_ = client.Flush(ctx)
+		endSpans(t, client, 5)
+		_ = client.Flush(ctx)
+
+		memory := memoryStatus(t, client)
+		assert.Greater(t, memory.BufferedBytes, int64(5*1024))
+		health, err := client.Health(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, health.Breakers)
+		assert.Equal(t, "traces", health.Breakers[0].Signal)
+		assert.Equal(t, 5, health.Breakers[0].Buffered)
+	})
+
+	t.Run("should report the limiter in self metrics", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { _ = provider.Shutdown(ctx) })
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, flushOnly, func(b *telemetryflow.Builder) {
+			b.WithMemoryLimit(8<<10, 1<<20).WithSelfMetrics().WithSelfMetricsMeterProvider(provider)
+		})
+
+		endSpans(t, client, 10)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		values := make(map[string]int64)
+		for _, scope := range rm.ScopeMetrics {
+			if scope.Scope.Name != infrastructure.SelfMetricsScopeName {
+				continue
+			}
+			for _, m := range scope.Metrics {
+				switch data := m.Data.(type) {
+				case metricdata.Sum[int64]:
+					if len(data.DataPoints) == 1 {
+						values[m.Name] = data.DataPoints[0].Value
+					}
+				case metricdata.Gauge[int64]:
+					values[m.Name] = data.DataPoints[0].Value
+				}
+			}
+		}
+		assert.Equal(t, memoryStatus(t, client).BufferedBytes, values["telemetryflow.sdk.memory.buffered"])
+		assert.Equal(t, int64(1), values["telemetryflow.sdk.memory.limit_state"])
+	})
+}
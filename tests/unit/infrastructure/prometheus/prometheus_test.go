@@ -0,0 +1,182 @@
+// Package infrastructure_test provides unit tests for the Prometheus scrape endpoint.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// scrape fetches the metrics page served by handler
+func scrape(t *testing.T, handler http.Handler) string {
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestClient_PrometheusHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve client metrics with Prometheus names and target_info", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithService("prom-service", "1.0.0").
+			WithExporters(domain.ExporterPrometheus).
+			Build()
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		require.NoError(t, client.IncrementCounter(ctx, "http.requests", 3, map[string]interface{}{"method": "GET"}))
+		require.NoError(t, client.RecordHistogram(ctx, "http.duration", 0.25, "s", nil))
+
+		page := scrape(t, client.PrometheusHandler())
+
+		assert.Contains(t, page, `http_requests_total{method="GET",otel_scope_name="prom-service"`)
+		assert.Contains(t, page, "http_duration_seconds_bucket")
+		assert.Contains(t, page, "target_info{")
+		assert.Contains(t, page, `service_name="prom-service"`)
+	})
+
+	t.Run("should work alongside the OTLP exporter", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint(collector.GRPCEndpoint()).
+			WithInsecure(true).
+			WithService("prom-service", "1.0.0").
+			WithPrometheusEndpoint("").
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ExporterType{domain.ExporterOTLP, domain.ExporterPrometheus}, client.Config().Exporters())
+		require.NoError(t, client.Initialize(ctx))
+
+		require.NoError(t, client.IncrementCounter(ctx, "jobs", 1, nil))
+		assert.Contains(t, scrape(t, client.PrometheusHandler()), "jobs_total")
+		require.NoError(t, client.Shutdown(ctx))
+
+		var names []string
+		for _, metric := range collector.Metrics() {
+			names = append(names, metric.GetName())
+		}
+		assert.Contains(t, names, "jobs")
+	})
+
+	t.Run("should be nil without the Prometheus exporter", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithService("prom-service", "1.0.0").
+			WithExporters(domain.ExporterConsole).
+			WithConsoleWriter(io.Discard).
+			Build()
+		require.NoError(t, err)
+		require.NoError(t, client.Initialize(ctx))
+		defer func() { _ = client.Shutdown(ctx) }()
+
+		assert.Nil(t, client.PrometheusHandler())
+	})
+}
+
+// shutdownProcessor is a span processor recording whether it was shut down
+type shutdownProcessor struct {
+	shutdown atomic.Bool
+}
+
+func (p *shutdownProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+func (p *shutdownProcessor) OnEnd(sdktrace.ReadOnlySpan)                     {}
+func (p *shutdownProcessor) ForceFlush(context.Context) error                { return nil }
+func (p *shutdownProcessor) Shutdown(context.Context) error {
+	p.shutdown.Store(true)
+	return nil
+}
+
+func TestClient_PrometheusEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should leave nothing running when the endpoint cannot listen", func(t *testing.T) {
+		busy, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		processor := &shutdownProcessor{}
+		client, err := telemetryflow.NewBuilder().
+			WithService("prom-service", "1.0.0").
+			WithExporters(domain.ExporterPrometheus).
+			WithPrometheusEndpoint(busy.Addr().String()).
+			WithSpanProcessor(processor).
+			Build()
+		require.NoError(t, err)
+		global := otel.GetTracerProvider()
+
+		require.ErrorContains(t, client.Initialize(ctx), "failed to start prometheus endpoint")
+		assert.True(t, processor.shutdown.Load(), "the tracer provider is shut down")
+		assert.Same(t, global, otel.GetTracerProvider(), "the global tracer provider is unchanged")
+
+		// The retry binds the address once it is free
+		require.NoError(t, busy.Close())
+		require.NoError(t, client.Initialize(ctx))
+		require.NoError(t, client.Shutdown(ctx))
+	})
+}
+
+func TestPrometheusReader_Listen(t *testing.T) {
+	t.Run("should serve /metrics until shutdown", func(t *testing.T) {
+		config, err := domain.NewTelemetryConfig(mustCredentials(t), "localhost:4317", "prom-service")
+		require.NoError(t, err)
+		reader, err := infrastructure.NewOTLPExporterFactory(config).CreatePrometheusReader()
+		require.NoError(t, err)
+
+		require.NoError(t, reader.Listen("127.0.0.1:0"))
+		url := "http://" + reader.Addr().String() + "/metrics"
+
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.NoError(t, reader.Shutdown(context.Background()))
+		_, err = http.Get(url)
+		assert.Error(t, err)
+	})
+}
+
+func mustCredentials(t *testing.T) *domain.Credentials {
+	creds, err := domain.NewCredentials("tfk_test", "tfs_secret")
+	require.NoError(t, err)
+	return creds
+}
@@ -0,0 +1,260 @@
+// Package infrastructure_test provides unit tests for export retries and circuit breaking.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// events collects trace export events from the callbacks
+type events struct {
+	mu      sync.Mutex
+	success []domain.ExportEvent
+	failure []domain.ExportEvent
+}
+
+func (e *events) record(event domain.ExportEvent) {
+	if event.Signal != domain.SignalTraces {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if event.Err == nil {
+		e.success = append(e.success, event)
+	} else {
+		e.failure = append(e.failure, event)
+	}
+}
+
+func (e *events) snapshot() ([]domain.ExportEvent, []domain.ExportEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]domain.ExportEvent(nil), e.success...), append([]domain.ExportEvent(nil), e.failure...)
+}
+
+// tracesTo exports traces only and records every export outcome in recorded
+func tracesTo(recorded *events) telemetryflowtest.Option {
+	return func(b *telemetryflow.Builder) {
+		b.WithSignals(false, false, true).
+			OnExportSuccess(recorded.record).
+			OnExportFailure(recorded.record)
+	}
+}
+
+// exportSpan ends one span and flushes it
+func exportSpan(t *testing.T, client *telemetryflow.Client) {
+	t.Helper()
+	ctx := context.Background()
+	spanID, err := client.StartSpan(ctx, "operation", "internal", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.EndSpan(ctx, spanID, nil))
+	_ = client.Flush(ctx)
+}
+
+// breaker returns the trace breaker of the otlp exporter from the health query
+func breaker(t *testing.T, client *telemetryflow.Client) application.BreakerHealth {
+	t.Helper()
+	health, err := client.Health(context.Background())
+	require.NoError(t, err)
+	require.Len(t, health.Breakers, 1)
+	return health.Breakers[0]
+}
+
+func TestRetry_Throttling(t *testing.T) {
+	for _, protocol := range []domain.Protocol{domain.ProtocolHTTP, domain.ProtocolGRPC} {
+		t.Run("should wait as long as the collector asks over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusTooManyRequests, RetryAfter: time.Second, Times: 1})
+			recorded := &events{}
+			client := collector.NewClient(t, protocol, tracesTo(recorded), func(b *telemetryflow.Builder) {
+				b.WithRetry(true, 3, 10*time.Millisecond)
+			})
+
+			exportSpan(t, client)
+
+			success, failure := recorded.snapshot()
+			require.Len(t, success, 1)
+			assert.Empty(t, failure)
+			assert.Equal(t, 1, success[0].Retries)
+			assert.GreaterOrEqual(t, success[0].Duration, time.Second)
+			assert.Len(t, collector.Spans(), 1)
+		})
+	}
+
+	t.Run("should retry temporary failures with backoff", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusServiceUnavailable, Times: 2})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolGRPC, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(true, 3, 10*time.Millisecond)
+		})
+
+		exportSpan(t, client)
+
+		success, _ := recorded.snapshot()
+		require.Len(t, success, 1)
+		assert.Equal(t, 2, success[0].Retries)
+		assert.Len(t, collector.Requests(domain.SignalTraces), 3)
+	})
+
+	t.Run("should not retry permanent failures", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadRequest})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolHTTP, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(true, 3, 10*time.Millisecond)
+		})
+
+		exportSpan(t, client)
+
+		_, failure := recorded.snapshot()
+		require.Len(t, failure, 1)
+		assert.Zero(t, failure[0].Retries)
+		assert.Len(t, collector.Requests(domain.SignalTraces), 1)
+	})
+
+	t.Run("should not retry ResourceExhausted without RetryInfo", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusTooManyRequests})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolGRPC, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(true, 3, 10*time.Millisecond)
+		})
+
+		exportSpan(t, client)
+
+		assert.Len(t, collector.Requests(domain.SignalTraces), 1)
+	})
+
+	t.Run("should stop at the max retries", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadGateway})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolHTTP, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(true, 2, time.Millisecond).WithRetryMaxBackoff(5 * time.Millisecond)
+		})
+
+		exportSpan(t, client)
+
+		_, failure := recorded.snapshot()
+		require.Len(t, failure, 1)
+		assert.Equal(t, 2, failure[0].Retries)
+		assert.Len(t, collector.Requests(domain.SignalTraces), 3)
+	})
+}
+
+func TestRetry_CircuitBreaker(t *testing.T) {
+	t.Run("should not report breakers unless configured", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, tracesTo(&events{}))
+
+		health, err := client.Health(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, health.Breakers)
+	})
+
+	t.Run("should open after consecutive failures and drop without requests", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusServiceUnavailable})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolHTTP, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(false, 0, 0).WithCircuitBreaker(2, time.Hour)
+		})
+
+		exportSpan(t, client)
+		assert.Equal(t, "closed", breaker(t, client).State)
+		exportSpan(t, client)
+
+		state := breaker(t, client)
+		assert.Equal(t, "open", state.State)
+		assert.Equal(t, 2, state.ConsecutiveFailures)
+		assert.False(t, state.OpenedAt.IsZero())
+		assert.True(t, state.RetryAt.After(time.Now()))
+
+		exportSpan(t, client)
+		assert.Len(t, collector.Requests(domain.SignalTraces), 2)
+		_, failure := recorded.snapshot()
+		require.Len(t, failure, 3)
+		assert.ErrorIs(t, failure[2].Err, domain.ErrCircuitOpen)
+		assert.Equal(t, int64(1), breaker(t, client).Dropped)
+
+		health, err := client.Health(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "unhealthy", health.Status)
+	})
+
+	t.Run("should close after a successful probe", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusServiceUnavailable, Times: 1})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolGRPC, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(false, 0, 0).WithCircuitBreaker(1, 50*time.Millisecond)
+		})
+
+		exportSpan(t, client)
+		require.Equal(t, "open", breaker(t, client).State)
+
+		time.Sleep(60 * time.Millisecond)
+		exportSpan(t, client)
+
+		state := breaker(t, client)
+		assert.Equal(t, "closed", state.State)
+		assert.Zero(t, state.ConsecutiveFailures)
+		assert.Len(t, collector.Spans(), 1)
+	})
+
+	t.Run("should send buffered spans once the breaker closes", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusServiceUnavailable, Times: 1})
+		recorded := &events{}
+		client := collector.NewClient(t, domain.ProtocolHTTP, tracesTo(recorded), func(b *telemetryflow.Builder) {
+			b.WithRetry(false, 0, 0).WithCircuitBreaker(1, 100*time.Millisecond).WithCircuitBreakerBuffer(2)
+		})
+
+		exportSpan(t, client)
+		for range 3 {
+			exportSpan(t, client)
+		}
+
+		state := breaker(t, client)
+		assert.Equal(t, "open", state.State)
+		assert.Equal(t, 2, state.Buffered)
+		assert.Equal(t, int64(1), state.Dropped)
+
+		time.Sleep(120 * time.Millisecond)
+		exportSpan(t, client)
+
+		state = breaker(t, client)
+		assert.Equal(t, "closed", state.State)
+		assert.Zero(t, state.Buffered)
+		assert.Len(t, collector.Spans(), 3)
+	})
+}
@@ -0,0 +1,159 @@
+// Package infrastructure_test provides unit tests for the Go runtime metrics.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// histogram returns the runtime histogram with the given name from a Produce result
+func histogram(t *testing.T, scopes []metricdata.ScopeMetrics, name string) metricdata.HistogramDataPoint[float64] {
+	for _, scope := range scopes {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				data, ok := m.Data.(metricdata.Histogram[float64])
+				require.True(t, ok)
+				require.Len(t, data.DataPoints, 1)
+				return data.DataPoints[0]
+			}
+		}
+	}
+	t.Fatalf("histogram %s not produced", name)
+	return metricdata.HistogramDataPoint[float64]{}
+}
+
+func TestRuntimeMetrics_Instruments(t *testing.T) {
+	t.Run("should report runtime metrics under semantic convention names", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) { b.WithRuntimeMetrics() })
+
+		goroutines, ok := kit.FindMetric("go.goroutine.count")
+		require.True(t, ok)
+		assert.Equal(t, "{goroutine}", goroutines.Unit)
+		assert.Greater(t, goroutines.Total(), 0.0)
+
+		processors, ok := kit.FindMetric("go.processor.limit")
+		require.True(t, ok)
+		assert.Equal(t, float64(runtime.GOMAXPROCS(0)), processors.Total())
+
+		used, ok := kit.FindMetric("go.memory.used")
+		require.True(t, ok)
+		stack, ok := used.Point(map[string]interface{}{"go.memory.type": "stack"})
+		require.True(t, ok)
+		assert.Greater(t, stack.Value, 0.0)
+		_, ok = used.Point(map[string]interface{}{"go.memory.type": "other"})
+		assert.True(t, ok)
+
+		for _, name := range []string{"go.memory.allocated", "go.memory.allocations", "go.memory.gc.goal", "go.config.gogc"} {
+			_, ok := kit.FindMetric(name)
+			assert.True(t, ok, name)
+		}
+	})
+
+	t.Run("should be off by default", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+
+		_, ok := kit.FindMetric("go.goroutine.count")
+		assert.False(t, ok)
+	})
+}
+
+func TestRuntimeMetrics_Produce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should fold scheduler latency and GC pauses into histograms", func(t *testing.T) {
+		runtime.GC()
+		scopes, err := infrastructure.NewRuntimeMetrics(time.Nanosecond).Produce(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, scopes, 1)
+		assert.Equal(t, infrastructure.RuntimeScopeName, scopes[0].Scope.Name)
+
+		pauses := histogram(t, scopes, "go.gc.pause.duration")
+		assert.Greater(t, pauses.Count, uint64(0))
+		assert.Len(t, pauses.BucketCounts, len(pauses.Bounds)+1)
+		var total uint64
+		for _, count := range pauses.BucketCounts {
+			total += count
+		}
+		assert.Equal(t, pauses.Count, total)
+
+		histogram(t, scopes, "go.schedule.duration")
+	})
+
+	t.Run("should reuse the sample within the interval", func(t *testing.T) {
+		runtimeMetrics := infrastructure.NewRuntimeMetrics(time.Hour)
+		first, err := runtimeMetrics.Produce(ctx)
+		require.NoError(t, err)
+
+		runtime.GC()
+		second, err := runtimeMetrics.Produce(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			histogram(t, first, "go.gc.pause.duration").Count,
+			histogram(t, second, "go.gc.pause.duration").Count)
+	})
+}
+
+func TestRuntimeMetrics_Export(t *testing.T) {
+	t.Run("should export instruments and histograms over OTLP", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint(collector.GRPCEndpoint()).
+			WithInsecure(true).
+			WithService("runtime-service", "1.0.0").
+			WithRuntimeMetrics().
+			WithRuntimeMetricsInterval(time.Second).
+			Build()
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		require.NoError(t, client.Initialize(ctx))
+		require.NoError(t, client.Shutdown(ctx))
+
+		var names []string
+		for _, metric := range collector.Metrics() {
+			names = append(names, metric.GetName())
+		}
+		assert.Contains(t, names, "go.goroutine.count")
+		assert.Contains(t, names, "go.schedule.duration")
+		assert.Contains(t, names, "go.gc.pause.duration")
+	})
+
+	t.Run("should reject a non-positive interval", func(t *testing.T) {
+		creds, _ := domain.NewCredentials("tfk_test", "tfs_secret")
+		config, _ := domain.NewTelemetryConfig(creds, "localhost:4317", "runtime-service")
+		config.WithRuntimeMetrics(true).WithRuntimeMetricsInterval(-time.Second)
+
+		assert.Error(t, config.Validate())
+	})
+}
@@ -0,0 +1,229 @@
+// Package infrastructure_test provides unit tests for the SDK self metrics.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// selfMetrics collects the self metrics recorded into a separate meter provider
+type selfMetrics struct {
+	reader *sdkmetric.ManualReader
+}
+
+func newSelfMetrics(t *testing.T) (*selfMetrics, *sdkmetric.MeterProvider) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return &selfMetrics{reader: reader}, provider
+}
+
+func (s *selfMetrics) collect(t *testing.T) map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, s.reader.Collect(context.Background(), &rm))
+
+	result := make(map[string]metricdata.Metrics)
+	for _, scope := range rm.ScopeMetrics {
+		if scope.Scope.Name != infrastructure.SelfMetricsScopeName {
+			continue
+		}
+		for _, m := range scope.Metrics {
+			result[m.Name] = m
+		}
+	}
+	return result
+}
+
+// sum returns the value of an int64 sum for a signal and exporter (0 if never recorded)
+func sum(metrics map[string]metricdata.Metrics, name string, signal domain.SignalType, exporter string) int64 {
+	data, ok := metrics[name].Data.(metricdata.Sum[int64])
+	if !ok {
+		return 0
+	}
+	var total int64
+	for _, point := range data.DataPoints {
+		if matches(point.Attributes, signal, exporter) {
+			total += point.Value
+		}
+	}
+	return total
+}
+
+// histogramCount returns the number of recordings of a histogram for a signal and exporter
+func histogramCount(metrics map[string]metricdata.Metrics, name string, signal domain.SignalType, exporter string) uint64 {
+	var total uint64
+	switch data := metrics[name].Data.(type) {
+	case metricdata.Histogram[int64]:
+		for _, point := range data.DataPoints {
+			if matches(point.Attributes, signal, exporter) {
+				total += point.Count
+			}
+		}
+	case metricdata.Histogram[float64]:
+		for _, point := range data.DataPoints {
+			if matches(point.Attributes, signal, exporter) {
+				total += point.Count
+			}
+		}
+	}
+	return total
+}
+
+func matches(set attribute.Set, signal domain.SignalType, exporter string) bool {
+	s, _ := set.Value("signal")
+	e, _ := set.Value("exporter")
+	return s.AsString() == string(signal) && e.AsString() == exporter
+}
+
+// selfMetricsTo sends self metrics to provider and retries failed exports
+func selfMetricsTo(provider *sdkmetric.MeterProvider) telemetryflowtest.Option {
+	return func(b *telemetryflow.Builder) {
+		b.WithRetry(true, 3, 10*time.Millisecond).WithSelfMetricsMeterProvider(provider)
+	}
+}
+
+func TestSelfMetrics_Exports(t *testing.T) {
+	ctx := context.Background()
+
+	for _, protocol := range []domain.Protocol{domain.ProtocolGRPC, domain.ProtocolHTTP} {
+		t.Run("should count exported items and retries over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusServiceUnavailable, Signal: domain.SignalTraces, Times: 1})
+			self, provider := newSelfMetrics(t)
+			client := collector.NewClient(t, protocol, selfMetricsTo(provider))
+
+			for range 3 {
+				spanID, err := client.StartSpan(ctx, "operation", "internal", nil)
+				require.NoError(t, err)
+				require.NoError(t, client.EndSpan(ctx, spanID, nil))
+			}
+			require.NoError(t, client.LogInfo(ctx, "hello", nil))
+			require.NoError(t, client.IncrementCounter(ctx, "requests", 1, nil))
+			require.NoError(t, client.Shutdown(ctx))
+
+			metrics := self.collect(t)
+			assert.Equal(t, int64(3), sum(metrics, "telemetryflow.sdk.items.queued", domain.SignalTraces, "otlp"))
+			assert.Equal(t, int64(3), sum(metrics, "telemetryflow.sdk.items.exported", domain.SignalTraces, "otlp"))
+			assert.Equal(t, int64(0), sum(metrics, "telemetryflow.sdk.items.dropped", domain.SignalTraces, "otlp"))
+			assert.Equal(t, int64(1), sum(metrics, "telemetryflow.sdk.exporter.retries", domain.SignalTraces, "otlp"))
+			assert.Equal(t, uint64(1), histogramCount(metrics, "telemetryflow.sdk.exporter.batch.size", domain.SignalTraces, "otlp"))
+			assert.Equal(t, uint64(1), histogramCount(metrics, "telemetryflow.sdk.exporter.duration", domain.SignalTraces, "otlp"))
+
+			assert.Equal(t, int64(1), sum(metrics, "telemetryflow.sdk.items.queued", domain.SignalLogs, "otlp"))
+			assert.Equal(t, int64(1), sum(metrics, "telemetryflow.sdk.items.exported", domain.SignalLogs, "otlp"))
+			assert.Positive(t, sum(metrics, "telemetryflow.sdk.items.exported", domain.SignalMetrics, "otlp"))
+		})
+	}
+
+	t.Run("should count items of a failed export", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusBadRequest, Signal: domain.SignalLogs})
+		self, provider := newSelfMetrics(t)
+		client := collector.NewClient(t, domain.ProtocolHTTP, selfMetricsTo(provider))
+
+		require.NoError(t, client.LogInfo(ctx, "first", nil))
+		require.NoError(t, client.LogInfo(ctx, "second", nil))
+		_ = client.Shutdown(ctx)
+
+		metrics := self.collect(t)
+		assert.Equal(t, int64(2), sum(metrics, "telemetryflow.sdk.items.failed", domain.SignalLogs, "otlp"))
+		assert.Equal(t, int64(0), sum(metrics, "telemetryflow.sdk.items.exported", domain.SignalLogs, "otlp"))
+	})
+
+	t.Run("should count spans dropped while the queue is full", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Delay: 500 * time.Millisecond, Signal: domain.SignalTraces, Times: 1})
+		self, provider := newSelfMetrics(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, selfMetricsTo(provider))
+
+		// The first batch blocks in the delayed export, so the queue fills up behind it
+		const spans = 3000
+		for range spans {
+			spanID, err := client.StartSpan(ctx, "burst", "internal", nil)
+			require.NoError(t, err)
+			require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		}
+		require.NoError(t, client.Shutdown(ctx))
+
+		metrics := self.collect(t)
+		queued := sum(metrics, "telemetryflow.sdk.items.queued", domain.SignalTraces, "otlp")
+		dropped := sum(metrics, "telemetryflow.sdk.items.dropped", domain.SignalTraces, "otlp")
+		assert.Equal(t, int64(spans), queued+dropped)
+		assert.Positive(t, dropped)
+		assert.Equal(t, queued, sum(metrics, "telemetryflow.sdk.items.exported", domain.SignalTraces, "otlp"))
+		assert.Len(t, collector.Spans(), int(queued))
+	})
+}
+
+func TestSelfMetrics_ActiveSpans(t *testing.T) {
+	t.Run("should report spans started and not yet ended", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		self, provider := newSelfMetrics(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, selfMetricsTo(provider))
+
+		ctx := context.Background()
+		first, err := client.StartSpan(ctx, "first", "internal", nil)
+		require.NoError(t, err)
+		_, err = client.StartSpan(ctx, "second", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, first, nil))
+
+		data, ok := self.collect(t)["telemetryflow.sdk.spans.active"].Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, data.DataPoints, 1)
+		assert.Equal(t, int64(1), data.DataPoints[0].Value)
+	})
+}
+
+func TestSelfMetrics_Disabled(t *testing.T) {
+	t.Run("should record nothing unless enabled", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+
+		_, err := kit.Client().StartSpan(context.Background(), "operation", "internal", nil)
+		require.NoError(t, err)
+
+		_, ok := kit.FindMetric("telemetryflow.sdk.spans.active")
+		assert.False(t, ok)
+	})
+
+	t.Run("should use the client's meter provider by default", func(t *testing.T) {
+		kit := telemetryflowtest.New(t, func(b *telemetryflow.Builder) { b.WithSelfMetrics() })
+
+		_, err := kit.Client().StartSpan(context.Background(), "operation", "internal", nil)
+		require.NoError(t, err)
+
+		active, ok := kit.FindMetric("telemetryflow.sdk.spans.active")
+		require.True(t, ok)
+		assert.Equal(t, 1.0, active.Total())
+	})
+}
@@ -0,0 +1,282 @@
+// Package infrastructure_test provides unit tests for the span commands.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/application"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+const (
+	linkedTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	linkedSpanID  = "00f067aa0ba902b7"
+)
+
+func TestSpanCommands(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should set attributes after start", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		spanID, err := client.StartSpan(ctx, "process-order", "internal", map[string]interface{}{"order.id": "o-1"})
+		require.NoError(t, err)
+
+		require.NoError(t, client.SetSpanAttributes(ctx, spanID, map[string]interface{}{
+			"order.id":    "o-2",
+			"order.items": 3,
+		}))
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+
+		kit.AssertSpan("process-order", map[string]interface{}{"order.id": "o-2", "order.items": 3})
+	})
+
+	t.Run("should set the span status", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		failed, err := client.StartSpan(ctx, "failed", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.SetSpanStatus(ctx, failed, "error", "payment declined"))
+		require.NoError(t, client.EndSpan(ctx, failed, nil))
+
+		succeeded, err := client.StartSpan(ctx, "succeeded", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.SetSpanStatus(ctx, succeeded, "OK", "ignored"))
+		require.NoError(t, client.EndSpan(ctx, succeeded, nil))
+
+		span, _ := kit.FindSpan("failed")
+		assert.Equal(t, "Error", span.StatusCode)
+		assert.Equal(t, "payment declined", span.StatusMessage)
+		span, _ = kit.FindSpan("succeeded")
+		assert.Equal(t, "Ok", span.StatusCode)
+		assert.Empty(t, span.StatusMessage)
+	})
+
+	t.Run("should reject an unknown status", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		spanID, err := kit.Client().StartSpan(ctx, "span", "internal", nil)
+		require.NoError(t, err)
+
+		err = kit.Client().SetSpanStatus(ctx, spanID, "failed", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported span status")
+	})
+
+	t.Run("should rename the span", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		spanID, err := client.StartSpan(ctx, "HTTP GET", "server", nil)
+		require.NoError(t, err)
+
+		require.Error(t, client.SetSpanName(ctx, spanID, ""))
+		require.NoError(t, client.SetSpanName(ctx, spanID, "GET /orders/{id}"))
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+
+		_, found := kit.FindSpan("GET /orders/{id}")
+		assert.True(t, found)
+	})
+
+	t.Run("should link the span to other traces", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		spanID, err := client.StartSpan(ctx, "consume-batch", "consumer", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, client.AddSpanLink(ctx, spanID, linkedTraceID, linkedSpanID, map[string]interface{}{
+			"messaging.message.id": "m-1",
+		}))
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+
+		span, _ := kit.FindSpan("consume-batch")
+		assert.Equal(t, []telemetryflowtest.SpanLink{{
+			TraceID:    linkedTraceID,
+			SpanID:     linkedSpanID,
+			Attributes: map[string]interface{}{"messaging.message.id": "m-1"},
+		}}, span.Links)
+	})
+
+	t.Run("should reject invalid link IDs", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		spanID, err := kit.Client().StartSpan(ctx, "span", "internal", nil)
+		require.NoError(t, err)
+
+		assert.Error(t, kit.Client().AddSpanLink(ctx, spanID, "not-hex", linkedSpanID, nil))
+		assert.Error(t, kit.Client().AddSpanLink(ctx, spanID, linkedTraceID, "", nil))
+	})
+
+	t.Run("should fail for unknown or ended spans", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		spanID, err := client.StartSpan(ctx, "span", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+
+		errs := []error{
+			client.SetSpanAttributes(ctx, spanID, nil),
+			client.SetSpanStatus(ctx, spanID, "ok", ""),
+			client.SetSpanName(ctx, spanID, "renamed"),
+			client.AddSpanLink(ctx, spanID, linkedTraceID, linkedSpanID, nil),
+			client.SetSpanBaggage(ctx, spanID, "tenant", "acme"),
+		}
+		for _, err := range errs {
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "span not found")
+		}
+		_, err = client.SpanBaggage(ctx, spanID)
+		assert.Error(t, err)
+	})
+}
+
+func TestSpanCommandHandlers(t *testing.T) {
+	ctx := context.Background()
+	collector := telemetryflowtest.NewCollector(t)
+
+	creds, err := domain.NewCredentials("tfk_test", "tfs_secret")
+	require.NoError(t, err)
+	config, err := domain.NewTelemetryConfig(creds, collector.GRPCEndpoint(), "span-service")
+	require.NoError(t, err)
+	config.WithProtocol(domain.ProtocolGRPC).WithInsecure(true).WithSignals(false, false, true).WithRetry(false, 0, 0)
+
+	handler := infrastructure.NewTelemetryCommandHandler(config)
+	require.NoError(t, handler.Handle(ctx, &application.InitializeSDKCommand{Config: config}))
+	t.Cleanup(func() { _ = handler.Handle(ctx, &application.ShutdownSDKCommand{Timeout: time.Second}) })
+
+	spanID, err := handler.StartSpanDirect(ctx, "consume", "consumer", nil)
+	require.NoError(t, err)
+	commands := []application.Command{
+		&application.SetSpanAttributesCommand{SpanID: spanID, Attributes: map[string]interface{}{"batch.size": 2}},
+		&application.SetSpanStatusCommand{SpanID: spanID, Code: "error", Description: "partial failure"},
+		&application.SetSpanNameCommand{SpanID: spanID, Name: "consume orders"},
+		&application.AddSpanLinkCommand{SpanID: spanID, LinkedTraceID: linkedTraceID, LinkedSpanID: linkedSpanID},
+		&application.SetSpanBaggageCommand{SpanID: spanID, Key: "tenant", Value: "acme"},
+	}
+	for _, cmd := range commands {
+		require.NoError(t, handler.Handle(ctx, cmd), "%T", cmd)
+	}
+
+	result, err := handler.Query(ctx, &application.GetSpanBaggageQuery{SpanID: spanID})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tenant": "acme"}, result.(*application.SpanBaggageResult).Baggage)
+
+	require.NoError(t, handler.Handle(ctx, &application.EndSpanCommand{SpanID: spanID}))
+	require.NoError(t, handler.Handle(ctx, &application.FlushTelemetryCommand{Timeout: time.Second}))
+	require.Eventually(t, func() bool { return len(collector.Spans()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	span := collector.Spans()[0]
+	assert.Equal(t, "consume orders", span.Name)
+	assert.Equal(t, "partial failure", span.Status.Message)
+	require.Len(t, span.Links, 1)
+	assert.Equal(t, linkedTraceID, hex.EncodeToString(span.Links[0].TraceId))
+	assert.Equal(t, linkedSpanID, hex.EncodeToString(span.Links[0].SpanId))
+}
+
+func TestSpanBaggage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should start with the baggage of the context", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		member, err := baggage.NewMember("tenant", "acme")
+		require.NoError(t, err)
+		bag, err := baggage.New(member)
+		require.NoError(t, err)
+
+		spanID, err := kit.Client().StartSpan(baggage.ContextWithBaggage(ctx, bag), "span", "internal", nil)
+		require.NoError(t, err)
+
+		got, err := kit.Client().SpanBaggage(ctx, spanID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"tenant": "acme"}, got)
+	})
+
+	t.Run("should set and replace members", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		spanID, err := client.StartSpan(ctx, "span", "internal", nil)
+		require.NoError(t, err)
+
+		got, err := client.SpanBaggage(ctx, spanID)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		require.NoError(t, client.SetSpanBaggage(ctx, spanID, "tenant", "acme"))
+		require.NoError(t, client.SetSpanBaggage(ctx, spanID, "region", "eu west"))
+		require.NoError(t, client.SetSpanBaggage(ctx, spanID, "tenant", "globex"))
+
+		got, err = client.SpanBaggage(ctx, spanID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"tenant": "globex", "region": "eu west"}, got)
+	})
+
+	t.Run("should reject an empty key", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		spanID, err := kit.Client().StartSpan(ctx, "span", "internal", nil)
+		require.NoError(t, err)
+
+		assert.Error(t, kit.Client().SetSpanBaggage(ctx, spanID, "", "value"))
+	})
+
+	t.Run("should propagate the span and its baggage from the returned context", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+		spanID, err := client.StartSpan(ctx, "parent", "server", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.SetSpanBaggage(ctx, spanID, "tenant", "acme"))
+
+		spanCtx, err := client.ContextWithSpan(ctx, spanID)
+		require.NoError(t, err)
+
+		assert.Equal(t, spanID, trace.SpanContextFromContext(spanCtx).SpanID().String())
+		assert.Equal(t, "acme", baggage.FromContext(spanCtx).Member("tenant").Value())
+
+		headers := propagation.MapCarrier{}
+		propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}).Inject(spanCtx, headers)
+		assert.Contains(t, headers.Get("traceparent"), spanID)
+		assert.Equal(t, "tenant=acme", headers.Get("baggage"))
+
+		childID, err := client.StartSpan(spanCtx, "child", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, childID, nil))
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		parent, _ := kit.FindSpan("parent")
+		child, _ := kit.FindSpan("child")
+		assert.Equal(t, parent.SpanID, child.ParentSpanID)
+	})
+
+	t.Run("should fail for unknown spans", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+
+		_, err := kit.Client().ContextWithSpan(ctx, "0000000000000001")
+
+		assert.Error(t, err)
+	})
+}
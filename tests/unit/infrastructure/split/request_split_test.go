@@ -0,0 +1,280 @@
+// Package infrastructure_test provides unit tests for size-aware export request splitting.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+const requestLimit = 16 << 10
+
+// events collects successful export events of one signal
+type events struct {
+	mu      sync.Mutex
+	signal  domain.SignalType
+	success []domain.ExportEvent
+}
+
+func (e *events) record(event domain.ExportEvent) {
+	if event.Signal != e.signal {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.success = append(e.success, event)
+}
+
+// splitting exports only the signal of recorded with the request size limit, records
+// successful exports and sets gzip compression
+func splitting(recorded *events, compression bool) telemetryflowtest.Option {
+	return func(b *telemetryflow.Builder) {
+		b.WithSignals(recorded.signal == domain.SignalMetrics, recorded.signal == domain.SignalLogs, recorded.signal == domain.SignalTraces).
+			WithCompression(compression).
+			WithMaxRequestSize(requestLimit).
+			OnExportSuccess(recorded.record)
+	}
+}
+
+// randomString returns n incompressible characters
+func randomString(t *testing.T, n int) string {
+	t.Helper()
+	buf := make([]byte, n/2)
+	_, err := rand.Read(buf)
+	require.NoError(t, err)
+	return hex.EncodeToString(buf)
+}
+
+// endSpan ends one span with the given attributes
+func endSpan(t *testing.T, client *telemetryflow.Client, attrs map[string]interface{}) {
+	t.Helper()
+	ctx := context.Background()
+	spanID, err := client.StartSpan(ctx, "operation", "internal", attrs)
+	require.NoError(t, err)
+	require.NoError(t, client.EndSpan(ctx, spanID, nil))
+}
+
+func TestRequestSplit_Traces(t *testing.T) {
+	tests := []struct {
+		protocol    domain.Protocol
+		compression bool
+	}{
+		{domain.ProtocolHTTP, false},
+		{domain.ProtocolHTTP, true},
+		{domain.ProtocolGRPC, false},
+		{domain.ProtocolGRPC, true},
+	}
+	for _, tt := range tests {
+		name := fmt.Sprintf("should split large requests over %s (gzip: %t)", tt.protocol, tt.compression)
+		t.Run(name, func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			recorded := &events{signal: domain.SignalTraces}
+			client := collector.NewClient(t, tt.protocol, splitting(recorded, tt.compression))
+
+			for range 12 {
+				endSpan(t, client, map[string]interface{}{"payload": randomString(t, 4<<10)})
+			}
+			require.NoError(t, client.Flush(context.Background()))
+
+			requests := collector.Requests(domain.SignalTraces)
+			assert.Greater(t, len(requests), 1)
+			for _, req := range requests {
+				assert.LessOrEqual(t, proto.Size(req.Traces), requestLimit)
+			}
+			assert.Len(t, collector.Spans(), 12)
+
+			require.Len(t, recorded.success, 1)
+			assert.Equal(t, 12, recorded.success[0].Items)
+			assert.Zero(t, recorded.success[0].Retries)
+			assert.Zero(t, recorded.success[0].Truncated)
+		})
+	}
+
+	t.Run("should not split requests within the limit", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		recorded := &events{signal: domain.SignalTraces}
+		client := collector.NewClient(t, domain.ProtocolHTTP, splitting(recorded, false))
+
+		for range 3 {
+			endSpan(t, client, nil)
+		}
+		require.NoError(t, client.Flush(context.Background()))
+
+		assert.Len(t, collector.Requests(domain.SignalTraces), 1)
+		assert.Len(t, collector.Spans(), 3)
+	})
+
+	for _, protocol := range []domain.Protocol{domain.ProtocolHTTP, domain.ProtocolGRPC} {
+		t.Run("should truncate a span over the limit over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			recorded := &events{signal: domain.SignalTraces}
+			client := collector.NewClient(t, protocol, splitting(recorded, false))
+
+			endSpan(t, client, map[string]interface{}{"payload": strings.Repeat("é", 32<<10), "small": "kept"})
+			require.NoError(t, client.Flush(context.Background()))
+
+			spans := collector.Spans()
+			require.Len(t, spans, 1)
+			for _, attr := range spans[0].Attributes {
+				switch attr.Key {
+				case "payload":
+					value := attr.Value.GetStringValue()
+					assert.Less(t, len(value), requestLimit)
+					assert.True(t, strings.HasPrefix(strings.Repeat("é", 32<<10), value))
+				case "small":
+					assert.Equal(t, "kept", attr.Value.GetStringValue())
+				}
+			}
+			require.Len(t, recorded.success, 1)
+			assert.Equal(t, 1, recorded.success[0].Truncated)
+			assert.Zero(t, recorded.success[0].Dropped)
+		})
+	}
+
+	t.Run("should drop a span that does not fit when truncated", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		recorded := &events{signal: domain.SignalTraces}
+		client := collector.NewClient(t, domain.ProtocolGRPC, splitting(recorded, false))
+
+		// keys are never truncated, and spans keep at most 128 attributes
+		attrs := make(map[string]interface{}, 128)
+		for i := range 128 {
+			attrs[fmt.Sprintf("attribute.%03d.%s", i, strings.Repeat("k", 200))] = strings.Repeat("x", 100)
+		}
+		endSpan(t, client, attrs)
+		endSpan(t, client, nil)
+		require.NoError(t, client.Flush(context.Background()))
+
+		assert.Len(t, collector.Spans(), 1)
+		require.Len(t, recorded.success, 1)
+		assert.Equal(t, 2, recorded.success[0].Items)
+		assert.Equal(t, 1, recorded.success[0].Dropped)
+
+		status, err := client.Status(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), status.Statistics.TracesSent)
+	})
+}
+
+func TestRequestSplit_Retry(t *testing.T) {
+	for _, protocol := range []domain.Protocol{domain.ProtocolHTTP, domain.ProtocolGRPC} {
+		t.Run("should not resend the delivered parts over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			endpoint := collector.GRPCEndpoint()
+			if protocol == domain.ProtocolHTTP {
+				endpoint = collector.HTTPEndpoint()
+			}
+			recorded := &events{signal: domain.SignalTraces}
+			client, err := telemetryflow.NewBuilder().
+				WithAPIKey("tfk_test", "tfs_secret").
+				WithEndpoint(endpoint).
+				WithProtocol(protocol).
+				WithInsecure(true).
+				WithService("split-service", "1.0.0").
+				WithSignals(false, false, true).
+				WithRetry(true, 3, time.Millisecond).
+				WithMaxRequestSize(requestLimit).
+				OnExportSuccess(recorded.record).
+				Build()
+			require.NoError(t, err)
+			require.NoError(t, client.Initialize(context.Background()))
+			t.Cleanup(func() { _ = client.Shutdown(context.Background()) })
+
+			// The second part fails once, after the first was accepted
+			collector.InjectFault(telemetryflowtest.Fault{Status: 503, Signal: domain.SignalTraces, After: 1, Times: 1})
+			for range 12 {
+				endSpan(t, client, map[string]interface{}{"payload": randomString(t, 4<<10)})
+			}
+			require.NoError(t, client.Flush(context.Background()))
+
+			spans := collector.Spans()
+			assert.Len(t, spans, 12)
+			seen := make(map[string]bool, len(spans))
+			for _, span := range spans {
+				id := hex.EncodeToString(span.SpanId)
+				assert.False(t, seen[id], "span %s sent twice", id)
+				seen[id] = true
+			}
+			require.Len(t, recorded.success, 1)
+			assert.Equal(t, 1, recorded.success[0].Retries)
+		})
+	}
+}
+
+func TestRequestSplit_MetricsAndLogs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should split metric requests by data point", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		recorded := &events{signal: domain.SignalMetrics}
+		client := collector.NewClient(t, domain.ProtocolHTTP, splitting(recorded, false))
+
+		for i := range 200 {
+			require.NoError(t, client.IncrementCounter(ctx, "split.requests", 1, map[string]interface{}{
+				"route": fmt.Sprintf("/route/%03d/%s", i, strings.Repeat("r", 200)),
+			}))
+		}
+		require.NoError(t, client.Flush(ctx))
+
+		requests := collector.Requests(domain.SignalMetrics)
+		assert.Greater(t, len(requests), 1)
+		points := 0
+		for _, req := range requests {
+			assert.LessOrEqual(t, proto.Size(req.Metrics), requestLimit)
+			for _, rm := range req.Metrics.ResourceMetrics {
+				for _, sm := range rm.ScopeMetrics {
+					for _, m := range sm.Metrics {
+						assert.Equal(t, "split.requests", m.Name)
+						assert.True(t, m.GetSum().GetIsMonotonic())
+						points += len(m.GetSum().GetDataPoints())
+					}
+				}
+			}
+		}
+		assert.Equal(t, 200, points)
+	})
+
+	t.Run("should split log requests", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		recorded := &events{signal: domain.SignalLogs}
+		client := collector.NewClient(t, domain.ProtocolGRPC, splitting(recorded, false))
+
+		for range 10 {
+			require.NoError(t, client.LogInfo(ctx, randomString(t, 4<<10), nil))
+		}
+		require.NoError(t, client.Flush(ctx))
+
+		assert.Greater(t, len(collector.Requests(domain.SignalLogs)), 1)
+		assert.Len(t, collector.LogRecords(), 10)
+	})
+}
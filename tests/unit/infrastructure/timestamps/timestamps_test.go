@@ -0,0 +1,147 @@
+// Package infrastructure_test provides unit tests for metrics and logs with explicit timestamps.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// metricsAndLogs exports metrics and logs only
+func metricsAndLogs(b *telemetryflow.Builder) {
+	b.WithSignals(true, true, false)
+}
+
+// gaugePoints returns the exported gauge data points of the named metric
+func gaugePoints(collector *telemetryflowtest.Collector, name string) []*metricspb.NumberDataPoint {
+	var points []*metricspb.NumberDataPoint
+	for _, metric := range collector.Metrics() {
+		if metric.Name == name {
+			points = append(points, metric.GetGauge().GetDataPoints()...)
+		}
+	}
+	return points
+}
+
+func TestExplicitTimestamps(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should export timestamped metric values once with their time", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+		first := time.Now().Add(-2 * time.Hour).Truncate(time.Millisecond)
+		second := first.Add(time.Minute)
+
+		require.NoError(t, client.RecordMetricAt(ctx, "sensor.temperature", 21.5, "Cel", map[string]interface{}{"sensor": "a"}, first))
+		require.NoError(t, client.RecordMetricAt(ctx, "sensor.temperature", 22.0, "Cel", map[string]interface{}{"sensor": "a"}, second))
+		require.NoError(t, client.Flush(ctx))
+
+		points := gaugePoints(collector, "sensor.temperature")
+		require.Len(t, points, 2)
+		assert.Equal(t, uint64(first.UnixNano()), points[0].TimeUnixNano)
+		assert.Equal(t, 21.5, points[0].GetAsDouble())
+		assert.Equal(t, uint64(second.UnixNano()), points[1].TimeUnixNano)
+		assert.Equal(t, 22.0, points[1].GetAsDouble())
+		assert.Equal(t, "sensor", points[0].Attributes[0].Key)
+
+		collector.Reset()
+		require.NoError(t, client.Flush(ctx))
+		assert.Empty(t, gaugePoints(collector, "sensor.temperature"))
+	})
+
+	t.Run("should keep the timestamp of log records", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+		timestamp := time.Now().Add(-3 * time.Hour).Truncate(time.Millisecond)
+
+		before := time.Now()
+		require.NoError(t, client.LogAt(ctx, "warn", "door opened", nil, timestamp))
+		require.NoError(t, client.Flush(ctx))
+
+		records := collector.LogRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, uint64(timestamp.UnixNano()), records[0].TimeUnixNano)
+		assert.GreaterOrEqual(t, records[0].ObservedTimeUnixNano, uint64(before.UnixNano()))
+	})
+
+	t.Run("should reject timestamps outside the skew limits", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs, func(b *telemetryflow.Builder) {
+			b.WithTimestampSkew(time.Hour, time.Minute)
+		})
+
+		err := client.RecordMetricAt(ctx, "sensor.temperature", 1, "", nil, time.Now().Add(-2*time.Hour))
+		assert.ErrorIs(t, err, domain.ErrTimestampSkew)
+		assert.ErrorContains(t, err, "in the past")
+
+		err = client.LogAt(ctx, "info", "from the future", nil, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrTimestampSkew)
+		assert.ErrorContains(t, err, "in the future")
+
+		require.NoError(t, client.Flush(ctx))
+		assert.Empty(t, gaugePoints(collector, "sensor.temperature"))
+		assert.Empty(t, collector.LogRecords())
+	})
+
+	t.Run("should accept any timestamp without limits", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs, func(b *telemetryflow.Builder) {
+			b.WithTimestampSkew(0, 0)
+		})
+		old := time.Now().AddDate(0, 0, -30)
+
+		require.NoError(t, client.RecordMetricAt(ctx, "sensor.temperature", 1, "", nil, old))
+		require.NoError(t, client.LogAt(ctx, "info", "a month ago", nil, old))
+		require.NoError(t, client.Flush(ctx))
+
+		assert.Len(t, gaugePoints(collector, "sensor.temperature"), 1)
+		assert.Len(t, collector.LogRecords(), 1)
+	})
+
+	t.Run("should stamp data recorded without a timestamp at collection", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collector.NewClient(t, domain.ProtocolGRPC, metricsAndLogs)
+
+		before := time.Now()
+		require.NoError(t, client.RecordMetric(ctx, "queue.depth", 7, "", nil))
+		require.NoError(t, client.Flush(ctx))
+
+		points := gaugePoints(collector, "queue.depth")
+		require.NotEmpty(t, points)
+		assert.GreaterOrEqual(t, points[0].TimeUnixNano, uint64(before.UnixNano()))
+	})
+
+	t.Run("should reject timestamped values without a periodic exporter", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+
+		err := kit.Client().RecordMetricAt(ctx, "sensor.temperature", 1, "", nil, time.Now().Add(-time.Minute))
+
+		assert.ErrorContains(t, err, "only exported by the OTLP, console and file exporters")
+		require.NoError(t, kit.Client().RecordMetric(ctx, "sensor.temperature", 1, "", nil))
+	})
+}
@@ -19,11 +19,18 @@
 package client_test
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
@@ -693,6 +700,368 @@ func TestBuilder_TFOv2FullChaining(t *testing.T) {
 	})
 }
 
+func TestBuilder_WithFailoverEndpoints(t *testing.T) {
+	t.Run("should set failover endpoints and probe interval", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithFailoverEndpoints("tfo-agent:4317", "collector.telemetryflow.id:4317").
+			WithFailoverProbeInterval(10 * time.Second).
+			Build()
+
+		require.NoError(t, err)
+		config := client.Config()
+		assert.Equal(t, []string{"localhost:4317", "tfo-agent:4317", "collector.telemetryflow.id:4317"}, config.Endpoints())
+		assert.Equal(t, 10*time.Second, config.FailoverProbeInterval())
+	})
+
+	t.Run("should read failover endpoints from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_FAILOVER_ENDPOINTS", "tfo-agent:4317, collector.telemetryflow.id:4317,")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithFailoverEndpointsFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tfo-agent:4317", "collector.telemetryflow.id:4317"}, client.Config().FailoverEndpoints())
+	})
+}
+
+func TestBuilder_WithDestination(t *testing.T) {
+	t.Run("should add destinations to config", func(t *testing.T) {
+		dest, err := domain.NewDestination("otel", "otel-collector:4317")
+		require.NoError(t, err)
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithDestination(dest).
+			Build()
+
+		require.NoError(t, err)
+		require.Len(t, client.Config().Destinations(), 1)
+		assert.Equal(t, "otel", client.Config().Destinations()[0].Name())
+	})
+
+	t.Run("should reject nil destination", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithDestination(nil).
+			Build()
+
+		require.Error(t, err)
+	})
+}
+
+func TestBuilder_WithSignalSettings(t *testing.T) {
+	t.Run("should apply per-signal settings", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithSignalEndpoint(domain.SignalMetrics, "metrics-collector:4318").
+			WithSignalProtocol(domain.SignalMetrics, domain.ProtocolHTTP).
+			WithSignalHeader(domain.SignalMetrics, "x-team", "platform").
+			WithSignalCompression(domain.SignalMetrics, false).
+			WithSignalTimeout(domain.SignalMetrics, 5*time.Second).
+			Build()
+
+		require.NoError(t, err)
+		config := client.Config()
+		assert.Equal(t, "metrics-collector:4318", config.SignalEndpoint(domain.SignalMetrics))
+		assert.Equal(t, domain.ProtocolHTTP, config.SignalProtocol(domain.SignalMetrics))
+		assert.Equal(t, "platform", config.SignalHeaders(domain.SignalMetrics)["x-team"])
+		assert.False(t, config.IsSignalCompressionEnabled(domain.SignalMetrics))
+		assert.Equal(t, 5*time.Second, config.SignalTimeout(domain.SignalMetrics))
+		assert.Equal(t, "localhost:4317", config.SignalEndpoint(domain.SignalTraces))
+	})
+
+	t.Run("should read per-signal settings from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_TRACES_OTLP_ENDPOINT", "traces-collector:4317")
+		t.Setenv("TELEMETRYFLOW_METRICS_OTLP_PROTOCOL", "HTTP")
+		t.Setenv("TELEMETRYFLOW_METRICS_OTLP_HEADERS", "x-team=platform, x-tier=gold")
+		t.Setenv("TELEMETRYFLOW_LOGS_OTLP_COMPRESSION", "none")
+		t.Setenv("TELEMETRYFLOW_LOGS_OTLP_TIMEOUT", "2s")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithSignalSettingsFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		config := client.Config()
+		assert.Equal(t, "traces-collector:4317", config.SignalEndpoint(domain.SignalTraces))
+		assert.Equal(t, domain.ProtocolHTTP, config.SignalProtocol(domain.SignalMetrics))
+		assert.Equal(t, map[string]string{"x-team": "platform", "x-tier": "gold"}, config.SignalHeaders(domain.SignalMetrics))
+		assert.False(t, config.IsSignalCompressionEnabled(domain.SignalLogs))
+		assert.Equal(t, 2*time.Second, config.SignalTimeout(domain.SignalLogs))
+	})
+
+	t.Run("should reject invalid per-signal environment values", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_TRACES_OTLP_COMPRESSION", "zstd")
+
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithSignalSettingsFromEnv().
+			Build()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TELEMETRYFLOW_TRACES_OTLP_COMPRESSION")
+	})
+}
+
+func TestBuilder_WithConfigFile(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("should load settings with environment expansion", func(t *testing.T) {
+		t.Setenv("TEST_TFO_KEY_SECRET", "tfs_from_env")
+		path := writeConfig(t, `
+service:
+  name: "file-service"
+  version: "2.0.0"
+credentials:
+  key_id: "tfk_file"
+  key_secret: "${TEST_TFO_KEY_SECRET}"
+endpoint:
+  address: "${TEST_TFO_UNSET_ENDPOINT:localhost:4317}"
+  protocol: "grpc"
+  insecure: true
+  timeout: "15s"
+signals:
+  traces:
+    enabled: true
+    endpoint: "traces-collector:4317"
+  metrics:
+    enabled: true
+    endpoint: "metrics-collector:4318"
+    protocol: "http"
+    compression: "none"
+    timeout: "5s"
+    headers:
+      x-team: "platform"
+  logs:
+    enabled: false
+`)
+
+		client, err := telemetryflow.NewBuilder().WithConfigFile(path).Build()
+
+		require.NoError(t, err)
+		config := client.Config()
+		assert.Equal(t, "file-service", config.ServiceName())
+		assert.Equal(t, "tfs_from_env", config.Credentials().KeySecret())
+		assert.Equal(t, "localhost:4317", config.Endpoint())
+		assert.True(t, config.IsInsecure())
+		assert.Equal(t, 15*time.Second, config.Timeout())
+		assert.False(t, config.IsSignalEnabled(domain.SignalLogs))
+		assert.Equal(t, "traces-collector:4317", config.SignalEndpoint(domain.SignalTraces))
+		assert.Equal(t, domain.ProtocolGRPC, config.SignalProtocol(domain.SignalTraces))
+		assert.Equal(t, "metrics-collector:4318", config.SignalEndpoint(domain.SignalMetrics))
+		assert.Equal(t, domain.ProtocolHTTP, config.SignalProtocol(domain.SignalMetrics))
+		assert.False(t, config.IsSignalCompressionEnabled(domain.SignalMetrics))
+		assert.Equal(t, 5*time.Second, config.SignalTimeout(domain.SignalMetrics))
+		assert.Equal(t, "platform", config.SignalHeaders(domain.SignalMetrics)["x-team"])
+	})
+
+	t.Run("should let later builder calls override the file", func(t *testing.T) {
+		path := writeConfig(t, `
+service:
+  name: "file-service"
+credentials:
+  key_id: "tfk_file"
+  key_secret: "tfs_file"
+endpoint:
+  address: "localhost:4317"
+`)
+
+		client, err := telemetryflow.NewBuilder().
+			WithConfigFile(path).
+			WithEndpoint("override:4317").
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, "override:4317", client.Config().Endpoint())
+	})
+
+	t.Run("should load the default config file", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_API_KEY_ID", "tfk_test")
+		t.Setenv("TELEMETRYFLOW_API_KEY_SECRET", "tfs_secret")
+
+		client, err := telemetryflow.NewBuilder().
+			WithConfigFile("../../../../configs/sdk-default.yaml").
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, "localhost:4317", client.Config().SignalEndpoint(domain.SignalTraces))
+	})
+
+	t.Run("should fail for a missing file", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")).
+			Build()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read config file")
+	})
+
+	t.Run("should fail for an invalid duration", func(t *testing.T) {
+		path := writeConfig(t, `
+endpoint:
+  timeout: "soon"
+`)
+
+		_, err := telemetryflow.NewBuilder().WithConfigFile(path).Build()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "endpoint.timeout")
+	})
+}
+
+func TestBuilder_WithHTTPTransport(t *testing.T) {
+	t.Run("should apply proxy, transport and header settings", func(t *testing.T) {
+		provider := func(context.Context) (map[string]string, error) {
+			return map[string]string{"X-Token": "t"}, nil
+		}
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("https://gateway.example.com").
+			WithService("test-service", "1.0.0").
+			WithProxy("http://proxy.corp:3128").
+			WithProxyAuth("svc", "secret").
+			WithNoProxy("localhost").
+			WithHeader("X-Tenant-ID", "tenant-a").
+			WithHeaders(map[string]string{"X-Team": "platform"}).
+			WithHeaderProvider(provider).
+			Build()
+
+		require.NoError(t, err)
+		config := client.Config()
+		username, password := config.ProxyCredentials()
+		assert.Equal(t, "http://proxy.corp:3128", config.ProxyURL())
+		assert.Equal(t, "svc", username)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, []string{"localhost"}, config.NoProxy())
+		assert.Equal(t, map[string]string{"X-Tenant-ID": "tenant-a", "X-Team": "platform"}, config.Headers())
+		assert.NotNil(t, config.HeaderProvider())
+	})
+
+	t.Run("should apply a custom HTTP client", func(t *testing.T) {
+		httpClient := &http.Client{Timeout: time.Second}
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4318").
+			WithService("test-service", "1.0.0").
+			WithHTTPClient(httpClient).
+			Build()
+
+		require.NoError(t, err)
+		assert.Same(t, httpClient, client.Config().HTTPClient())
+	})
+
+	t.Run("should read proxy and headers from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_PROXY_URL", "http://proxy.corp:3128")
+		t.Setenv("TELEMETRYFLOW_PROXY_USERNAME", "svc")
+		t.Setenv("TELEMETRYFLOW_PROXY_PASSWORD", "secret")
+		t.Setenv("TELEMETRYFLOW_NO_PROXY", "localhost, .internal")
+		t.Setenv("TELEMETRYFLOW_OTLP_HEADERS", "x-tenant-id=tenant-a")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4318").
+			WithService("test-service", "1.0.0").
+			WithProxyFromEnv().
+			WithHeadersFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		config := client.Config()
+		username, _ := config.ProxyCredentials()
+		assert.Equal(t, "http://proxy.corp:3128", config.ProxyURL())
+		assert.Equal(t, "svc", username)
+		assert.Equal(t, []string{"localhost", ".internal"}, config.NoProxy())
+		assert.Equal(t, "tenant-a", config.Headers()["x-tenant-id"])
+	})
+
+	t.Run("should read the HTTP encoding from environment and config file", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_OTLP_HTTP_ENCODING", "JSON")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4318").
+			WithService("test-service", "1.0.0").
+			WithHTTPEncodingFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.HTTPEncodingJSON, client.Config().HTTPEncoding())
+
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+endpoint:
+  address: "localhost:4318"
+  encoding: json
+`), 0o600))
+
+		client, err = telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithService("test-service", "1.0.0").
+			WithConfigFile(path).
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.HTTPEncodingJSON, client.Config().HTTPEncoding())
+	})
+
+	t.Run("should reject an unsupported HTTP encoding", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4318").
+			WithService("test-service", "1.0.0").
+			WithHTTPEncoding("xml").
+			Build()
+
+		assert.ErrorContains(t, err, "unsupported HTTP encoding")
+	})
+
+	t.Run("should reject a proxy combined with a custom transport", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4318").
+			WithService("test-service", "1.0.0").
+			WithProxy("http://proxy.corp:3128").
+			WithHTTPTransport(http.DefaultTransport).
+			Build()
+
+		require.Error(t, err)
+	})
+
+	t.Run("should reject a proxy bypass list combined with a custom client", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4318").
+			WithService("test-service", "1.0.0").
+			WithNoProxy("localhost").
+			WithHTTPClient(&http.Client{Transport: http.DefaultTransport}).
+			Build()
+
+		assert.ErrorContains(t, err, "configure the proxy on its transport")
+	})
+}
+
 // Benchmark tests
 func BenchmarkBuilder_Build(b *testing.B) {
 	b.ResetTimer()
@@ -719,3 +1088,691 @@ func BenchmarkBuilder_BuildWithTFOv2(b *testing.B) {
 			Build()
 	}
 }
+
+func TestBuilder_WithConsoleExporter(t *testing.T) {
+	t.Run("should add the console exporter alongside OTLP", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithConsoleExporter().
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ExporterType{domain.ExporterConsole, domain.ExporterOTLP}, client.Config().Exporters())
+	})
+
+	t.Run("should read exporters from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_EXPORTER", "Console")
+
+		client, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporterFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ExporterType{domain.ExporterConsole}, client.Config().Exporters())
+	})
+
+	t.Run("should still require credentials for OTLP", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithConsoleExporter().
+			Build()
+
+		require.Error(t, err)
+	})
+
+	t.Run("should reject an unknown exporter", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporters(domain.ExporterType("zipkin")).
+			Build()
+
+		require.Error(t, err)
+	})
+}
+
+func TestBuilder_WithFileExporter(t *testing.T) {
+	t.Run("should add the file exporter with a directory", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporters(domain.ExporterFile).
+			WithFileExporter("/var/lib/telemetry").
+			WithFileRotation(10<<20, 30*time.Minute, false).
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ExporterType{domain.ExporterFile}, client.Config().Exporters())
+		assert.Equal(t, domain.FileExporterConfig{
+			Directory: "/var/lib/telemetry",
+			MaxSize:   10 << 20,
+			MaxAge:    30 * time.Minute,
+			Compress:  false,
+		}, client.Config().FileExporter())
+	})
+
+	t.Run("should read file exporter settings from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_EXPORTER", "file,console")
+		t.Setenv("TELEMETRYFLOW_FILE_EXPORTER_DIR", "/tmp/tf")
+		t.Setenv("TELEMETRYFLOW_FILE_EXPORTER_MAX_SIZE_MB", "5")
+		t.Setenv("TELEMETRYFLOW_FILE_EXPORTER_MAX_AGE", "2h")
+		t.Setenv("TELEMETRYFLOW_FILE_EXPORTER_COMPRESS", "false")
+
+		client, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporterFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		config := client.Config().FileExporter()
+		assert.Equal(t, "/tmp/tf", config.Directory)
+		assert.Equal(t, int64(5<<20), config.MaxSize)
+		assert.Equal(t, 2*time.Hour, config.MaxAge)
+		assert.False(t, config.Compress)
+	})
+
+	t.Run("should reject an empty directory", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithFileExporter("").
+			Build()
+
+		require.Error(t, err)
+	})
+}
+
+func TestBuilder_WithInProcessProcessors(t *testing.T) {
+	t.Run("should allow no exporters with an in-process processor", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporters().
+			WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(tracetest.NewInMemoryExporter())).
+			Build()
+
+		require.NoError(t, err)
+		assert.Empty(t, client.Config().Exporters())
+		assert.True(t, client.Config().HasInProcessConsumers())
+	})
+
+	t.Run("should reject no exporters without consumers", func(t *testing.T) {
+		_, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporters().
+			Build()
+
+		require.Error(t, err)
+	})
+}
+
+func TestBuilder_WithPrometheusEndpoint(t *testing.T) {
+	t.Run("should add the Prometheus exporter alongside OTLP", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithPrometheusEndpoint(":9464").
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ExporterType{domain.ExporterOTLP, domain.ExporterPrometheus}, client.Config().Exporters())
+		assert.Equal(t, ":9464", client.Config().PrometheusEndpoint())
+	})
+
+	t.Run("should read the Prometheus endpoint from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_EXPORTER", "prometheus")
+		t.Setenv("TELEMETRYFLOW_PROMETHEUS_ENDPOINT", "0.0.0.0:9100")
+
+		client, err := telemetryflow.NewBuilder().
+			WithService("test-service", "1.0.0").
+			WithExporterFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ExporterType{domain.ExporterPrometheus}, client.Config().Exporters())
+		assert.Equal(t, "0.0.0.0:9100", client.Config().PrometheusEndpoint())
+	})
+}
+
+func TestBuilder_WithRuntimeMetrics(t *testing.T) {
+	t.Run("should leave runtime metrics off by default", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			Build()
+
+		require.NoError(t, err)
+		assert.False(t, client.Config().IsRuntimeMetricsEnabled())
+		assert.Equal(t, 10*time.Second, client.Config().RuntimeMetricsInterval())
+	})
+
+	t.Run("should enable runtime metrics with a custom interval", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithRuntimeMetrics().
+			WithRuntimeMetricsInterval(30 * time.Second).
+			Build()
+
+		require.NoError(t, err)
+		assert.True(t, client.Config().IsRuntimeMetricsEnabled())
+		assert.Equal(t, 30*time.Second, client.Config().RuntimeMetricsInterval())
+	})
+
+	t.Run("should read runtime metrics settings from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_RUNTIME_METRICS", "true")
+		t.Setenv("TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL", "5s")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithRuntimeMetricsFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.True(t, client.Config().IsRuntimeMetricsEnabled())
+		assert.Equal(t, 5*time.Second, client.Config().RuntimeMetricsInterval())
+	})
+
+	t.Run("should let the environment turn runtime metrics off", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_RUNTIME_METRICS", "false")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithRuntimeMetrics().
+			WithRuntimeMetricsFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.False(t, client.Config().IsRuntimeMetricsEnabled())
+	})
+
+	t.Run("should fail on an invalid interval", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_RUNTIME_METRICS_INTERVAL", "soon")
+
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithRuntimeMetricsFromEnv().
+			Build()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_WithHostMetrics(t *testing.T) {
+	t.Run("should enable process and host metrics separately", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithProcessMetrics().
+			Build()
+
+		require.NoError(t, err)
+		assert.True(t, client.Config().IsProcessMetricsEnabled())
+		assert.False(t, client.Config().IsHostMetricsEnabled())
+	})
+
+	t.Run("should read process and host metrics settings from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_PROCESS_METRICS", "true")
+		t.Setenv("TELEMETRYFLOW_HOST_METRICS", "1")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithHostMetricsFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.True(t, client.Config().IsProcessMetricsEnabled())
+		assert.True(t, client.Config().IsHostMetricsEnabled())
+	})
+
+	t.Run("should fail on an invalid boolean", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_HOST_METRICS", "sometimes")
+
+		_, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithHostMetricsFromEnv().
+			Build()
+
+		assert.ErrorContains(t, err, "TELEMETRYFLOW_HOST_METRICS")
+	})
+}
+
+func TestBuilder_WithSelfMetrics(t *testing.T) {
+	t.Run("should leave self metrics off by default", func(t *testing.T) {
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			Build()
+
+		require.NoError(t, err)
+		assert.False(t, client.Config().IsSelfMetricsEnabled())
+	})
+
+	t.Run("should enable self metrics with a separate meter provider", func(t *testing.T) {
+		provider := sdkmetric.NewMeterProvider()
+		defer func() { _ = provider.Shutdown(context.Background()) }()
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithSelfMetricsMeterProvider(provider).
+			Build()
+
+		require.NoError(t, err)
+		assert.True(t, client.Config().IsSelfMetricsEnabled())
+	})
+
+	t.Run("should read self metrics setting from environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_SELF_METRICS", "true")
+
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithSelfMetricsFromEnv().
+			Build()
+
+		require.NoError(t, err)
+		assert.True(t, client.Config().IsSelfMetricsEnabled())
+	})
+}
+
+func TestBuilder_WithExportCallbacks(t *testing.T) {
+	t.Run("should pass the error handler and export callbacks to the config", func(t *testing.T) {
+		var handled []error
+		client, err := telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0").
+			WithErrorHandler(func(err error) { handled = append(handled, err) }).
+			OnExportSuccess(func(domain.ExportEvent) {}).
+			OnExportFailure(func(domain.ExportEvent) {}).
+			OnExportFailure(func(domain.ExportEvent) {}).
+			Build()
+
+		require.NoError(t, err)
+		require.NotNil(t, client.Config().ErrorHandler())
+		client.Config().ErrorHandler()(assert.AnError)
+		assert.Equal(t, []error{assert.AnError}, handled)
+		assert.Len(t, client.Config().ExportSuccessHooks(), 1)
+		assert.Len(t, client.Config().ExportFailureHooks(), 2)
+	})
+}
+
+func TestBuilder_WithCircuitBreaker(t *testing.T) {
+	newBuilder := func() *telemetryflow.Builder {
+		return telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0")
+	}
+
+	t.Run("should leave the breaker disabled by default", func(t *testing.T) {
+		client, err := newBuilder().Build()
+
+		require.NoError(t, err)
+		assert.False(t, client.Config().CircuitBreaker().Enabled())
+	})
+
+	t.Run("should fill unset breaker settings with defaults", func(t *testing.T) {
+		client, err := newBuilder().
+			WithRetryMaxBackoff(time.Minute).
+			WithCircuitBreaker(3, 0).
+			WithCircuitBreakerBuffer(100).
+			Build()
+
+		require.NoError(t, err)
+		breaker := client.Config().CircuitBreaker()
+		assert.Equal(t, 3, breaker.FailureThreshold)
+		assert.Equal(t, 30*time.Second, breaker.Cooldown)
+		assert.Equal(t, domain.BreakerBuffer, breaker.Policy)
+		assert.Equal(t, 100, breaker.BufferSize)
+		assert.Equal(t, time.Minute, client.Config().RetryMaxBackoff())
+	})
+
+	t.Run("should read the breaker from the environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_THRESHOLD", "4")
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_COOLDOWN", "1m")
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_POLICY", "BUFFER")
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_BUFFER_SIZE", "512")
+
+		client, err := newBuilder().WithCircuitBreakerFromEnv().Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CircuitBreakerConfig{
+			FailureThreshold: 4,
+			Cooldown:         time.Minute,
+			Policy:           domain.BreakerBuffer,
+			BufferSize:       512,
+		}, client.Config().CircuitBreaker())
+	})
+
+	t.Run("should load the breaker from a config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+retry:
+  max_backoff: "20s"
+circuit_breaker:
+  failure_threshold: 2
+  cooldown: "5s"
+  policy: drop
+`), 0o600))
+
+		client, err := newBuilder().WithConfigFile(path).Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Second, client.Config().RetryMaxBackoff())
+		assert.Equal(t, 2, client.Config().CircuitBreaker().FailureThreshold)
+		assert.Equal(t, 5*time.Second, client.Config().CircuitBreaker().Cooldown)
+	})
+
+	t.Run("should collect invalid environment values", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_THRESHOLD", "many")
+
+		_, err := newBuilder().WithCircuitBreakerFromEnv().Build()
+
+		assert.ErrorContains(t, err, "TELEMETRYFLOW_CIRCUIT_BREAKER_THRESHOLD")
+	})
+
+	t.Run("should reject an unknown policy", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_THRESHOLD", "4")
+		t.Setenv("TELEMETRYFLOW_CIRCUIT_BREAKER_POLICY", "queue")
+
+		_, err := newBuilder().WithCircuitBreakerFromEnv().Build()
+
+		assert.ErrorContains(t, err, "unknown circuit breaker policy")
+	})
+}
+
+func TestBuilder_WithMaxRequestSize(t *testing.T) {
+	newBuilder := func() *telemetryflow.Builder {
+		return telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0")
+	}
+
+	t.Run("should set the request size limit", func(t *testing.T) {
+		client, err := newBuilder().WithMaxRequestSize(1 << 20).Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1<<20, client.Config().MaxRequestSize())
+	})
+
+	t.Run("should load the limit from a config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+batch:
+  max_request_size: 2097152
+`), 0o600))
+
+		client, err := newBuilder().WithConfigFile(path).Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2<<20, client.Config().MaxRequestSize())
+	})
+
+	t.Run("should reject a negative limit", func(t *testing.T) {
+		_, err := newBuilder().WithMaxRequestSize(-1).Build()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_WithMemoryLimiter(t *testing.T) {
+	newBuilder := func() *telemetryflow.Builder {
+		return telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0")
+	}
+
+	t.Run("should leave the limiter disabled by default", func(t *testing.T) {
+		client, err := newBuilder().Build()
+
+		require.NoError(t, err)
+		assert.False(t, client.Config().MemoryLimiter().Enabled())
+	})
+
+	t.Run("should fill the degradation with defaults", func(t *testing.T) {
+		client, err := newBuilder().WithMemoryLimit(1<<20, 2<<20).Build()
+
+		require.NoError(t, err)
+		limiter := client.Config().MemoryLimiter()
+		assert.Equal(t, int64(1<<20), limiter.SoftLimit)
+		assert.Equal(t, int64(2<<20), limiter.HardLimit)
+		assert.Equal(t, 0.1, limiter.SamplingRatio)
+		assert.Equal(t, "warn", limiter.MinSeverity)
+		assert.Equal(t, time.Second, limiter.CheckInterval)
+	})
+
+	t.Run("should keep an explicit degradation", func(t *testing.T) {
+		client, err := newBuilder().
+			WithHeapLimit(256<<20, 512<<20).
+			WithMemoryLimiterDegradation(0, "").
+			Build()
+
+		require.NoError(t, err)
+		limiter := client.Config().MemoryLimiter()
+		assert.Equal(t, int64(512<<20), limiter.HeapHardLimit)
+		assert.Zero(t, limiter.SamplingRatio)
+		assert.Empty(t, limiter.MinSeverity)
+	})
+
+	t.Run("should read the limiter from the environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_MEMORY_SOFT_LIMIT", "1048576")
+		t.Setenv("TELEMETRYFLOW_MEMORY_HARD_LIMIT", "2097152")
+		t.Setenv("TELEMETRYFLOW_MEMORY_HEAP_HARD_LIMIT", "536870912")
+		t.Setenv("TELEMETRYFLOW_MEMORY_SAMPLING_RATIO", "0.5")
+		t.Setenv("TELEMETRYFLOW_MEMORY_MIN_LOG_SEVERITY", "error")
+		t.Setenv("TELEMETRYFLOW_MEMORY_CHECK_INTERVAL", "5s")
+
+		client, err := newBuilder().WithMemoryLimiterFromEnv().Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.MemoryLimiterConfig{
+			SoftLimit:     1 << 20,
+			HardLimit:     2 << 20,
+			HeapHardLimit: 512 << 20,
+			SamplingRatio: 0.5,
+			MinSeverity:   "error",
+			CheckInterval: 5 * time.Second,
+		}, client.Config().MemoryLimiter())
+	})
+
+	t.Run("should load the limiter from a config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+memory_limiter:
+  soft_limit: 1048576
+  hard_limit: 2097152
+  sampling_ratio: 0.25
+`), 0o600))
+
+		client, err := newBuilder().WithConfigFile(path).Build()
+
+		require.NoError(t, err)
+		limiter := client.Config().MemoryLimiter()
+		assert.Equal(t, int64(2<<20), limiter.HardLimit)
+		assert.Equal(t, 0.25, limiter.SamplingRatio)
+		assert.Equal(t, "warn", limiter.MinSeverity)
+	})
+
+	t.Run("should collect invalid environment values", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_MEMORY_HARD_LIMIT", "lots")
+
+		_, err := newBuilder().WithMemoryLimiterFromEnv().Build()
+
+		assert.ErrorContains(t, err, "TELEMETRYFLOW_MEMORY_HARD_LIMIT")
+	})
+}
+
+func TestBuilder_WithTimestampSkew(t *testing.T) {
+	newBuilder := func() *telemetryflow.Builder {
+		return telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0")
+	}
+
+	t.Run("should apply the default limits", func(t *testing.T) {
+		client, err := newBuilder().Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultTimestampSkewConfig(), client.Config().TimestampSkew())
+	})
+
+	t.Run("should set the limits", func(t *testing.T) {
+		client, err := newBuilder().WithTimestampSkew(7*24*time.Hour, 0).Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TimestampSkewConfig{MaxPast: 7 * 24 * time.Hour}, client.Config().TimestampSkew())
+	})
+
+	t.Run("should read the limits from the environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_TIMESTAMP_MAX_PAST", "72h")
+		t.Setenv("TELEMETRYFLOW_TIMESTAMP_MAX_FUTURE", "1m")
+
+		client, err := newBuilder().WithTimestampSkewFromEnv().Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TimestampSkewConfig{MaxPast: 72 * time.Hour, MaxFuture: time.Minute}, client.Config().TimestampSkew())
+	})
+
+	t.Run("should load the limits from a config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+timestamps:
+  max_past: "48h"
+`), 0o600))
+
+		client, err := newBuilder().WithConfigFile(path).Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, 48*time.Hour, client.Config().TimestampSkew().MaxPast)
+		assert.Equal(t, 5*time.Minute, client.Config().TimestampSkew().MaxFuture)
+	})
+
+	t.Run("should collect invalid environment values", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_TIMESTAMP_MAX_PAST", "a week")
+
+		_, err := newBuilder().WithTimestampSkewFromEnv().Build()
+
+		assert.ErrorContains(t, err, "TELEMETRYFLOW_TIMESTAMP_MAX_PAST")
+	})
+}
+
+func TestBuilder_WithLogFilter(t *testing.T) {
+	newBuilder := func() *telemetryflow.Builder {
+		return telemetryflow.NewBuilder().
+			WithAPIKey("tfk_test", "tfs_secret").
+			WithEndpoint("localhost:4317").
+			WithService("test-service", "1.0.0")
+	}
+
+	t.Run("should not filter logs by default", func(t *testing.T) {
+		client, err := newBuilder().Build()
+
+		require.NoError(t, err)
+		assert.False(t, client.Config().LogFilter().Enabled())
+	})
+
+	t.Run("should set the filter", func(t *testing.T) {
+		client, err := newBuilder().
+			WithMinLogSeverity("info").
+			WithLoggerSeverity("db", "debug").
+			WithLoggerSeverity("http.access", "error").
+			WithLogSampling(time.Second, 10, 100).
+			WithLogDeduplication(5 * time.Second).
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LogFilterConfig{
+			MinSeverity:        "info",
+			LoggerSeverities:   map[string]string{"db": "debug", "http.access": "error"},
+			SamplingInterval:   time.Second,
+			SamplingInitial:    10,
+			SamplingThereafter: 100,
+			DedupWindow:        5 * time.Second,
+		}, client.Config().LogFilter())
+	})
+
+	t.Run("should read the filter from the environment", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_LOG_MIN_SEVERITY", "warn")
+		t.Setenv("TELEMETRYFLOW_LOG_LOGGER_SEVERITIES", "db=debug, http = error")
+		t.Setenv("TELEMETRYFLOW_LOG_SAMPLING_INTERVAL", "1s")
+		t.Setenv("TELEMETRYFLOW_LOG_SAMPLING_INITIAL", "5")
+		t.Setenv("TELEMETRYFLOW_LOG_SAMPLING_THEREAFTER", "50")
+		t.Setenv("TELEMETRYFLOW_LOG_DEDUP_WINDOW", "10s")
+
+		client, err := newBuilder().WithLogFilterFromEnv().Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LogFilterConfig{
+			MinSeverity:        "warn",
+			LoggerSeverities:   map[string]string{"db": "debug", "http": "error"},
+			SamplingInterval:   time.Second,
+			SamplingInitial:    5,
+			SamplingThereafter: 50,
+			DedupWindow:        10 * time.Second,
+		}, client.Config().LogFilter())
+	})
+
+	t.Run("should load the filter from a config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sdk.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+log_filter:
+  min_severity: info
+  logger_severities:
+    db: debug
+  sampling:
+    interval: "2s"
+    initial: 3
+  dedup_window: "30s"
+`), 0o600))
+
+		client, err := newBuilder().WithConfigFile(path).Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LogFilterConfig{
+			MinSeverity:      "info",
+			LoggerSeverities: map[string]string{"db": "debug"},
+			SamplingInterval: 2 * time.Second,
+			SamplingInitial:  3,
+			DedupWindow:      30 * time.Second,
+		}, client.Config().LogFilter())
+	})
+
+	t.Run("should collect invalid environment values", func(t *testing.T) {
+		t.Setenv("TELEMETRYFLOW_LOG_LOGGER_SEVERITIES", "db")
+		t.Setenv("TELEMETRYFLOW_LOG_SAMPLING_INITIAL", "ten")
+
+		_, err := newBuilder().WithLogFilterFromEnv().Build()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TELEMETRYFLOW_LOG_LOGGER_SEVERITIES")
+		assert.Contains(t, err.Error(), "TELEMETRYFLOW_LOG_SAMPLING_INITIAL")
+	})
+
+	t.Run("should reject an invalid filter", func(t *testing.T) {
+		_, err := newBuilder().WithMinLogSeverity("loud").Build()
+
+		assert.Error(t, err)
+	})
+}
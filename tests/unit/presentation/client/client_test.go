@@ -96,6 +96,30 @@ func TestClient_IsInitialized(t *testing.T) {
 	})
 }
 
+func TestClient_Health(t *testing.T) {
+	t.Run("should report unhealthy before initialization", func(t *testing.T) {
+		client := createTestClient(t)
+
+		health, err := client.Health(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "unhealthy", health.Status)
+		assert.Equal(t, "disconnected", health.Metrics.ConnectionState)
+	})
+
+	t.Run("should report status with configured endpoints", func(t *testing.T) {
+		client := createTestClient(t)
+		client.Config().WithFailoverEndpoints("tfo-agent:4317")
+
+		status, err := client.Status(context.Background())
+
+		require.NoError(t, err)
+		assert.False(t, status.Initialized)
+		assert.Equal(t, []string{"localhost:4317", "tfo-agent:4317"}, status.Config["endpoints"])
+		assert.ElementsMatch(t, []string{"metrics", "logs", "traces"}, status.EnabledSignals)
+	})
+}
+
 func TestClient_Metrics(t *testing.T) {
 	client := createTestClient(t)
 	ctx := context.Background()
@@ -190,6 +214,29 @@ func TestClient_Traces(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not initialized")
 	})
+
+	t.Run("span updates should fail when not initialized", func(t *testing.T) {
+		errs := []error{
+			client.SetSpanAttributes(ctx, "test-span-id", map[string]interface{}{"key": "value"}),
+			client.SetSpanStatus(ctx, "test-span-id", "ok", ""),
+			client.SetSpanName(ctx, "test-span-id", "renamed"),
+			client.AddSpanLink(ctx, "test-span-id", "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", nil),
+			client.SetSpanBaggage(ctx, "test-span-id", "tenant", "acme"),
+		}
+
+		for _, err := range errs {
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "not initialized")
+		}
+	})
+
+	t.Run("SpanBaggage should fail when not initialized", func(t *testing.T) {
+		bag, err := client.SpanBaggage(ctx, "test-span-id")
+
+		require.Error(t, err)
+		assert.Nil(t, bag)
+		assert.Contains(t, err.Error(), "not initialized")
+	})
 }
 
 func TestClient_Flush(t *testing.T) {
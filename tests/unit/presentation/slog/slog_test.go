@@ -0,0 +1,249 @@
+// Package tfslog_test provides unit tests for the slog handler.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfslog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/tfslog"
+)
+
+// spanContext returns ctx carrying a sampled span
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		severity string
+	}{
+		{slog.LevelDebug - 8, "trace"},
+		{slog.LevelDebug - 1, "trace4"},
+		{slog.LevelDebug, "debug"},
+		{slog.LevelInfo, "info"},
+		{slog.LevelInfo + 1, "info2"},
+		{slog.LevelWarn, "warn"},
+		{slog.LevelError, "error"},
+		{slog.LevelError + 3, "error4"},
+		{slog.LevelError + 4, "fatal"},
+		{slog.LevelError + 100, "fatal4"},
+	}
+	for _, tt := range tests {
+		t.Run("should map "+tt.level.String()+" to "+tt.severity, func(t *testing.T) {
+			assert.Equal(t, tt.severity, tfslog.Severity(tt.level))
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("should export records at or above the level with their severity", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := slog.New(tfslog.NewHandler(kit.Client(), nil))
+
+		logger.Debug("not exported")
+		logger.Info("started")
+		logger.Log(context.Background(), slog.LevelInfo+1, "noticed")
+		logger.Error("failed")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 3)
+		assert.Equal(t, "started", logs[0].Body)
+		assert.Equal(t, "info", logs[0].Severity)
+		assert.Equal(t, 9, logs[0].SeverityNumber)
+		assert.Equal(t, "info2", logs[1].Severity)
+		assert.Equal(t, 10, logs[1].SeverityNumber)
+		assert.Equal(t, 17, logs[2].SeverityNumber)
+	})
+
+	t.Run("should use the configured level", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		level := new(slog.LevelVar)
+		level.Set(slog.LevelWarn)
+		logger := slog.New(tfslog.NewHandler(kit.Client(), &tfslog.Options{Level: level}))
+
+		logger.Info("dropped")
+		level.Set(slog.LevelDebug)
+		logger.Debug("kept")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.Equal(t, "kept", logs[0].Body)
+		assert.Equal(t, "debug", logs[0].Severity)
+	})
+
+	t.Run("should keep the record time", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		handler := tfslog.NewHandler(kit.Client(), nil)
+		timestamp := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+
+		require.NoError(t, handler.Handle(context.Background(), slog.NewRecord(timestamp, slog.LevelInfo, "earlier", 0)))
+
+		require.Len(t, kit.Logs(), 1)
+		assert.True(t, timestamp.Equal(kit.Logs()[0].Timestamp))
+	})
+
+	t.Run("should prefix the attributes of groups", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := slog.New(tfslog.NewHandler(kit.Client(), nil)).
+			With("service.tier", "web").
+			WithGroup("http").
+			With("route", "/orders")
+
+		logger.Info("request",
+			"method", "GET",
+			"status", 200,
+			slog.Group("client", "ip", "10.0.0.1"),
+			slog.Group("", "inline", true),
+			slog.Group("empty"),
+			slog.Duration("elapsed", 1500*time.Millisecond),
+			slog.Any("error", errors.New("timeout")),
+		)
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.Equal(t, map[string]interface{}{
+			"service.tier":   "web",
+			"http.route":     "/orders",
+			"http.method":    "GET",
+			"http.status":    int64(200),
+			"http.client.ip": "10.0.0.1",
+			"http.inline":    true,
+			"http.elapsed":   "1.5s",
+			"http.error":     "timeout",
+		}, logs[0].Attributes)
+	})
+
+	t.Run("should not share attributes between derived loggers", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		base := slog.New(tfslog.NewHandler(kit.Client(), nil)).With("a", 1)
+
+		base.With("b", 2).Info("first")
+		base.With("c", 3).Info("second")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 2)
+		assert.NotContains(t, logs[1].Attributes, "b")
+		assert.Contains(t, logs[1].Attributes, "c")
+	})
+
+	t.Run("should not share attributes between concurrent records", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		// Three attributes leave spare capacity in the handler's attributes
+		logger := slog.New(tfslog.NewHandler(kit.Client(), nil)).With("a", 1, "b", 2, "c", 3)
+
+		var wg sync.WaitGroup
+		for g := range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 50 {
+					logger.Info("concurrent", "worker", g, "i", i)
+				}
+			}()
+		}
+		wg.Wait()
+
+		logs := kit.Logs()
+		require.Len(t, logs, 200)
+		for _, log := range logs {
+			assert.Len(t, log.Attributes, 5)
+		}
+	})
+
+	t.Run("should correlate records with the span in the context", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := slog.New(tfslog.NewHandler(kit.Client(), nil))
+
+		logger.InfoContext(spanContext(t), "in span")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", logs[0].TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", logs[0].SpanID)
+	})
+
+	t.Run("should add the source of records", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := slog.New(tfslog.NewHandler(kit.Client(), &tfslog.Options{AddSource: true}))
+
+		logger.Info("with source")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.True(t, strings.HasSuffix(logs[0].Attributes["code.filepath"].(string), "slog_test.go"))
+		assert.Contains(t, logs[0].Attributes["code.function"], "TestHandler")
+		assert.NotZero(t, logs[0].Attributes["code.lineno"])
+	})
+
+	t.Run("should tee records to a local handler", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		var out bytes.Buffer
+		logger := slog.New(tfslog.NewHandler(kit.Client(), &tfslog.Options{
+			Tee: slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		})).WithGroup("job").With("id", 7)
+
+		logger.Debug("local only")
+		logger.InfoContext(spanContext(t), "both")
+
+		require.Len(t, kit.Logs(), 1)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var local map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &local))
+		assert.Equal(t, "both", local["msg"])
+		job := local["job"].(map[string]interface{})
+		assert.Equal(t, 7.0, job["id"])
+		assert.Equal(t, kit.Logs()[0].TraceID, job["trace_id"])
+		assert.Equal(t, kit.Logs()[0].SpanID, job["span_id"])
+	})
+
+	t.Run("should report export errors", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		require.NoError(t, kit.Client().Shutdown(context.Background()))
+		handler := tfslog.NewHandler(kit.Client(), nil)
+
+		err := handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "after shutdown", 0))
+
+		assert.Error(t, err)
+	})
+}
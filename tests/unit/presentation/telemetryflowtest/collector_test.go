@@ -0,0 +1,204 @@
+// Package telemetryflowtest_test provides unit tests for the telemetryflowtest fake collector.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/domain"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/infrastructure"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// collectorClient builds an initialized client that exports to endpoint
+func collectorClient(t *testing.T, endpoint string, protocol domain.Protocol) *telemetryflow.Client {
+	client, err := telemetryflow.NewBuilder().
+		WithAPIKey("tfk_collector", "tfs_secret").
+		WithEndpoint(endpoint).
+		WithProtocol(protocol).
+		WithInsecure(true).
+		WithService("collector-test", "1.0.0").
+		WithRetry(true, 3, 10*time.Millisecond).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, client.Initialize(context.Background()))
+	return client
+}
+
+// emitSpan records a single span and flushes it by shutting the client down
+func emitSpan(t *testing.T, client *telemetryflow.Client, name string) {
+	ctx := context.Background()
+	spanID, err := client.StartSpan(ctx, name, "internal", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.EndSpan(ctx, spanID, nil))
+	require.NoError(t, client.Shutdown(ctx))
+}
+
+// traceExporter creates the SDK's trace exporter for endpoint without batching
+func traceExporter(t *testing.T, endpoint string, protocol domain.Protocol) (func() error, func()) {
+	creds, err := domain.NewCredentials("tfk_collector", "tfs_secret")
+	require.NoError(t, err)
+	config, err := domain.NewTelemetryConfig(creds, endpoint, "collector-test")
+	require.NoError(t, err)
+	config.WithProtocol(protocol).WithInsecure(true).WithRetry(false, 0, 0).WithTimeout(100 * time.Millisecond)
+
+	exporter, err := infrastructure.NewOTLPExporterFactory(config).CreateTraceExporter(context.Background())
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{{
+		Name: "probe",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{1},
+		}),
+	}}.Snapshots()
+	export := func() error { return exporter.ExportSpans(context.Background(), spans) }
+	shutdown := func() { _ = exporter.Shutdown(context.Background()) }
+	return export, shutdown
+}
+
+func TestCollector_Receive(t *testing.T) {
+	t.Run("should record gRPC exports with TelemetryFlow headers", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collectorClient(t, collector.GRPCEndpoint(), domain.ProtocolGRPC)
+
+		emitSpan(t, client, "grpc-span")
+
+		spans := collector.Spans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "grpc-span", spans[0].Name)
+
+		requests := collector.Requests(domain.SignalTraces)
+		require.NotEmpty(t, requests)
+		assert.Equal(t, domain.ProtocolGRPC, requests[0].Protocol)
+		assert.Equal(t, "tfk_collector", requests[0].TelemetryFlowHeaders()["X-Telemetryflow-Key-Id"])
+	})
+
+	t.Run("should record HTTP exports on the v2 API", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		client := collectorClient(t, collector.HTTPEndpoint(), domain.ProtocolHTTP)
+
+		emitSpan(t, client, "http-span")
+
+		requests := collector.Requests(domain.SignalTraces)
+		require.NotEmpty(t, requests)
+		assert.Equal(t, "/v2/traces", requests[0].Path)
+		assert.Equal(t, "v2", requests[0].APIVersion)
+		assert.Equal(t, "tfk_collector", requests[0].Headers.Get("X-TelemetryFlow-Key-ID"))
+		assert.Len(t, collector.Spans(), 1)
+	})
+
+	t.Run("should let the SDK fall back to v1", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusNotFound, APIVersion: "v2"})
+		client := collectorClient(t, collector.HTTPEndpoint(), domain.ProtocolHTTP)
+
+		emitSpan(t, client, "v1-span")
+
+		var paths []string
+		for _, req := range collector.Requests(domain.SignalTraces) {
+			paths = append(paths, req.Path)
+		}
+		assert.Contains(t, paths, "/v1/traces")
+		assert.Len(t, collector.Spans(), 1)
+	})
+}
+
+func TestCollector_Faults(t *testing.T) {
+	t.Run("should let the SDK retry after a 503", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{
+			Status: http.StatusServiceUnavailable,
+			Signal: domain.SignalTraces,
+			Times:  1,
+		})
+		client := collectorClient(t, collector.HTTPEndpoint(), domain.ProtocolHTTP)
+
+		emitSpan(t, client, "retried")
+
+		requests := collector.Requests(domain.SignalTraces)
+		require.Len(t, requests, 2)
+		assert.Equal(t, http.StatusServiceUnavailable, requests[0].Status)
+		assert.Equal(t, http.StatusOK, requests[1].Status)
+		assert.Len(t, collector.Spans(), 1)
+	})
+
+	for _, protocol := range []domain.Protocol{domain.ProtocolGRPC, domain.ProtocolHTTP} {
+		t.Run("should reject unauthorized exports over "+string(protocol), func(t *testing.T) {
+			collector := telemetryflowtest.NewCollector(t)
+			collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusUnauthorized})
+			endpoint := collector.GRPCEndpoint()
+			if protocol == domain.ProtocolHTTP {
+				endpoint = collector.HTTPEndpoint()
+			}
+			export, shutdown := traceExporter(t, endpoint, protocol)
+			defer shutdown()
+
+			require.Error(t, export())
+			assert.Empty(t, collector.Spans())
+
+			collector.ClearFaults()
+			require.NoError(t, export())
+			assert.Len(t, collector.Spans(), 1)
+		})
+	}
+
+	t.Run("should answer 429 with Retry-After", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Status: http.StatusTooManyRequests, RetryAfter: 2 * time.Second})
+
+		resp, err := http.Post(collector.HTTPURL()+"/v1/logs", "application/x-protobuf", http.NoBody)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	})
+
+	t.Run("should delay slow responses past the exporter timeout", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+		collector.InjectFault(telemetryflowtest.Fault{Delay: time.Second, Times: 1})
+		export, shutdown := traceExporter(t, collector.GRPCEndpoint(), domain.ProtocolGRPC)
+		defer shutdown()
+
+		start := time.Now()
+		require.Error(t, export())
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should reject unknown paths", func(t *testing.T) {
+		collector := telemetryflowtest.NewCollector(t)
+
+		resp, err := http.Post(collector.HTTPURL()+"/v3/traces", "application/x-protobuf", http.NoBody)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Empty(t, collector.Requests())
+	})
+}
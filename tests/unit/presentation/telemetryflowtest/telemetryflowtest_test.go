@@ -0,0 +1,192 @@
+// Package telemetryflowtest_test provides unit tests for the telemetryflowtest package.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryflowtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+)
+
+// recordingT captures assertion failures instead of failing the test
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestKit_Spans(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should record finished spans with attributes and events", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		spanID, err := client.StartSpan(ctx, "checkout", "server", map[string]interface{}{"http.status_code": 200})
+		require.NoError(t, err)
+		require.NoError(t, client.AddSpanEvent(ctx, spanID, "cart.loaded", map[string]interface{}{"items": 3}))
+		require.NoError(t, client.EndSpan(ctx, spanID, errors.New("payment declined")))
+
+		span := kit.AssertSpan("checkout", map[string]interface{}{"http.status_code": 200})
+		assert.Equal(t, spanID, span.SpanID)
+		assert.Equal(t, "server", span.Kind)
+		require.Len(t, span.Events, 2)
+		assert.Equal(t, "cart.loaded", span.Events[0].Name)
+		assert.Equal(t, "exception", span.Events[1].Name)
+		assert.Equal(t, "payment declined", span.Events[1].Attributes["exception.message"])
+		assert.True(t, span.IsRoot())
+	})
+
+	t.Run("should expose the span tree", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		parentCtx, parent := otel.Tracer("test").Start(ctx, "handle")
+		childID, err := client.StartSpan(parentCtx, "db.query", "client", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, childID, nil))
+		parent.End()
+
+		roots := kit.Roots()
+		require.Len(t, roots, 1)
+		assert.Equal(t, "handle", roots[0].Name)
+
+		children := kit.Children(roots[0])
+		require.Len(t, children, 1)
+		assert.Equal(t, "db.query", children[0].Name)
+
+		found, ok := kit.Parent(children[0])
+		require.True(t, ok)
+		assert.Equal(t, roots[0].SpanID, found.SpanID)
+	})
+
+	t.Run("should report a missing span", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		spanID, err := kit.Client().StartSpan(ctx, "checkout", "server", map[string]interface{}{"region": "eu"})
+		require.NoError(t, err)
+		require.NoError(t, kit.Client().EndSpan(ctx, spanID, nil))
+
+		recorder := &recordingT{TB: t}
+		kit = telemetryflowtest.New(recorder)
+		kit.AssertSpan("checkout", nil)
+
+		require.Len(t, recorder.errors, 1)
+		assert.Contains(t, recorder.errors[0], `no span "checkout"`)
+	})
+}
+
+func TestKit_Metrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should accumulate counters per attribute set", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		require.NoError(t, client.IncrementCounter(ctx, "orders", 2, map[string]interface{}{"status": "paid"}))
+		_, ok := kit.FindMetric("orders")
+		require.True(t, ok)
+		require.NoError(t, client.IncrementCounter(ctx, "orders", 3, map[string]interface{}{"status": "paid"}))
+		require.NoError(t, client.IncrementCounter(ctx, "orders", 1, map[string]interface{}{"status": "failed"}))
+
+		metric, ok := kit.FindMetric("orders")
+		require.True(t, ok)
+		assert.Equal(t, "counter", metric.Type)
+		assert.Equal(t, 6.0, metric.Total())
+
+		paid, ok := metric.Point(map[string]interface{}{"status": "paid"})
+		require.True(t, ok)
+		assert.Equal(t, 5.0, paid.Value)
+	})
+
+	t.Run("should record histograms and gauges", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		require.NoError(t, client.RecordHistogram(ctx, "latency", 0.5, "s", nil))
+		require.NoError(t, client.RecordHistogram(ctx, "latency", 1.5, "s", nil))
+		require.NoError(t, client.RecordGauge(ctx, "queue.depth", 7, nil))
+
+		latency, ok := kit.FindMetric("latency")
+		require.True(t, ok)
+		assert.Equal(t, "histogram", latency.Type)
+		assert.Equal(t, "s", latency.Unit)
+		assert.Equal(t, uint64(2), latency.Points[0].Count)
+		assert.Equal(t, 2.0, latency.Points[0].Value)
+
+		depth, ok := kit.FindMetric("queue.depth")
+		require.True(t, ok)
+		assert.Equal(t, "gauge", depth.Type)
+		assert.Equal(t, 7.0, depth.Points[0].Value)
+	})
+}
+
+func TestKit_Logs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should record logs correlated with spans", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		spanCtx, span := otel.Tracer("test").Start(ctx, "work")
+		require.NoError(t, client.LogWarn(spanCtx, "retrying", map[string]interface{}{"attempt": 2}))
+		span.End()
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		entry := kit.AssertLog("retrying", map[string]interface{}{"attempt": 2})
+		assert.Equal(t, "warn", entry.Severity)
+		assert.Equal(t, span.SpanContext().SpanID().String(), entry.SpanID)
+	})
+}
+
+func TestKit_Reset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should discard recorded telemetry", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		client := kit.Client()
+
+		spanID, err := client.StartSpan(ctx, "before", "internal", nil)
+		require.NoError(t, err)
+		require.NoError(t, client.EndSpan(ctx, spanID, nil))
+		require.NoError(t, client.IncrementCounter(ctx, "events", 4, nil))
+		require.NoError(t, client.LogInfo(ctx, "before", nil))
+
+		kit.Reset()
+		require.NoError(t, client.IncrementCounter(ctx, "events", 1, nil))
+
+		assert.Empty(t, kit.Spans())
+		assert.Empty(t, kit.Logs())
+		metric, ok := kit.FindMetric("events")
+		require.True(t, ok)
+		assert.Equal(t, 1.0, metric.Total())
+	})
+}
@@ -0,0 +1,210 @@
+// Package tfzap_test provides unit tests for the zap core.
+//
+// TelemetryFlow Go SDK - Community Enterprise Observability Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+// Open Source Software built by Telemetri Data Indonesia.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfzap_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/telemetryflowtest"
+	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow/tfzap"
+)
+
+// spanContext returns ctx carrying a sampled span
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+// user is an object field
+type user struct {
+	id   int
+	name string
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", u.id)
+	enc.AddString("name", u.name)
+	return nil
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		level    zapcore.Level
+		severity string
+	}{
+		{zapcore.DebugLevel, "debug"},
+		{zapcore.InfoLevel, "info"},
+		{zapcore.WarnLevel, "warn"},
+		{zapcore.ErrorLevel, "error"},
+		{zapcore.DPanicLevel, "fatal"},
+		{zapcore.PanicLevel, "fatal2"},
+		{zapcore.FatalLevel, "fatal3"},
+	}
+	for _, tt := range tests {
+		t.Run("should map "+tt.level.String()+" to "+tt.severity, func(t *testing.T) {
+			assert.Equal(t, tt.severity, tfzap.Severity(tt.level))
+		})
+	}
+}
+
+func TestCore(t *testing.T) {
+	t.Run("should export entries at or above the level", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := zap.New(tfzap.NewCore(kit.Client(), nil))
+
+		logger.Debug("not exported")
+		logger.Info("started")
+		logger.Error("failed")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 2)
+		assert.Equal(t, "started", logs[0].Body)
+		assert.Equal(t, "info", logs[0].Severity)
+		assert.Equal(t, 9, logs[0].SeverityNumber)
+		assert.Equal(t, 17, logs[1].SeverityNumber)
+	})
+
+	t.Run("should use the configured level", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		level := zap.NewAtomicLevelAt(zapcore.WarnLevel)
+		logger := zap.New(tfzap.NewCore(kit.Client(), &tfzap.Options{Level: level}))
+
+		logger.Info("dropped")
+		level.SetLevel(zapcore.DebugLevel)
+		logger.Debug("kept")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.Equal(t, "kept", logs[0].Body)
+	})
+
+	t.Run("should convert field types", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := zap.New(tfzap.NewCore(kit.Client(), nil)).Named("orders").With(zap.String("service.tier", "web"))
+
+		logger.Info("request",
+			zap.Int("status", 200),
+			zap.Uint8("retries", 2),
+			zap.Float32("ratio", 0.5),
+			zap.Bool("cached", true),
+			zap.Duration("elapsed", 1500*time.Millisecond),
+			zap.Time("at", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+			zap.Error(errors.New("timeout")),
+			zap.Strings("tags", []string{"a", "b"}),
+			zap.Object("user", user{id: 7, name: "ana"}),
+			zap.Namespace("http"),
+			zap.String("method", "GET"),
+		)
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.Equal(t, map[string]interface{}{
+			"logger.name":  "orders",
+			"service.tier": "web",
+			"status":       int64(200),
+			"retries":      int64(2),
+			"ratio":        0.5,
+			"cached":       true,
+			"elapsed":      "1.5s",
+			"at":           "2026-01-02T03:04:05Z",
+			"error":        "timeout",
+			"tags":         `["a","b"]`,
+			"user.id":      int64(7),
+			"user.name":    "ana",
+			"http.method":  "GET",
+		}, logs[0].Attributes)
+	})
+
+	t.Run("should correlate entries with trace fields", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := zap.New(tfzap.NewCore(kit.Client(), nil))
+
+		logger.Info("in span", tfzap.TraceFields(spanContext(t))...)
+		logger.Info("no span", tfzap.TraceFields(context.Background())...)
+
+		logs := kit.Logs()
+		require.Len(t, logs, 2)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", logs[0].TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", logs[0].SpanID)
+		assert.NotContains(t, logs[0].Attributes, tfzap.TraceIDKey)
+		assert.Empty(t, logs[1].TraceID)
+	})
+
+	t.Run("should add the caller and stack", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := zap.New(tfzap.NewCore(kit.Client(), nil), zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+
+		logger.Error("with caller")
+
+		logs := kit.Logs()
+		require.Len(t, logs, 1)
+		assert.True(t, strings.HasSuffix(logs[0].Attributes["code.filepath"].(string), "zap_test.go"))
+		assert.Contains(t, logs[0].Attributes["code.function"], "TestCore")
+		assert.Contains(t, logs[0].Attributes["code.stacktrace"], "TestCore")
+	})
+
+	t.Run("should sample repeated messages", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		logger := zap.New(tfzap.NewCore(kit.Client(), &tfzap.Options{
+			Sampling: &tfzap.Sampling{Tick: time.Minute, First: 3, Thereafter: 10},
+		}))
+
+		for range 25 {
+			logger.Error("connection refused")
+		}
+		logger.Error("other message")
+
+		// 3 first, then the 10th and 20th of the remaining 22, and the other message
+		assert.Len(t, kit.Logs(), 6)
+	})
+
+	t.Run("should tee an existing logger", func(t *testing.T) {
+		kit := telemetryflowtest.New(t)
+		local, observed := observer.New(zapcore.DebugLevel)
+		logger := zap.New(local).WithOptions(tfzap.WrapCore(kit.Client(), nil))
+
+		logger.Debug("local only")
+		logger.Info("both", tfzap.TraceFields(spanContext(t))...)
+		require.NoError(t, logger.Sync())
+
+		assert.Equal(t, 2, observed.Len())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", observed.All()[1].ContextMap()[tfzap.TraceIDKey])
+		require.Len(t, kit.Logs(), 1)
+		assert.Equal(t, "both", kit.Logs()[0].Body)
+	})
+}